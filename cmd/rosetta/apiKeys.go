@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-rosetta/server/services"
+)
+
+const (
+	// Access to the data endpoints (network, block, account, mempool, events, search)
+	scopeData = "data"
+	// Access to the construction endpoints (except for submit)
+	scopeConstruction = "construction"
+	// Access to /construction/submit (broadcasting transactions)
+	scopeSubmit = "submit"
+	// Access to the pprof endpoints (if enabled)
+	scopeAdmin = "admin"
+)
+
+const endpointSubmit = "/construction/submit"
+
+var knownScopes = map[string]struct{}{
+	scopeData:         {},
+	scopeConstruction: {},
+	scopeSubmit:       {},
+	scopeAdmin:        {},
+}
+
+// The submitted transactions are logged (per API key) by a dedicated logger, so that its level can be set independently (e.g. "audit:INFO")
+var auditLog = logger.GetOrCreate("audit")
+
+// apiKeyNameContextKey is the key of the request context value holding the name of the (authenticated) API key
+type apiKeyNameContextKey struct{}
+
+// getAuthenticatedApiKeyName returns the name of the API key of a request, if authenticated by apiKeysMiddleware
+func getAuthenticatedApiKeyName(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(apiKeyNameContextKey{}).(string)
+	return name, ok
+}
+
+// apiKey is an entry of the API keys config file
+type apiKey struct {
+	Name   string   `json:"name"`
+	Key    string   `json:"key"`
+	Scopes []string `json:"scopes"`
+}
+
+func (key *apiKey) hasScope(scope string) bool {
+	for _, item := range key.Scopes {
+		if item == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// apiKeys holds the known API keys, indexed by their value
+type apiKeys struct {
+	byKey map[string]*apiKey
+}
+
+func newApiKeys(keys []apiKey) (*apiKeys, error) {
+	registry := &apiKeys{
+		byKey: make(map[string]*apiKey),
+	}
+
+	for i := range keys {
+		key := &keys[i]
+
+		if len(key.Name) == 0 || len(key.Key) == 0 {
+			return nil, fmt.Errorf("API key at index %d: both name and key must be provided", i)
+		}
+
+		if _, ok := registry.byKey[key.Key]; ok {
+			return nil, fmt.Errorf("API key %s: duplicated key", key.Name)
+		}
+
+		for _, scope := range key.Scopes {
+			if _, ok := knownScopes[scope]; !ok {
+				return nil, fmt.Errorf("API key %s: unknown scope %s", key.Name, scope)
+			}
+		}
+
+		registry.byKey[key.Key] = key
+	}
+
+	return registry, nil
+}
+
+func (registry *apiKeys) get(key string) (*apiKey, bool) {
+	found, ok := registry.byKey[key]
+	return found, ok
+}
+
+// decideApiKeys loads the API keys, if a config file is provided (otherwise, authentication is disabled, and nil is returned)
+func decideApiKeys(configFileApiKeys string) (*apiKeys, error) {
+	if len(configFileApiKeys) == 0 {
+		return nil, nil
+	}
+
+	return loadConfigOfApiKeys(configFileApiKeys)
+}
+
+func loadConfigOfApiKeys(configFile string) (*apiKeys, error) {
+	fileContent, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("error when reading API keys config file: %w", err)
+	}
+
+	var keys []apiKey
+
+	err = json.Unmarshal(fileContent, &keys)
+	if err != nil {
+		return nil, fmt.Errorf("error when loading API keys from file: %w", err)
+	}
+
+	return newApiKeys(keys)
+}
+
+// getRequiredScope returns the scope required in order to access an endpoint
+func getRequiredScope(endpoint string) string {
+	if endpoint == endpointSubmit {
+		return scopeSubmit
+	}
+	if strings.HasPrefix(endpoint, "/construction/") {
+		return scopeConstruction
+	}
+	if strings.HasPrefix(endpoint, "/debug/") {
+		return scopeAdmin
+	}
+
+	return scopeData
+}
+
+// apiKeysMiddleware rejects the requests without a known API key, or whose API key lacks the scope required by the endpoint.
+// The name of the (authenticated) API key is passed down, in the request context (e.g. for rate limiting).
+// Additionally, it logs the submitted transactions (along with the name of the API key).
+// If no API keys are configured (nil registry), the requests are let through.
+func apiKeysMiddleware(apiKeyHeader string, registry *apiKeys, handler http.Handler) http.Handler {
+	if registry == nil {
+		return handler
+	}
+
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		endpoint := request.URL.Path
+
+		key, ok := registry.get(request.Header.Get(apiKeyHeader))
+		if !ok {
+			log.Debug("apiKeysMiddleware: missing or unknown API key", "endpoint", endpoint, "remoteAddr", request.RemoteAddr)
+			writeRosettaError(writer, services.NewErrUnauthorized())
+			return
+		}
+
+		requiredScope := getRequiredScope(endpoint)
+		if !key.hasScope(requiredScope) {
+			log.Debug("apiKeysMiddleware: API key not allowed", "key", key.Name, "endpoint", endpoint, "requiredScope", requiredScope)
+			writeRosettaError(writer, services.NewErrForbidden(requiredScope))
+			return
+		}
+
+		request = request.WithContext(context.WithValue(request.Context(), apiKeyNameContextKey{}, key.Name))
+
+		if endpoint != endpointSubmit {
+			handler.ServeHTTP(writer, request)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: writer, statusCode: http.StatusOK}
+		handler.ServeHTTP(recorder, request)
+		auditSubmission(key, request, recorder)
+	})
+}
+
+func auditSubmission(key *apiKey, request *http.Request, recorder *responseRecorder) {
+	if recorder.statusCode == http.StatusOK {
+		response := &types.TransactionIdentifierResponse{}
+		err := json.Unmarshal(recorder.body.Bytes(), response)
+		if err == nil && response.TransactionIdentifier != nil {
+			auditLog.Info("transaction submitted", "key", key.Name, "remoteAddr", request.RemoteAddr, "txHash", response.TransactionIdentifier.Hash)
+			return
+		}
+	}
+
+	rosettaErr := &types.Error{}
+	_ = json.Unmarshal(recorder.body.Bytes(), rosettaErr)
+	auditLog.Info("transaction not submitted", "key", key.Name, "remoteAddr", request.RemoteAddr, "status", recorder.statusCode, "err", rosettaErr.Message)
+}
+
+// responseRecorder passes the response through, while keeping a copy of it (status code and body)
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (recorder *responseRecorder) WriteHeader(statusCode int) {
+	recorder.statusCode = statusCode
+	recorder.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (recorder *responseRecorder) Write(data []byte) (int, error) {
+	recorder.body.Write(data)
+	return recorder.ResponseWriter.Write(data)
+}
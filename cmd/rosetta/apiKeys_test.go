@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecideApiKeys(t *testing.T) {
+	t.Run("with success (file provided)", func(t *testing.T) {
+		registry, err := decideApiKeys("testdata/api-keys.json")
+		require.NoError(t, err)
+		require.Len(t, registry.byKey, 3)
+	})
+
+	t.Run("with success (file not provided)", func(t *testing.T) {
+		registry, err := decideApiKeys("")
+		require.NoError(t, err)
+		require.Nil(t, registry)
+	})
+}
+
+func TestLoadConfigOfApiKeys(t *testing.T) {
+	t.Run("with success", func(t *testing.T) {
+		registry, err := loadConfigOfApiKeys("testdata/api-keys.json")
+		require.NoError(t, err)
+
+		key, ok := registry.get("c41d07e2b95f8a3d")
+		require.True(t, ok)
+		require.Equal(t, "exchange", key.Name)
+		require.Equal(t, []string{"data", "construction", "submit"}, key.Scopes)
+
+		_, ok = registry.get("")
+		require.False(t, ok)
+	})
+
+	t.Run("with error (missing file)", func(t *testing.T) {
+		_, err := loadConfigOfApiKeys("testdata/missing-file.json")
+		require.ErrorContains(t, err, "error when reading API keys config file")
+	})
+
+	t.Run("with error (invalid file)", func(t *testing.T) {
+		_, err := loadConfigOfApiKeys("testdata/api-keys-bad.json")
+		require.ErrorContains(t, err, "error when loading API keys from file")
+	})
+}
+
+func TestNewApiKeys(t *testing.T) {
+	_, err := newApiKeys([]apiKey{{Name: "partner", Key: "", Scopes: []string{"data"}}})
+	require.ErrorContains(t, err, "both name and key must be provided")
+
+	_, err = newApiKeys([]apiKey{{Name: "partner", Key: "abba", Scopes: []string{"everything"}}})
+	require.ErrorContains(t, err, "unknown scope everything")
+
+	_, err = newApiKeys([]apiKey{
+		{Name: "partner", Key: "abba", Scopes: []string{"data"}},
+		{Name: "exchange", Key: "abba", Scopes: []string{"submit"}},
+	})
+	require.ErrorContains(t, err, "duplicated key")
+}
+
+func TestGetRequiredScope(t *testing.T) {
+	require.Equal(t, scopeData, getRequiredScope("/network/status"))
+	require.Equal(t, scopeData, getRequiredScope("/block/transaction"))
+	require.Equal(t, scopeData, getRequiredScope("/account/balance"))
+	require.Equal(t, scopeConstruction, getRequiredScope("/construction/payloads"))
+	require.Equal(t, scopeSubmit, getRequiredScope("/construction/submit"))
+	require.Equal(t, scopeAdmin, getRequiredScope("/debug/pprof/heap"))
+}
+
+func TestApiKeysMiddleware(t *testing.T) {
+	registry, err := loadConfigOfApiKeys("testdata/api-keys.json")
+	require.NoError(t, err)
+
+	var apiKeyName string
+
+	handler := apiKeysMiddleware("X-Api-Key", registry, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		apiKeyName, _ = getAuthenticatedApiKeyName(request.Context())
+
+		if request.URL.Path == "/construction/submit" {
+			_, _ = fmt.Fprint(writer, `{"transaction_identifier": {"hash": "aaaa"}}`)
+		}
+	}))
+
+	serve := func(endpoint string, key string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, endpoint, nil)
+		if len(key) > 0 {
+			request.Header.Set("X-Api-Key", key)
+		}
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	t.Run("missing or unknown key", func(t *testing.T) {
+		recorder := serve("/network/status", "")
+		require.Equal(t, http.StatusInternalServerError, recorder.Code)
+		require.Contains(t, recorder.Body.String(), "missing or unknown API key")
+
+		recorder = serve("/network/status", "abba")
+		require.Equal(t, http.StatusInternalServerError, recorder.Code)
+		require.Contains(t, recorder.Body.String(), "missing or unknown API key")
+	})
+
+	t.Run("read-only key", func(t *testing.T) {
+		recorder := serve("/block", "3f9a8c1e0b7d4a62")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Equal(t, "partner", apiKeyName)
+
+		recorder = serve("/construction/submit", "3f9a8c1e0b7d4a62")
+		require.Equal(t, http.StatusInternalServerError, recorder.Code)
+		require.Contains(t, recorder.Body.String(), "API key not allowed to access the endpoint")
+		require.Contains(t, recorder.Body.String(), `"requiredScope":"submit"`)
+
+		recorder = serve("/construction/payloads", "3f9a8c1e0b7d4a62")
+		require.Equal(t, http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.Run("submit key", func(t *testing.T) {
+		recorder := serve("/construction/submit", "c41d07e2b95f8a3d")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Equal(t, `{"transaction_identifier": {"hash": "aaaa"}}`, recorder.Body.String())
+
+		recorder = serve("/debug/pprof/heap", "c41d07e2b95f8a3d")
+		require.Equal(t, http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.Run("admin key", func(t *testing.T) {
+		recorder := serve("/debug/pprof/heap", "7e2b95f8a3dc41d0")
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		recorder = serve("/network/status", "7e2b95f8a3dc41d0")
+		require.Equal(t, http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.Run("authentication disabled", func(t *testing.T) {
+		handler := apiKeysMiddleware("X-Api-Key", nil, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {}))
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/construction/submit", nil))
+		require.Equal(t, http.StatusOK, recorder.Code)
+	})
+}
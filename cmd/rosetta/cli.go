@@ -1,7 +1,12 @@
 package main
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
 	"github.com/urfave/cli"
 )
 
@@ -9,11 +14,14 @@ var (
 	helpTemplate = `NAME:
    {{.Name}} - {{.Usage}}
 USAGE:
-   {{.HelpName}} {{if .VisibleFlags}}[global options]{{end}}
+   {{.HelpName}} {{if .VisibleFlags}}[global options]{{end}}{{if .Commands}} [command [command options]]{{end}}
    {{if len .Authors}}
 AUTHOR:
    {{range .Authors}}{{ . }}{{end}}
    {{end}}{{if .Commands}}
+COMMANDS:
+   {{range .VisibleCommands}}{{join .Names ", "}}{{"\t"}}{{.Usage}}
+   {{end}}
 GLOBAL OPTIONS:
    {{range .VisibleFlags}}{{.}}
    {{end}}
@@ -62,10 +70,22 @@ VERSION:
 
 	cliFlagObserverHttpUrl = cli.StringFlag{
 		Name:  "observer-http-url",
-		Usage: "Specifies the URL of the observer.",
+		Usage: "Specifies the URL of the observer. Multiple observers (of the same shard) can be provided, as comma-separated URLs, in the order of preference: requests are routed to the healthiest one.",
 		Value: "http://nowhere.localhost.local",
 	}
 
+	cliFlagObserversFirstHistoricalEpochs = cli.StringFlag{
+		Name:  "observers-first-historical-epochs",
+		Usage: "Specifies the first epoch with historical data available in each observer's database (comma-separated, in the order of --observer-http-url). Historical queries are routed to the observers holding the requested epoch. If not provided, --first-historical-epoch applies to all observers.",
+		Value: "",
+	}
+
+	cliFlagObserversHealthCheckInterval = cli.UintFlag{
+		Name:  "observers-health-check-interval",
+		Usage: "Specifies the interval (in seconds) for checking the health (reachability, sync state) of the observers. Only applicable when multiple observers are provided. 0 disables the health check.",
+		Value: 10,
+	}
+
 	cliFlagBlockchainName = cli.StringFlag{
 		Name:  "blockchain",
 		Usage: "Specifies the blockchain name (e.g. MultiversX).",
@@ -126,6 +146,12 @@ VERSION:
 		Value: 50000,
 	}
 
+	cliFlagGasLimitEstimationMargin = cli.UintFlag{
+		Name:  "gas-limit-estimation-margin",
+		Usage: "Specifies the safety margin (as a percentage) added on top of the gas limit estimated by the observer (for transaction construction).",
+		Value: 10,
+	}
+
 	cliFlagGasPerDataByte = cli.UintFlag{
 		Name:  "gas-per-data-byte",
 		Usage: "Specifies the gas required per data byte (for transaction construction).",
@@ -167,12 +193,23 @@ VERSION:
 		Usage: "Whether to handle balance changes of smart contracts or not.",
 	}
 
+	cliFlagShouldSimulateBeforeSubmit = cli.BoolFlag{
+		Name:  "simulate-before-submit",
+		Usage: "Whether to simulate (dry-run) transactions before broadcasting them. If the simulation fails, the transaction isn't broadcasted.",
+	}
+
 	cliFlagConfigFileCustomCurrencies = cli.StringFlag{
 		Name:     "config-custom-currencies",
 		Usage:    "Specifies the configuration file for custom currencies.",
 		Required: false,
 	}
 
+	cliFlagConfigFileApiKeys = cli.StringFlag{
+		Name:     "config-api-keys",
+		Usage:    "Specifies the configuration file for API keys (names, keys and scopes). If provided, requests must hold a known API key (see --api-key-header), whose scopes allow access to the endpoint.",
+		Required: false,
+	}
+
 	cliFlagActivationEpochSirius = cli.UintFlag{
 		Name:     "activation-epoch-sirius",
 		Usage:    "Deprecated (not used anymore).",
@@ -188,10 +225,232 @@ VERSION:
 		Value:  0,
 	}
 
+	cliFlagNetworkConfigRefreshInterval = cli.UintFlag{
+		Name:  "network-config-refresh-interval",
+		Usage: "Specifies the interval (in seconds) for re-fetching the network parameters (e.g. gas price, gas limits) from the observer. Explicitly provided parameters take precedence over the fetched ones. 0 disables the refresh (the network parameters are only fetched at startup).",
+		Value: 300,
+	}
+
+	cliFlagSubmissionsTrackingInterval = cli.UintFlag{
+		Name:  "submissions-tracking-interval",
+		Usage: "Specifies the interval (in seconds) for checking whether the broadcasted transactions have landed in a final block (or have been dropped). Must be greater than 0.",
+		Value: 6,
+	}
+
+	cliFlagEventsPollingInterval = cli.UintFlag{
+		Name:  "events-polling-interval",
+		Usage: "Specifies the interval (in seconds) for polling the observer for new (final) blocks, so that block events are recorded (for the Events API). 0 disables the polling.",
+		Value: 6,
+	}
+
+	cliFlagSearchIndexFolder = cli.StringFlag{
+		Name:  "search-index-folder",
+		Usage: "Specifies the folder of the local index of transactions, which backs the Search API. If not provided, the Search API is disabled.",
+		Value: "",
+	}
+
+	cliFlagSearchIndexingInterval = cli.UintFlag{
+		Name:  "search-indexing-interval",
+		Usage: "Specifies the interval (in seconds) for indexing the new (final) blocks, for the Search API. 0 disables the indexing.",
+		Value: 6,
+	}
+
+	cliFlagObserverRetries = cli.UintFlag{
+		Name:  "observer-retries",
+		Usage: "Specifies how many times an (idempotent) request is retried, while the observer is unavailable (unreachable).",
+		Value: 3,
+	}
+
+	cliFlagObserverRetryBackoff = cli.UintFlag{
+		Name:  "observer-retry-backoff",
+		Usage: "Specifies the delay (in milliseconds) before the first retry of a request. The delay doubles with each retry (see --observer-max-retry-backoff).",
+		Value: 200,
+	}
+
+	cliFlagObserverMaxRetryBackoff = cli.UintFlag{
+		Name:  "observer-max-retry-backoff",
+		Usage: "Specifies the maximum delay (in milliseconds) between the retries of a request.",
+		Value: 2000,
+	}
+
+	cliFlagCircuitBreakerThreshold = cli.UintFlag{
+		Name:  "circuit-breaker-threshold",
+		Usage: "Specifies the number of consecutive failed (observer unavailable) requests after which requests fail fast, for a while (see --circuit-breaker-open-duration). Use 0 to disable the circuit breaker.",
+		Value: 10,
+	}
+
+	cliFlagCircuitBreakerOpenDuration = cli.UintFlag{
+		Name:  "circuit-breaker-open-duration",
+		Usage: "Specifies for how long (in seconds) requests fail fast, once the circuit breaker opens. Afterwards, a single trial request is let through.",
+		Value: 5,
+	}
+
+	cliFlagObserverRequestTimeout = cli.UintFlag{
+		Name:  "observer-request-timeout",
+		Usage: "Specifies the timeout (in seconds) of a single request towards an observer.",
+		Value: 60,
+	}
+
+	cliFlagRequestTimeout = cli.UintFlag{
+		Name:  "request-timeout",
+		Usage: "Specifies the deadline (in seconds) of a Rosetta request, including the underlying requests towards the observer(s). Once the deadline passes (or the client disconnects), the underlying requests are abandoned. 0 means no deadline.",
+		Value: 60,
+	}
+
+	cliFlagRequestTimeoutsByEndpoint = cli.StringFlag{
+		Name:  "request-timeouts-by-endpoint",
+		Usage: "Specifies (comma-separated) deadlines (in seconds) for individual endpoints, overriding --request-timeout. E.g. \"/block=120,/account/balance=10\".",
+		Value: "",
+	}
+
+	cliFlagTlsCertFile = cli.StringFlag{
+		Name:  "tls-cert-file",
+		Usage: "Specifies the TLS certificate file (PEM). If provided (along with --tls-key-file), Rosetta endpoints are served over HTTPS.",
+		Value: "",
+	}
+
+	cliFlagTlsKeyFile = cli.StringFlag{
+		Name:  "tls-key-file",
+		Usage: "Specifies the TLS private key file (PEM).",
+		Value: "",
+	}
+
+	cliFlagTlsClientCAFile = cli.StringFlag{
+		Name:  "tls-client-ca-file",
+		Usage: "Specifies the CA certificates file (PEM) for verifying client certificates. If provided, clients must present a certificate signed by one of these CAs (mutual TLS).",
+		Value: "",
+	}
+
+	cliFlagHttpReadHeaderTimeout = cli.UintFlag{
+		Name:  "http-read-header-timeout",
+		Usage: "Specifies the timeout (in seconds) for reading the headers of a request. 0 means no timeout.",
+		Value: 10,
+	}
+
+	cliFlagHttpReadTimeout = cli.UintFlag{
+		Name:  "http-read-timeout",
+		Usage: "Specifies the timeout (in seconds) for reading a whole request, including the body. 0 means no timeout.",
+		Value: 30,
+	}
+
+	cliFlagHttpWriteTimeout = cli.UintFlag{
+		Name:  "http-write-timeout",
+		Usage: "Specifies the timeout (in seconds) for writing a response, starting once the request is read. Should exceed the request deadlines (see --request-timeout). 0 means no timeout.",
+		Value: 150,
+	}
+
+	cliFlagHttpIdleTimeout = cli.UintFlag{
+		Name:  "http-idle-timeout",
+		Usage: "Specifies for how long (in seconds) idle keep-alive connections are kept open. 0 means the same as --http-read-timeout.",
+		Value: 120,
+	}
+
+	cliFlagMaxRequestBodySize = cli.Uint64Flag{
+		Name:  "max-request-body-size",
+		Usage: "Specifies the maximum size (in bytes) of the body of a request. 0 means no limit.",
+		Value: 2097152,
+	}
+
+	cliFlagGzipMinResponseSize = cli.UintFlag{
+		Name:  "gzip-min-response-size",
+		Usage: "Specifies the minimum size (in bytes) of a response to be compressed (gzip), if the client accepts it. 0 means no compression.",
+		Value: 8192,
+	}
+
+	cliFlagShutdownTimeout = cli.UintFlag{
+		Name:  "shutdown-timeout",
+		Usage: "Specifies for how long (in seconds) in-flight requests are drained, on shutdown. Afterwards, the remaining ones are abandoned.",
+		Value: 30,
+	}
+
+	cliFlagRateLimit = cli.Float64Flag{
+		Name:  "rate-limit",
+		Usage: "Specifies the number of requests per second allowed for each client (and each endpoint group, e.g. \"block\", \"account\", \"construction\"). Clients are told apart by API key (see --api-key-header) or by IP address. 0 means no limit.",
+		Value: 0,
+	}
+
+	cliFlagRateLimitBurst = cli.UintFlag{
+		Name:  "rate-limit-burst",
+		Usage: "Specifies the number of requests a client can make at once, above --rate-limit. 0 means the same as the rate limit.",
+		Value: 0,
+	}
+
+	cliFlagRateLimitsByEndpointGroup = cli.StringFlag{
+		Name:  "rate-limits-by-endpoint-group",
+		Usage: "Specifies (comma-separated) rate limits for individual endpoint groups, overriding --rate-limit. Each limit is given as requests per second, optionally followed by the burst. E.g. \"block=5:10,construction=1\".",
+		Value: "",
+	}
+
+	cliFlagMaxConcurrentRequests = cli.UintFlag{
+		Name:  "max-concurrent-requests",
+		Usage: "Specifies the maximum number of concurrent requests that involve the observer(s), across all clients. Requests above the cap are rejected (with a retriable error). 0 means no cap.",
+		Value: 0,
+	}
+
+	cliFlagApiKeyHeader = cli.StringFlag{
+		Name:  "api-key-header",
+		Usage: "Specifies the HTTP header holding the API key of a client (used for authentication, if --config-api-keys is provided). Authenticated clients are told apart by API key (for rate limiting), the others by IP address.",
+		Value: "X-Api-Key",
+	}
+
+	cliFlagClientIpHeader = cli.StringFlag{
+		Name:  "client-ip-header",
+		Usage: "Specifies the HTTP header holding the IP address of a client, when running behind reverse proxies (e.g. \"X-Forwarded-For\"). If not provided, the address of the connection is used.",
+		Value: "",
+	}
+
+	cliFlagNumTrustedProxies = cli.UintFlag{
+		Name:  "num-trusted-proxies",
+		Usage: "Specifies the number of (trusted) reverse proxies in front of Rosetta, appending to --client-ip-header. The client address is the entry appended by the outermost trusted proxy (counting from the right), since the leftmost entries are set by the client.",
+		Value: 1,
+	}
+
+	cliFlagRecordDir = cli.StringFlag{
+		Name:  "record-dir",
+		Usage: "Specifies a folder where all the responses received from the observer (blocks, accounts, node status, epoch start etc.) are recorded. Useful for reproducing issues offline, with --replay-dir.",
+		Value: "",
+	}
+
+	cliFlagReplayDir = cli.StringFlag{
+		Name:  "replay-dir",
+		Usage: "Specifies a folder of (previously) recorded observer responses (see --record-dir). If provided, Rosetta is served entirely from these recordings, without contacting the observer.",
+		Value: "",
+	}
+
 	cliFlagShouldEnablePprofEndpoints = cli.BoolFlag{
 		Name:  "pprof",
 		Usage: "Whether to enable pprof HTTP endpoints.",
 	}
+
+	cliFlagReconcileFromBlock = cli.Uint64Flag{
+		Name:  "from-block",
+		Usage: "Specifies the first block (nonce) to reconcile.",
+		Value: 1,
+	}
+
+	cliFlagReconcileToBlock = cli.Uint64Flag{
+		Name:  "to-block",
+		Usage: "Specifies the last block (nonce) to reconcile. If not provided, the latest final block is used.",
+		Value: 0,
+	}
+
+	cliFlagReconcileAddresses = cli.StringFlag{
+		Name:     "addresses",
+		Usage:    "Specifies the (comma-separated) addresses to reconcile.",
+		Required: true,
+	}
+
+	cliFlagReconcileCurrencies = cli.StringFlag{
+		Name:  "currencies",
+		Usage: "Specifies the (comma-separated) currencies to reconcile. If not provided, the native currency and all the custom currencies are reconciled.",
+		Value: "",
+	}
+
+	cliFlagReconcileReportFile = cli.StringFlag{
+		Name:  "report-file",
+		Usage: "Specifies where to save the reconciliation report (JSON). If not provided, the report is only logged.",
+		Value: "",
+	}
 )
 
 func getAllCliFlags() []cli.Flag {
@@ -203,6 +462,8 @@ func getAllCliFlags() []cli.Flag {
 		cliFlagObserverActualShard,
 		cliFlagObserverProjectedShard,
 		cliFlagObserverHttpUrl,
+		cliFlagObserversFirstHistoricalEpochs,
+		cliFlagObserversHealthCheckInterval,
 		cliFlagBlockchainName,
 		cliFlagNetworkID,
 		cliFlagNetworkName,
@@ -213,20 +474,65 @@ func getAllCliFlags() []cli.Flag {
 		cliFlagMinGasLimit,
 		cliFlagExtraGasLimitGuardedTx,
 		cliFlagExtraGasLimitRelayedTxV3,
+		cliFlagGasLimitEstimationMargin,
 		cliFlagGasPerDataByte,
 		cliFlagGasPriceModifier,
 		cliFlagGasLimitCustomTransfer,
 		cliFlagNativeCurrencySymbol,
+		cliFlagNetworkConfigRefreshInterval,
 		cliFlagFirstHistoricalEpoch,
 		cliFlagNumHistoricalEpochs,
 		cliFlagShouldHandleContracts,
+		cliFlagShouldSimulateBeforeSubmit,
+		cliFlagSubmissionsTrackingInterval,
+		cliFlagEventsPollingInterval,
+		cliFlagSearchIndexFolder,
+		cliFlagSearchIndexingInterval,
+		cliFlagObserverRetries,
+		cliFlagObserverRetryBackoff,
+		cliFlagObserverMaxRetryBackoff,
+		cliFlagCircuitBreakerThreshold,
+		cliFlagCircuitBreakerOpenDuration,
+		cliFlagObserverRequestTimeout,
+		cliFlagRequestTimeout,
+		cliFlagRequestTimeoutsByEndpoint,
+		cliFlagTlsCertFile,
+		cliFlagTlsKeyFile,
+		cliFlagTlsClientCAFile,
+		cliFlagHttpReadHeaderTimeout,
+		cliFlagHttpReadTimeout,
+		cliFlagHttpWriteTimeout,
+		cliFlagHttpIdleTimeout,
+		cliFlagMaxRequestBodySize,
+		cliFlagGzipMinResponseSize,
+		cliFlagShutdownTimeout,
+		cliFlagRateLimit,
+		cliFlagRateLimitBurst,
+		cliFlagRateLimitsByEndpointGroup,
+		cliFlagMaxConcurrentRequests,
+		cliFlagApiKeyHeader,
+		cliFlagClientIpHeader,
+		cliFlagNumTrustedProxies,
+		cliFlagRecordDir,
+		cliFlagReplayDir,
 		cliFlagConfigFileCustomCurrencies,
+		cliFlagConfigFileApiKeys,
 		cliFlagActivationEpochSirius,
 		cliFlagActivationEpochSpica,
 		cliFlagShouldEnablePprofEndpoints,
 	}
 }
 
+func getReconcileCliFlags() []cli.Flag {
+	return []cli.Flag{
+		cliFlagReconcileFromBlock,
+		cliFlagReconcileToBlock,
+		cliFlagReconcileAddresses,
+		cliFlagReconcileCurrencies,
+		cliFlagReconcileReportFile,
+	}
+}
+
 type parsedCliFlags struct {
 	port                        int
 	offline                     bool
@@ -235,7 +541,7 @@ type parsedCliFlags struct {
 	observerActualShard         uint32
 	observerProjectedShard      uint32
 	observerProjectedShardIsSet bool
-	observerHttpUrl             string
+	observerHttpUrls            []string
 	blockchainName              string
 	networkID                   string
 	networkName                 string
@@ -246,15 +552,59 @@ type parsedCliFlags struct {
 	minGasLimit                 uint64
 	extraGasLimitGuardedTx      uint64
 	extraGasLimitRelayedTxV3    uint64
+	gasLimitEstimationMargin    uint64
 	gasPerDataByte              uint64
 	gasPriceModifier            float64
 	gasLimitCustomTransfer      uint64
+	networkConfigOverrides      resources.NetworkConfigOverrides
 	nativeCurrencySymbol        string
 	firstHistoricalEpoch        uint32
 	numHistoricalEpochs         uint32
 	shouldHandleContracts       bool
+	shouldSimulateBeforeSubmit  bool
 	configFileCustomCurrencies  string
+	configFileApiKeys           string
 	shouldEnablePprofEndpoints  bool
+	searchIndexFolder           string
+	recordDir                   string
+	replayDir                   string
+	numObserverRetries          uint32
+	circuitBreakerThreshold     uint32
+
+	observersFirstHistoricalEpochs string
+	requestTimeoutsByEndpoint      string
+
+	networkConfigRefreshIntervalInSeconds uint64
+	observersHealthCheckIntervalInSeconds uint64
+	submissionsTrackingIntervalInSeconds  uint64
+	eventsPollingIntervalInSeconds        uint64
+	searchIndexingIntervalInSeconds       uint64
+	observerRequestTimeoutInSeconds       uint64
+	requestTimeoutInSeconds               uint64
+
+	observerRetryBackoffInMilliseconds    uint64
+	observerMaxRetryBackoffInMilliseconds uint64
+	circuitBreakerOpenDurationInSeconds   uint64
+
+	rateLimit                 float64
+	rateLimitBurst            uint32
+	rateLimitsByEndpointGroup string
+	maxConcurrentRequests     uint32
+	apiKeyHeader              string
+	clientIpHeader            string
+	numTrustedProxies         uint32
+
+	tlsCertFile         string
+	tlsKeyFile          string
+	tlsClientCAFile     string
+	maxRequestBodySize  uint64
+	gzipMinResponseSize uint32
+
+	httpReadHeaderTimeoutInSeconds uint64
+	httpReadTimeoutInSeconds       uint64
+	httpWriteTimeoutInSeconds      uint64
+	httpIdleTimeoutInSeconds       uint64
+	shutdownTimeoutInSeconds       uint64
 }
 
 func getParsedCliFlags(ctx *cli.Context) parsedCliFlags {
@@ -266,7 +616,7 @@ func getParsedCliFlags(ctx *cli.Context) parsedCliFlags {
 		observerActualShard:         uint32(ctx.GlobalUint(cliFlagObserverActualShard.Name)),
 		observerProjectedShard:      uint32(ctx.GlobalUint(cliFlagObserverProjectedShard.Name)),
 		observerProjectedShardIsSet: ctx.GlobalIsSet(cliFlagObserverProjectedShard.Name),
-		observerHttpUrl:             ctx.GlobalString(cliFlagObserverHttpUrl.Name),
+		observerHttpUrls:            splitCommaSeparatedValues(ctx.GlobalString(cliFlagObserverHttpUrl.Name)),
 		blockchainName:              ctx.GlobalString(cliFlagBlockchainName.Name),
 		networkID:                   ctx.GlobalString(cliFlagNetworkID.Name),
 		networkName:                 ctx.GlobalString(cliFlagNetworkName.Name),
@@ -277,14 +627,128 @@ func getParsedCliFlags(ctx *cli.Context) parsedCliFlags {
 		minGasLimit:                 ctx.GlobalUint64(cliFlagMinGasLimit.Name),
 		extraGasLimitGuardedTx:      ctx.GlobalUint64(cliFlagExtraGasLimitGuardedTx.Name),
 		extraGasLimitRelayedTxV3:    ctx.GlobalUint64(cliFlagExtraGasLimitRelayedTxV3.Name),
+		gasLimitEstimationMargin:    ctx.GlobalUint64(cliFlagGasLimitEstimationMargin.Name),
 		gasPerDataByte:              ctx.GlobalUint64(cliFlagGasPerDataByte.Name),
 		gasPriceModifier:            ctx.GlobalFloat64(cliFlagGasPriceModifier.Name),
 		gasLimitCustomTransfer:      ctx.GlobalUint64(cliFlagGasLimitCustomTransfer.Name),
+		networkConfigOverrides:      getNetworkConfigOverrides(ctx),
 		nativeCurrencySymbol:        ctx.GlobalString(cliFlagNativeCurrencySymbol.Name),
 		firstHistoricalEpoch:        uint32(ctx.GlobalUint(cliFlagFirstHistoricalEpoch.Name)),
 		numHistoricalEpochs:         uint32(ctx.GlobalUint(cliFlagNumHistoricalEpochs.Name)),
 		shouldHandleContracts:       ctx.GlobalBool(cliFlagShouldHandleContracts.Name),
+		shouldSimulateBeforeSubmit:  ctx.GlobalBool(cliFlagShouldSimulateBeforeSubmit.Name),
 		configFileCustomCurrencies:  ctx.GlobalString(cliFlagConfigFileCustomCurrencies.Name),
+		configFileApiKeys:           ctx.GlobalString(cliFlagConfigFileApiKeys.Name),
 		shouldEnablePprofEndpoints:  ctx.GlobalBool(cliFlagShouldEnablePprofEndpoints.Name),
+		searchIndexFolder:           ctx.GlobalString(cliFlagSearchIndexFolder.Name),
+		recordDir:                   ctx.GlobalString(cliFlagRecordDir.Name),
+		replayDir:                   ctx.GlobalString(cliFlagReplayDir.Name),
+		numObserverRetries:          uint32(ctx.GlobalUint(cliFlagObserverRetries.Name)),
+		circuitBreakerThreshold:     uint32(ctx.GlobalUint(cliFlagCircuitBreakerThreshold.Name)),
+
+		observersFirstHistoricalEpochs: ctx.GlobalString(cliFlagObserversFirstHistoricalEpochs.Name),
+		requestTimeoutsByEndpoint:      ctx.GlobalString(cliFlagRequestTimeoutsByEndpoint.Name),
+
+		networkConfigRefreshIntervalInSeconds: ctx.GlobalUint64(cliFlagNetworkConfigRefreshInterval.Name),
+		observersHealthCheckIntervalInSeconds: ctx.GlobalUint64(cliFlagObserversHealthCheckInterval.Name),
+		submissionsTrackingIntervalInSeconds:  ctx.GlobalUint64(cliFlagSubmissionsTrackingInterval.Name),
+		eventsPollingIntervalInSeconds:        ctx.GlobalUint64(cliFlagEventsPollingInterval.Name),
+		searchIndexingIntervalInSeconds:       ctx.GlobalUint64(cliFlagSearchIndexingInterval.Name),
+		observerRequestTimeoutInSeconds:       ctx.GlobalUint64(cliFlagObserverRequestTimeout.Name),
+		requestTimeoutInSeconds:               ctx.GlobalUint64(cliFlagRequestTimeout.Name),
+
+		observerRetryBackoffInMilliseconds:    ctx.GlobalUint64(cliFlagObserverRetryBackoff.Name),
+		observerMaxRetryBackoffInMilliseconds: ctx.GlobalUint64(cliFlagObserverMaxRetryBackoff.Name),
+		circuitBreakerOpenDurationInSeconds:   ctx.GlobalUint64(cliFlagCircuitBreakerOpenDuration.Name),
+
+		rateLimit:                 ctx.GlobalFloat64(cliFlagRateLimit.Name),
+		rateLimitBurst:            uint32(ctx.GlobalUint(cliFlagRateLimitBurst.Name)),
+		rateLimitsByEndpointGroup: ctx.GlobalString(cliFlagRateLimitsByEndpointGroup.Name),
+		maxConcurrentRequests:     uint32(ctx.GlobalUint(cliFlagMaxConcurrentRequests.Name)),
+		apiKeyHeader:              ctx.GlobalString(cliFlagApiKeyHeader.Name),
+		clientIpHeader:            ctx.GlobalString(cliFlagClientIpHeader.Name),
+		numTrustedProxies:         uint32(ctx.GlobalUint(cliFlagNumTrustedProxies.Name)),
+
+		tlsCertFile:         ctx.GlobalString(cliFlagTlsCertFile.Name),
+		tlsKeyFile:          ctx.GlobalString(cliFlagTlsKeyFile.Name),
+		tlsClientCAFile:     ctx.GlobalString(cliFlagTlsClientCAFile.Name),
+		maxRequestBodySize:  ctx.GlobalUint64(cliFlagMaxRequestBodySize.Name),
+		gzipMinResponseSize: uint32(ctx.GlobalUint(cliFlagGzipMinResponseSize.Name)),
+
+		httpReadHeaderTimeoutInSeconds: ctx.GlobalUint64(cliFlagHttpReadHeaderTimeout.Name),
+		httpReadTimeoutInSeconds:       ctx.GlobalUint64(cliFlagHttpReadTimeout.Name),
+		httpWriteTimeoutInSeconds:      ctx.GlobalUint64(cliFlagHttpWriteTimeout.Name),
+		httpIdleTimeoutInSeconds:       ctx.GlobalUint64(cliFlagHttpIdleTimeout.Name),
+		shutdownTimeoutInSeconds:       ctx.GlobalUint64(cliFlagShutdownTimeout.Name),
+	}
+}
+
+// checkParsedCliFlags rejects the combinations of flags which cannot work
+func checkParsedCliFlags(cliFlags parsedCliFlags) error {
+	// Without tracking, the submitted transactions would be pending forever (and could not be submitted again, if dropped).
+	if cliFlags.submissionsTrackingIntervalInSeconds == 0 {
+		return fmt.Errorf("--%s must be greater than 0", cliFlagSubmissionsTrackingInterval.Name)
+	}
+
+	return nil
+}
+
+// getNetworkConfigOverrides flags the network parameters explicitly provided by the operator (which take precedence over the ones fetched from the observer)
+func getNetworkConfigOverrides(ctx *cli.Context) resources.NetworkConfigOverrides {
+	return resources.NetworkConfigOverrides{
+		MinGasPrice:              ctx.GlobalIsSet(cliFlagMinGasPrice.Name),
+		MinGasLimit:              ctx.GlobalIsSet(cliFlagMinGasLimit.Name),
+		GasPerDataByte:           ctx.GlobalIsSet(cliFlagGasPerDataByte.Name),
+		GasPriceModifier:         ctx.GlobalIsSet(cliFlagGasPriceModifier.Name),
+		ExtraGasLimitGuardedTx:   ctx.GlobalIsSet(cliFlagExtraGasLimitGuardedTx.Name),
+		ExtraGasLimitRelayedTxV3: ctx.GlobalIsSet(cliFlagExtraGasLimitRelayedTxV3.Name),
 	}
 }
+
+type parsedReconcileCliFlags struct {
+	fromBlock    uint64
+	toBlock      uint64
+	toBlockIsSet bool
+	addresses    []string
+	currencies   []string
+	reportFile   string
+}
+
+func getParsedReconcileCliFlags(ctx *cli.Context) parsedReconcileCliFlags {
+	return parsedReconcileCliFlags{
+		fromBlock:    ctx.Uint64(cliFlagReconcileFromBlock.Name),
+		toBlock:      ctx.Uint64(cliFlagReconcileToBlock.Name),
+		toBlockIsSet: ctx.IsSet(cliFlagReconcileToBlock.Name),
+		addresses:    splitCommaSeparatedValues(ctx.String(cliFlagReconcileAddresses.Name)),
+		currencies:   splitCommaSeparatedValues(ctx.String(cliFlagReconcileCurrencies.Name)),
+		reportFile:   ctx.String(cliFlagReconcileReportFile.Name),
+	}
+}
+
+func splitCommaSeparatedValues(input string) []string {
+	values := make([]string, 0)
+
+	for _, value := range strings.Split(input, ",") {
+		value = strings.TrimSpace(value)
+		if len(value) > 0 {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+func parseCommaSeparatedEpochs(input string) ([]uint32, error) {
+	epochs := make([]uint32, 0)
+
+	for _, value := range splitCommaSeparatedValues(input) {
+		epoch, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse epoch %s: %w", value, err)
+		}
+
+		epochs = append(epochs, uint32(epoch))
+	}
+
+	return epochs, nil
+}
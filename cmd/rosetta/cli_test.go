@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitCommaSeparatedValues(t *testing.T) {
+	require.Equal(t, []string{}, splitCommaSeparatedValues(""))
+	require.Equal(t, []string{"a"}, splitCommaSeparatedValues("a"))
+	require.Equal(t, []string{"a", "b", "c"}, splitCommaSeparatedValues("a, b,,c ,"))
+}
+
+func TestCheckParsedCliFlags(t *testing.T) {
+	err := checkParsedCliFlags(parsedCliFlags{submissionsTrackingIntervalInSeconds: 6})
+	require.Nil(t, err)
+
+	// Other intervals may be zero (disabled)
+	err = checkParsedCliFlags(parsedCliFlags{submissionsTrackingIntervalInSeconds: 6, eventsPollingIntervalInSeconds: 0})
+	require.Nil(t, err)
+
+	err = checkParsedCliFlags(parsedCliFlags{submissionsTrackingIntervalInSeconds: 0})
+	require.ErrorContains(t, err, "--submissions-tracking-interval must be greater than 0")
+}
+
+func TestParseCommaSeparatedEpochs(t *testing.T) {
+	epochs, err := parseCommaSeparatedEpochs("")
+	require.Nil(t, err)
+	require.Equal(t, []uint32{}, epochs)
+
+	epochs, err = parseCommaSeparatedEpochs("0, 1200")
+	require.Nil(t, err)
+	require.Equal(t, []uint32{0, 1200}, epochs)
+
+	_, err = parseCommaSeparatedEpochs("0,foo")
+	require.Error(t, err)
+
+	_, err = parseCommaSeparatedEpochs("-1")
+	require.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// requestDeadlines holds the deadlines of the Rosetta requests: a default one, and (optionally) one per endpoint
+type requestDeadlines struct {
+	defaultTimeout      time.Duration
+	timeoutsByEndpoints map[string]time.Duration
+}
+
+// newRequestDeadlines creates the deadlines, given the default timeout and the overrides by endpoint (e.g. "/block=120,/account/balance=10", in seconds)
+func newRequestDeadlines(defaultTimeout time.Duration, timeoutsByEndpoints string) (*requestDeadlines, error) {
+	deadlines := &requestDeadlines{
+		defaultTimeout:      defaultTimeout,
+		timeoutsByEndpoints: make(map[string]time.Duration),
+	}
+
+	for _, item := range splitCommaSeparatedValues(timeoutsByEndpoints) {
+		endpoint, timeoutInSeconds, ok := strings.Cut(item, "=")
+		endpoint = strings.TrimSpace(endpoint)
+		if !ok || !strings.HasPrefix(endpoint, "/") {
+			return nil, fmt.Errorf("bad endpoint timeout (expected \"/endpoint=seconds\"): %s", item)
+		}
+
+		timeout, err := strconv.ParseUint(strings.TrimSpace(timeoutInSeconds), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse timeout of endpoint %s: %w", endpoint, err)
+		}
+
+		deadlines.timeoutsByEndpoints[endpoint] = time.Duration(timeout) * time.Second
+	}
+
+	return deadlines, nil
+}
+
+// getTimeout returns the timeout of an endpoint (0 means no deadline)
+func (deadlines *requestDeadlines) getTimeout(endpoint string) time.Duration {
+	timeout, ok := deadlines.timeoutsByEndpoints[endpoint]
+	if ok {
+		return timeout
+	}
+
+	return deadlines.defaultTimeout
+}
+
+// getMaxTimeout returns the longest timeout, across all endpoints
+func (deadlines *requestDeadlines) getMaxTimeout() time.Duration {
+	maxTimeout := deadlines.defaultTimeout
+
+	for _, timeout := range deadlines.timeoutsByEndpoints {
+		if timeout > maxTimeout {
+			maxTimeout = timeout
+		}
+	}
+
+	return maxTimeout
+}
+
+// deadlinesMiddleware bounds the context of each request to the deadline of its endpoint.
+// The context is passed down to the requests towards the observer(s), which are abandoned once the deadline passes (or the client disconnects).
+func deadlinesMiddleware(deadlines *requestDeadlines, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		timeout := deadlines.getTimeout(request.URL.Path)
+		if timeout == 0 {
+			handler.ServeHTTP(writer, request)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(request.Context(), timeout)
+		defer cancel()
+
+		handler.ServeHTTP(writer, request.WithContext(ctx))
+	})
+}
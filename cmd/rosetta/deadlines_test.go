@@ -0,0 +1,52 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRequestDeadlines(t *testing.T) {
+	deadlines, err := newRequestDeadlines(60*time.Second, "")
+	require.Nil(t, err)
+	require.Equal(t, 60*time.Second, deadlines.getTimeout("/block"))
+
+	deadlines, err = newRequestDeadlines(60*time.Second, "/block=120, /account/balance = 10,/mempool=0")
+	require.Nil(t, err)
+	require.Equal(t, 120*time.Second, deadlines.getTimeout("/block"))
+	require.Equal(t, 10*time.Second, deadlines.getTimeout("/account/balance"))
+	require.Equal(t, time.Duration(0), deadlines.getTimeout("/mempool"))
+	require.Equal(t, 60*time.Second, deadlines.getTimeout("/block/transaction"))
+	require.Equal(t, 120*time.Second, deadlines.getMaxTimeout())
+
+	_, err = newRequestDeadlines(60*time.Second, "/block")
+	require.Error(t, err)
+
+	_, err = newRequestDeadlines(60*time.Second, "block=120")
+	require.Error(t, err)
+
+	_, err = newRequestDeadlines(60*time.Second, "/block=foo")
+	require.Error(t, err)
+}
+
+func TestDeadlinesMiddleware(t *testing.T) {
+	deadlines, err := newRequestDeadlines(0, "/block=120")
+	require.Nil(t, err)
+
+	var deadline time.Time
+	var hasDeadline bool
+
+	handler := deadlinesMiddleware(deadlines, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		deadline, hasDeadline = request.Context().Deadline()
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/block", nil))
+	require.True(t, hasDeadline)
+	require.WithinDuration(t, time.Now().Add(120*time.Second), deadline, time.Second)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/network/status", nil))
+	require.False(t, hasDeadline)
+}
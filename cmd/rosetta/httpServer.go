@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	"github.com/multiversx/mx-chain-rosetta/server/services"
+)
+
+type httpServerArgs struct {
+	port               int
+	tlsCertFile        string
+	tlsKeyFile         string
+	tlsClientCAFile    string
+	readHeaderTimeout  time.Duration
+	readTimeout        time.Duration
+	writeTimeout       time.Duration
+	idleTimeout        time.Duration
+	maxRequestBodySize int64
+	gzipMinSize        int
+}
+
+func (args httpServerArgs) isTlsEnabled() bool {
+	return len(args.tlsCertFile) > 0
+}
+
+func createHttpServer(args httpServerArgs, applyMiddlewares func(http.Handler) http.Handler, routers ...server.Router) (*http.Server, error) {
+	tlsConfig, err := createTlsConfig(args.tlsCertFile, args.tlsKeyFile, args.tlsClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	router := server.NewRouter(
+		routers...,
+	)
+
+	handler := gzipMiddleware(args.gzipMinSize, maxBodySizeMiddleware(args.maxRequestBodySize, applyMiddlewares(router)))
+	corsRouter := server.CorsMiddleware(handler)
+
+	httpServer := &http.Server{
+		Addr:              fmt.Sprintf(":%d", args.port),
+		Handler:           corsRouter,
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: args.readHeaderTimeout,
+		ReadTimeout:       args.readTimeout,
+		WriteTimeout:      args.writeTimeout,
+		IdleTimeout:       args.idleTimeout,
+	}
+
+	return httpServer, nil
+}
+
+// createTlsConfig creates the TLS config of the server (nil, if TLS isn't enabled).
+// If a client CA is provided, clients must present a certificate signed by it (mutual TLS).
+func createTlsConfig(certFile string, keyFile string, clientCAFile string) (*tls.Config, error) {
+	if len(certFile) == 0 && len(keyFile) == 0 {
+		if len(clientCAFile) > 0 {
+			return nil, errors.New("a client CA can only be used along with a TLS certificate and key")
+		}
+
+		return nil, nil
+	}
+
+	if len(certFile) == 0 || len(keyFile) == 0 {
+		return nil, errors.New("both the TLS certificate and key must be provided")
+	}
+
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error when loading TLS certificate and key: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{certificate},
+	}
+
+	if len(clientCAFile) == 0 {
+		return tlsConfig, nil
+	}
+
+	clientCA, err := os.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("error when reading TLS client CA file: %w", err)
+	}
+
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(clientCA) {
+		return nil, fmt.Errorf("no certificates found in TLS client CA file: %s", clientCAFile)
+	}
+
+	tlsConfig.ClientCAs = clientCAs
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	return tlsConfig, nil
+}
+
+// maxBodySizeMiddleware rejects the requests whose body exceeds the maximum size (0 means no limit)
+func maxBodySizeMiddleware(maxSize int64, handler http.Handler) http.Handler {
+	if maxSize == 0 {
+		return handler
+	}
+
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.ContentLength > maxSize {
+			writeRosettaError(writer, services.NewErrRequestBodyTooLarge(maxSize))
+			return
+		}
+
+		// The declared length might be missing (or wrong), thus the body is bounded, as well.
+		request.Body = http.MaxBytesReader(writer, request.Body, maxSize)
+		handler.ServeHTTP(writer, request)
+	})
+}
+
+var gzipWritersPool = sync.Pool{
+	New: func() interface{} {
+		return gzip.NewWriter(nil)
+	},
+}
+
+// gzipMiddleware compresses the responses of at least the given size (0 means no compression), if the client accepts gzip.
+// Smaller responses (e.g. most of the Rosetta responses, except for blocks) aren't worth compressing.
+func gzipMiddleware(minSize int, handler http.Handler) http.Handler {
+	if minSize == 0 {
+		return handler
+	}
+
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Add("Vary", "Accept-Encoding")
+
+		if !strings.Contains(request.Header.Get("Accept-Encoding"), "gzip") {
+			handler.ServeHTTP(writer, request)
+			return
+		}
+
+		gzipWriter := &gzipResponseWriter{
+			ResponseWriter: writer,
+			minSize:        minSize,
+			statusCode:     http.StatusOK,
+		}
+
+		defer gzipWriter.finish()
+		handler.ServeHTTP(gzipWriter, request)
+	})
+}
+
+// gzipResponseWriter holds back the response until it reaches the minimum size (to be compressed), or until it ends (to be sent as it is)
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	minSize     int
+	statusCode  int
+	wroteHeader bool
+	buffer      bytes.Buffer
+	gzipWriter  *gzip.Writer
+	passThrough bool
+}
+
+func (writer *gzipResponseWriter) WriteHeader(statusCode int) {
+	if writer.wroteHeader {
+		return
+	}
+
+	writer.statusCode = statusCode
+	writer.wroteHeader = true
+}
+
+func (writer *gzipResponseWriter) Write(data []byte) (int, error) {
+	writer.wroteHeader = true
+
+	if writer.passThrough {
+		return writer.ResponseWriter.Write(data)
+	}
+	if writer.gzipWriter != nil {
+		return writer.gzipWriter.Write(data)
+	}
+
+	writer.buffer.Write(data)
+	if writer.buffer.Len() < writer.minSize {
+		return len(data), nil
+	}
+
+	err := writer.startCompression()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+func (writer *gzipResponseWriter) startCompression() error {
+	header := writer.ResponseWriter.Header()
+
+	// Already encoded by the handler
+	if len(header.Get("Content-Encoding")) > 0 {
+		return writer.startPassThrough()
+	}
+
+	header.Set("Content-Encoding", "gzip")
+	header.Del("Content-Length")
+	writer.ResponseWriter.WriteHeader(writer.statusCode)
+
+	gzipWriter := gzipWritersPool.Get().(*gzip.Writer)
+	gzipWriter.Reset(writer.ResponseWriter)
+	writer.gzipWriter = gzipWriter
+
+	_, err := writer.gzipWriter.Write(writer.buffer.Bytes())
+	writer.buffer.Reset()
+	return err
+}
+
+func (writer *gzipResponseWriter) startPassThrough() error {
+	writer.passThrough = true
+	writer.ResponseWriter.WriteHeader(writer.statusCode)
+
+	_, err := writer.ResponseWriter.Write(writer.buffer.Bytes())
+	writer.buffer.Reset()
+	return err
+}
+
+func (writer *gzipResponseWriter) finish() {
+	if writer.gzipWriter != nil {
+		_ = writer.gzipWriter.Close()
+		gzipWritersPool.Put(writer.gzipWriter)
+		writer.gzipWriter = nil
+		return
+	}
+
+	if !writer.passThrough && writer.wroteHeader {
+		_ = writer.startPassThrough()
+	}
+}
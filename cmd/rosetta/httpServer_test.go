@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGzipMiddleware(t *testing.T) {
+	largeResponse := strings.Repeat("a", 2048)
+
+	handler := gzipMiddleware(1024, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/block":
+			writer.Header().Set("Content-Type", "application/json")
+			writer.WriteHeader(http.StatusOK)
+			// Written in chunks, to cross the threshold midway
+			_, _ = fmt.Fprint(writer, largeResponse[:1000])
+			_, _ = fmt.Fprint(writer, largeResponse[1000:])
+		case "/network/status":
+			_, _ = fmt.Fprint(writer, "small")
+		case "/error":
+			writer.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+
+	serve := func(endpoint string, acceptEncoding string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, endpoint, nil)
+		request.Header.Set("Accept-Encoding", acceptEncoding)
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	t.Run("large response", func(t *testing.T) {
+		recorder := serve("/block", "gzip, deflate")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Equal(t, "gzip", recorder.Header().Get("Content-Encoding"))
+		require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		require.Less(t, recorder.Body.Len(), len(largeResponse))
+
+		reader, err := gzip.NewReader(recorder.Body)
+		require.Nil(t, err)
+		decompressed, err := io.ReadAll(reader)
+		require.Nil(t, err)
+		require.Equal(t, largeResponse, string(decompressed))
+	})
+
+	t.Run("large response, gzip not accepted", func(t *testing.T) {
+		recorder := serve("/block", "")
+		require.Equal(t, "", recorder.Header().Get("Content-Encoding"))
+		require.Equal(t, largeResponse, recorder.Body.String())
+	})
+
+	t.Run("small response", func(t *testing.T) {
+		recorder := serve("/network/status", "gzip")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Equal(t, "", recorder.Header().Get("Content-Encoding"))
+		require.Equal(t, "small", recorder.Body.String())
+	})
+
+	t.Run("empty response", func(t *testing.T) {
+		recorder := serve("/error", "gzip")
+		require.Equal(t, http.StatusInternalServerError, recorder.Code)
+		require.Equal(t, "", recorder.Header().Get("Content-Encoding"))
+		require.Equal(t, 0, recorder.Body.Len())
+	})
+}
+
+func TestMaxBodySizeMiddleware(t *testing.T) {
+	handler := maxBodySizeMiddleware(16, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		_, err := io.ReadAll(request.Body)
+		if err != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+
+	// Within limit
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/block", strings.NewReader("{}")))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	// Declared length exceeds limit
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/block", strings.NewReader(strings.Repeat("a", 17))))
+	require.Equal(t, http.StatusInternalServerError, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "request body too large")
+
+	// Unknown length, exceeding limit
+	request := httptest.NewRequest(http.MethodPost, "/block", io.NopCloser(strings.NewReader(strings.Repeat("a", 17))))
+	request.ContentLength = -1
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestCreateTlsConfig(t *testing.T) {
+	folder := t.TempDir()
+	certFile, keyFile := createTestCertificate(t, folder, "server")
+
+	t.Run("TLS not enabled", func(t *testing.T) {
+		tlsConfig, err := createTlsConfig("", "", "")
+		require.Nil(t, err)
+		require.Nil(t, tlsConfig)
+	})
+
+	t.Run("with TLS", func(t *testing.T) {
+		tlsConfig, err := createTlsConfig(certFile, keyFile, "")
+		require.Nil(t, err)
+		require.Len(t, tlsConfig.Certificates, 1)
+		require.Equal(t, tls.NoClientCert, tlsConfig.ClientAuth)
+	})
+
+	t.Run("with mutual TLS", func(t *testing.T) {
+		tlsConfig, err := createTlsConfig(certFile, keyFile, certFile)
+		require.Nil(t, err)
+		require.NotNil(t, tlsConfig.ClientCAs)
+		require.Equal(t, tls.RequireAndVerifyClientCert, tlsConfig.ClientAuth)
+	})
+
+	t.Run("with errors", func(t *testing.T) {
+		_, err := createTlsConfig(certFile, "", "")
+		require.ErrorContains(t, err, "both the TLS certificate and key must be provided")
+
+		_, err = createTlsConfig("", "", certFile)
+		require.ErrorContains(t, err, "a client CA can only be used along with a TLS certificate and key")
+
+		_, err = createTlsConfig(certFile, path.Join(folder, "missing.pem"), "")
+		require.ErrorContains(t, err, "error when loading TLS certificate and key")
+
+		_, err = createTlsConfig(certFile, keyFile, keyFile)
+		require.ErrorContains(t, err, "no certificates found in TLS client CA file")
+	})
+}
+
+func TestCreateHttpServer_WithMutualTls(t *testing.T) {
+	folder := t.TempDir()
+	serverCertFile, serverKeyFile := createTestCertificate(t, folder, "server")
+	clientCertFile, clientKeyFile := createTestCertificate(t, folder, "client")
+
+	httpServer, err := createHttpServer(httpServerArgs{
+		tlsCertFile:     serverCertFile,
+		tlsKeyFile:      serverKeyFile,
+		tlsClientCAFile: clientCertFile,
+	}, func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			_, _ = fmt.Fprint(writer, "hello")
+		})
+	})
+	require.Nil(t, err)
+
+	testServer := httptest.NewUnstartedServer(httpServer.Handler)
+	testServer.TLS = httpServer.TLSConfig
+	testServer.StartTLS()
+	defer testServer.Close()
+
+	serverCertificate, err := os.ReadFile(serverCertFile)
+	require.Nil(t, err)
+	rootCAs := x509.NewCertPool()
+	rootCAs.AppendCertsFromPEM(serverCertificate)
+
+	clientCertificate, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+	require.Nil(t, err)
+
+	createClient := func(certificates []tls.Certificate) *http.Client {
+		return &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					RootCAs:      rootCAs,
+					Certificates: certificates,
+				},
+			},
+		}
+	}
+
+	// With client certificate
+	response, err := createClient([]tls.Certificate{clientCertificate}).Post(testServer.URL+"/network/list", "application/json", bytes.NewBufferString("{}"))
+	require.Nil(t, err)
+	body, _ := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	require.Equal(t, "hello", string(body))
+
+	// Without client certificate
+	_, err = createClient(nil).Post(testServer.URL+"/network/list", "application/json", bytes.NewBufferString("{}"))
+	require.Error(t, err)
+}
+
+// createTestCertificate creates a self-signed certificate (valid for localhost), saved as PEM files
+func createTestCertificate(t *testing.T, folder string, name string) (string, string) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.Nil(t, err)
+
+	privateKeyBytes, err := x509.MarshalECPrivateKey(privateKey)
+	require.Nil(t, err)
+
+	certFile := path.Join(folder, name+"-cert.pem")
+	keyFile := path.Join(folder, name+"-key.pem")
+
+	err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate}), 0600)
+	require.Nil(t, err)
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privateKeyBytes}), 0600)
+	require.Nil(t, err)
+
+	return certFile, keyFile
+}
@@ -2,13 +2,12 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"syscall"
 	"time"
 
-	"github.com/coinbase/rosetta-sdk-go/server"
 	"github.com/multiversx/mx-chain-rosetta/server/factory"
 	"github.com/multiversx/mx-chain-rosetta/version"
 	"github.com/urfave/cli"
@@ -37,6 +36,14 @@ func main() {
 	}
 
 	app.Action = startRosetta
+	app.Commands = []cli.Command{
+		{
+			Name:   "reconcile",
+			Usage:  "Checks the operations of /block against the balances of /account/balance, for a range of blocks and a set of addresses",
+			Flags:  getReconcileCliFlags(),
+			Action: reconcileBalances,
+		},
+	}
 
 	err := app.Run(os.Args)
 	if err != nil {
@@ -48,49 +55,50 @@ func main() {
 func startRosetta(ctx *cli.Context) error {
 	cliFlags := getParsedCliFlags(ctx)
 
-	fileLogging, err := initializeLogger(cliFlags.logsFolder, cliFlags.logLevel)
+	err := checkParsedCliFlags(cliFlags)
 	if err != nil {
 		return err
 	}
 
-	customCurrencies, err := decideCustomCurrencies(cliFlags.configFileCustomCurrencies)
+	fileLogging, err := initializeLogger(cliFlags.logsFolder, cliFlags.logLevel)
 	if err != nil {
 		return err
 	}
 
 	log.Info("Starting Rosetta...", "middleware", version.RosettaMiddlewareVersion, "specification", version.RosettaVersion)
 
-	networkProvider, err := factory.CreateNetworkProvider(factory.ArgsCreateNetworkProvider{
-		IsOffline:                   cliFlags.offline,
-		NumShards:                   cliFlags.numShards,
-		ObservedActualShard:         cliFlags.observerActualShard,
-		ObservedProjectedShard:      cliFlags.observerProjectedShard,
-		ObservedProjectedShardIsSet: cliFlags.observerProjectedShardIsSet,
-		ObserverUrl:                 cliFlags.observerHttpUrl,
-		BlockchainName:              cliFlags.blockchainName,
-		NetworkID:                   cliFlags.networkID,
-		NetworkName:                 cliFlags.networkName,
-		GasPerDataByte:              cliFlags.gasPerDataByte,
-		GasPriceModifier:            cliFlags.gasPriceModifier,
-		GasLimitCustomTransfer:      cliFlags.gasLimitCustomTransfer,
-		MinGasPrice:                 cliFlags.minGasPrice,
-		MinGasLimit:                 cliFlags.minGasLimit,
-		ExtraGasLimitGuardedTx:      cliFlags.extraGasLimitGuardedTx,
-		ExtraGasLimitRelayedTxV3:    cliFlags.extraGasLimitRelayedTxV3,
-		NativeCurrencySymbol:        cliFlags.nativeCurrencySymbol,
-		CustomCurrencies:            customCurrencies,
-		GenesisBlockHash:            cliFlags.genesisBlock,
-		FirstHistoricalEpoch:        cliFlags.firstHistoricalEpoch,
-		NumHistoricalEpochs:         cliFlags.numHistoricalEpochs,
-		ShouldHandleContracts:       cliFlags.shouldHandleContracts,
-	})
+	networkProvider, err := createNetworkProvider(cliFlags)
+	if err != nil {
+		return err
+	}
+
+	eventsService := factory.CreateEventsService(networkProvider)
+
+	searchService, err := factory.CreateSearchService(networkProvider, cliFlags.searchIndexFolder)
 	if err != nil {
 		return err
 	}
 
+	if !cliFlags.offline {
+		// If the observer isn't reachable yet, we start with the provided (or default) network parameters.
+		err = networkProvider.RefreshNetworkConfig()
+		if err != nil {
+			log.Warn("Cannot fetch network config from observer, using the provided one", "err", err)
+		}
+
+		networkProvider.StartNetworkConfigRefreshLoop(time.Duration(cliFlags.networkConfigRefreshIntervalInSeconds) * time.Second)
+		networkProvider.StartSubmissionsTrackingLoop(time.Duration(cliFlags.submissionsTrackingIntervalInSeconds) * time.Second)
+		networkProvider.StartObserversHealthCheckLoop(time.Duration(cliFlags.observersHealthCheckIntervalInSeconds) * time.Second)
+		eventsService.StartPollingLoop(time.Duration(cliFlags.eventsPollingIntervalInSeconds) * time.Second)
+
+		if searchService != nil {
+			searchService.StartIndexingLoop(time.Duration(cliFlags.searchIndexingIntervalInSeconds) * time.Second)
+		}
+	}
+
 	networkProvider.LogDescription()
 
-	controllers, err := factory.CreateControllers(networkProvider)
+	controllers, err := factory.CreateControllers(networkProvider, eventsService, searchService)
 	if err != nil {
 		return err
 	}
@@ -99,14 +107,69 @@ func startRosetta(ctx *cli.Context) error {
 		controllers = append(controllers, newPprofController())
 	}
 
-	httpServer, err := createHttpServer(cliFlags.port, controllers...)
+	deadlines, err := newRequestDeadlines(time.Duration(cliFlags.requestTimeoutInSeconds)*time.Second, cliFlags.requestTimeoutsByEndpoint)
+	if err != nil {
+		return err
+	}
+
+	rateLimitsByEndpointGroup, err := parseRateLimitsByEndpointGroup(cliFlags.rateLimitsByEndpointGroup)
+	if err != nil {
+		return err
+	}
+
+	identification := clientIdentification{
+		clientIpHeader:    cliFlags.clientIpHeader,
+		numTrustedProxies: cliFlags.numTrustedProxies,
+	}
+
+	rateLimiter := newRateLimiter(newRateLimit(cliFlags.rateLimit, cliFlags.rateLimitBurst), rateLimitsByEndpointGroup)
+	concurrencyLimiter := newConcurrencyLimiter(cliFlags.maxConcurrentRequests)
+
+	apiKeys, err := decideApiKeys(cliFlags.configFileApiKeys)
+	if err != nil {
+		return err
+	}
+
+	// Requests are first authenticated (if API keys are configured), then subject to the rate limits (and to the cap on concurrent requests), then bound to their deadline.
+	applyMiddlewares := func(handler http.Handler) http.Handler {
+		return apiKeysMiddleware(cliFlags.apiKeyHeader, apiKeys,
+			rateLimitsMiddleware(identification, rateLimiter, concurrencyLimiter,
+				deadlinesMiddleware(deadlines, handler)))
+	}
+
+	httpArgs := httpServerArgs{
+		port:               cliFlags.port,
+		tlsCertFile:        cliFlags.tlsCertFile,
+		tlsKeyFile:         cliFlags.tlsKeyFile,
+		tlsClientCAFile:    cliFlags.tlsClientCAFile,
+		readHeaderTimeout:  time.Duration(cliFlags.httpReadHeaderTimeoutInSeconds) * time.Second,
+		readTimeout:        time.Duration(cliFlags.httpReadTimeoutInSeconds) * time.Second,
+		writeTimeout:       time.Duration(cliFlags.httpWriteTimeoutInSeconds) * time.Second,
+		idleTimeout:        time.Duration(cliFlags.httpIdleTimeoutInSeconds) * time.Second,
+		maxRequestBodySize: int64(cliFlags.maxRequestBodySize),
+		gzipMinSize:        int(cliFlags.gzipMinResponseSize),
+	}
+
+	if httpArgs.writeTimeout > 0 && httpArgs.writeTimeout < deadlines.getMaxTimeout() {
+		log.Warn("The HTTP write timeout is shorter than the deadline of some requests, whose responses might be cut off", "writeTimeout", httpArgs.writeTimeout, "maxDeadline", deadlines.getMaxTimeout())
+	}
+
+	httpServer, err := createHttpServer(httpArgs, applyMiddlewares, controllers...)
 	if err != nil {
 		return err
 	}
 
 	go func() {
-		log.Info("Starting HTTP server...", "address", httpServer.Addr)
-		err := httpServer.ListenAndServe()
+		log.Info("Starting HTTP server...", "address", httpServer.Addr, "tls", httpArgs.isTlsEnabled())
+
+		var err error
+		if httpArgs.isTlsEnabled() {
+			// The certificate and key are already loaded, in the TLS config.
+			err = httpServer.ListenAndServeTLS("", "")
+		} else {
+			err = httpServer.ListenAndServe()
+		}
+
 		if err == http.ErrServerClosed {
 			log.Info("HTTP server stopped")
 		} else {
@@ -116,29 +179,77 @@ func startRosetta(ctx *cli.Context) error {
 
 	// Set up signal capturing
 	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt, os.Kill)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 	<-stop
 
-	shutdownContext, cancel := context.WithTimeout(context.Background(), time.Second)
+	shutdownTimeout := time.Duration(cliFlags.shutdownTimeoutInSeconds) * time.Second
+	log.Info("Shutting down, draining in-flight requests...", "timeout", shutdownTimeout)
+
+	// Stop accepting new requests, and wait for the in-flight ones to complete. Then, abandon the remaining ones (if any).
+	shutdownContext, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
-	_ = httpServer.Shutdown(shutdownContext)
+	err = httpServer.Shutdown(shutdownContext)
+	if err != nil {
+		log.Warn("Could not drain all in-flight requests", "err", err)
+	}
+
 	_ = httpServer.Close()
+	_ = eventsService.Close()
+	if searchService != nil {
+		_ = searchService.Close()
+	}
+	_ = networkProvider.Close()
 	_ = fileLogging.Close()
 
 	return nil
 }
 
-func createHttpServer(port int, routers ...server.Router) (*http.Server, error) {
-	router := server.NewRouter(
-		routers...,
-	)
-
-	corsRouter := server.CorsMiddleware(router)
+func createNetworkProvider(cliFlags parsedCliFlags) (factory.NetworkProvider, error) {
+	customCurrencies, err := decideCustomCurrencies(cliFlags.configFileCustomCurrencies)
+	if err != nil {
+		return nil, err
+	}
 
-	httpServer := &http.Server{
-		Addr:    fmt.Sprintf(":%d", port),
-		Handler: corsRouter,
+	observersFirstHistoricalEpochs, err := parseCommaSeparatedEpochs(cliFlags.observersFirstHistoricalEpochs)
+	if err != nil {
+		return nil, err
 	}
 
-	return httpServer, nil
+	return factory.CreateNetworkProvider(factory.ArgsCreateNetworkProvider{
+		IsOffline:                   cliFlags.offline,
+		NumShards:                   cliFlags.numShards,
+		ObservedActualShard:         cliFlags.observerActualShard,
+		ObservedProjectedShard:      cliFlags.observerProjectedShard,
+		ObservedProjectedShardIsSet: cliFlags.observerProjectedShardIsSet,
+		BlockchainName:              cliFlags.blockchainName,
+		NetworkID:                   cliFlags.networkID,
+		NetworkName:                 cliFlags.networkName,
+		GasPerDataByte:              cliFlags.gasPerDataByte,
+		GasPriceModifier:            cliFlags.gasPriceModifier,
+		GasLimitCustomTransfer:      cliFlags.gasLimitCustomTransfer,
+		MinGasPrice:                 cliFlags.minGasPrice,
+		MinGasLimit:                 cliFlags.minGasLimit,
+		ExtraGasLimitGuardedTx:      cliFlags.extraGasLimitGuardedTx,
+		ExtraGasLimitRelayedTxV3:    cliFlags.extraGasLimitRelayedTxV3,
+		GasLimitEstimationMargin:    cliFlags.gasLimitEstimationMargin,
+		NetworkConfigOverrides:      cliFlags.networkConfigOverrides,
+		NativeCurrencySymbol:        cliFlags.nativeCurrencySymbol,
+		CustomCurrencies:            customCurrencies,
+		GenesisBlockHash:            cliFlags.genesisBlock,
+		FirstHistoricalEpoch:        cliFlags.firstHistoricalEpoch,
+		NumHistoricalEpochs:         cliFlags.numHistoricalEpochs,
+		ShouldHandleContracts:       cliFlags.shouldHandleContracts,
+		ShouldSimulateBeforeSubmit:  cliFlags.shouldSimulateBeforeSubmit,
+		NumObserverRetries:          cliFlags.numObserverRetries,
+		ObserverRetryBackoff:        time.Duration(cliFlags.observerRetryBackoffInMilliseconds) * time.Millisecond,
+		ObserverMaxRetryBackoff:     time.Duration(cliFlags.observerMaxRetryBackoffInMilliseconds) * time.Millisecond,
+		CircuitBreakerThreshold:     cliFlags.circuitBreakerThreshold,
+		CircuitBreakerOpenDuration:  time.Duration(cliFlags.circuitBreakerOpenDurationInSeconds) * time.Second,
+		ObserverRequestTimeout:      time.Duration(cliFlags.observerRequestTimeoutInSeconds) * time.Second,
+		RecordDir:                   cliFlags.recordDir,
+		ReplayDir:                   cliFlags.replayDir,
+
+		ObserverUrls:                   cliFlags.observerHttpUrls,
+		ObserversFirstHistoricalEpochs: observersFirstHistoricalEpochs,
+	})
 }
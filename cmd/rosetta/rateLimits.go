@@ -0,0 +1,312 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/server/services"
+)
+
+const (
+	// Full (idle) buckets are dropped from time to time, since they are equivalent to new ones
+	rateLimiterSweepInterval = time.Minute
+	// The hint given to the clients rejected due to the cap on concurrent requests
+	retryAfterTooManyConcurrentRequests = time.Second
+)
+
+// Endpoints served without contacting the observer(s), thus not subject to the cap on concurrent requests
+var endpointsNotBoundToObserver = map[string]struct{}{
+	"/network/list":            {},
+	"/construction/derive":     {},
+	"/construction/preprocess": {},
+	"/construction/payloads":   {},
+	"/construction/combine":    {},
+	"/construction/parse":      {},
+	"/construction/hash":       {},
+	"/events/blocks":           {},
+	"/search/transactions":     {},
+}
+
+// rateLimit is a number of requests per second (0 means no limit), along with the size of the allowed bursts
+type rateLimit struct {
+	requestsPerSecond float64
+	burst             uint32
+}
+
+func newRateLimit(requestsPerSecond float64, burst uint32) rateLimit {
+	if burst == 0 {
+		// By default, a client can make (at once) the requests allowed in a second.
+		burst = uint32(math.Max(1, math.Ceil(requestsPerSecond)))
+	}
+
+	return rateLimit{
+		requestsPerSecond: requestsPerSecond,
+		burst:             burst,
+	}
+}
+
+// parseRateLimitsByEndpointGroup parses the rate limits of the endpoint groups, e.g. "block=5:10,construction=1" (requests per second, optionally followed by the burst)
+func parseRateLimitsByEndpointGroup(input string) (map[string]rateLimit, error) {
+	limits := make(map[string]rateLimit)
+
+	for _, item := range splitCommaSeparatedValues(input) {
+		group, limit, ok := strings.Cut(item, "=")
+		group = strings.TrimSpace(group)
+		if !ok || len(group) == 0 {
+			return nil, fmt.Errorf("bad rate limit (expected \"group=requestsPerSecond[:burst]\"): %s", item)
+		}
+
+		requestsPerSecondString, burstString, hasBurst := strings.Cut(limit, ":")
+
+		requestsPerSecond, err := strconv.ParseFloat(strings.TrimSpace(requestsPerSecondString), 64)
+		if err != nil || requestsPerSecond < 0 {
+			return nil, fmt.Errorf("bad rate limit of endpoint group %s: %s", group, limit)
+		}
+
+		burst := uint64(0)
+		if hasBurst {
+			burst, err = strconv.ParseUint(strings.TrimSpace(burstString), 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("bad burst of endpoint group %s: %w", group, err)
+			}
+		}
+
+		limits[group] = newRateLimit(requestsPerSecond, uint32(burst))
+	}
+
+	return limits, nil
+}
+
+// getEndpointGroup returns the group of an endpoint, which is the first segment of its path (e.g. "/block/transaction" belongs to the "block" group)
+func getEndpointGroup(endpoint string) string {
+	group, _, _ := strings.Cut(strings.TrimPrefix(endpoint, "/"), "/")
+	return group
+}
+
+type tokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+// rateLimiter applies token-bucket rate limits, per client and endpoint group
+type rateLimiter struct {
+	defaultLimit  rateLimit
+	limitsByGroup map[string]rateLimit
+	buckets       map[string]*tokenBucket
+	lastSweep     time.Time
+	mutex         sync.Mutex
+}
+
+func newRateLimiter(defaultLimit rateLimit, limitsByGroup map[string]rateLimit) *rateLimiter {
+	return &rateLimiter{
+		defaultLimit:  defaultLimit,
+		limitsByGroup: limitsByGroup,
+		buckets:       make(map[string]*tokenBucket),
+		lastSweep:     time.Now(),
+	}
+}
+
+func (limiter *rateLimiter) getLimit(group string) rateLimit {
+	limit, ok := limiter.limitsByGroup[group]
+	if ok {
+		return limit
+	}
+
+	return limiter.defaultLimit
+}
+
+// take consumes a token from the bucket of the client (for the given endpoint group).
+// If the bucket is empty, the request should be rejected: the time until the next token becomes available is returned.
+func (limiter *rateLimiter) take(client string, group string, now time.Time) (bool, time.Duration) {
+	limit := limiter.getLimit(group)
+	if limit.requestsPerSecond == 0 {
+		return true, 0
+	}
+
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	limiter.sweepIfNecessary(now)
+
+	key := group + "/" + client
+	bucket, ok := limiter.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{
+			tokens:     float64(limit.burst),
+			lastRefill: now,
+		}
+
+		limiter.buckets[key] = bucket
+	}
+
+	elapsed := now.Sub(bucket.lastRefill).Seconds()
+	bucket.tokens = math.Min(float64(limit.burst), bucket.tokens+elapsed*limit.requestsPerSecond)
+	bucket.lastRefill = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+
+	retryAfter := time.Duration((1 - bucket.tokens) / limit.requestsPerSecond * float64(time.Second))
+	return false, retryAfter
+}
+
+func (limiter *rateLimiter) sweepIfNecessary(now time.Time) {
+	if now.Sub(limiter.lastSweep) < rateLimiterSweepInterval {
+		return
+	}
+
+	for key, bucket := range limiter.buckets {
+		group, _, _ := strings.Cut(key, "/")
+		limit := limiter.getLimit(group)
+
+		timeToRefill := time.Duration(float64(limit.burst) / limit.requestsPerSecond * float64(time.Second))
+		if now.Sub(bucket.lastRefill) >= timeToRefill {
+			delete(limiter.buckets, key)
+		}
+	}
+
+	limiter.lastSweep = now
+}
+
+// concurrencyLimiter caps the number of concurrent (in-flight) requests (0 means no cap)
+type concurrencyLimiter struct {
+	slots chan struct{}
+}
+
+func newConcurrencyLimiter(maxConcurrentRequests uint32) *concurrencyLimiter {
+	if maxConcurrentRequests == 0 {
+		return &concurrencyLimiter{}
+	}
+
+	return &concurrencyLimiter{
+		slots: make(chan struct{}, maxConcurrentRequests),
+	}
+}
+
+// tryAcquire acquires a slot, without waiting for one; if acquired, the slot must be released afterwards
+func (limiter *concurrencyLimiter) tryAcquire() bool {
+	if limiter.slots == nil {
+		return true
+	}
+
+	select {
+	case limiter.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (limiter *concurrencyLimiter) release() {
+	if limiter.slots == nil {
+		return
+	}
+
+	<-limiter.slots
+}
+
+// clientIdentification decides how the clients are told apart (for rate limiting): by API key, if authenticated (see apiKeysMiddleware), otherwise by IP address.
+// Unauthenticated API keys are ignored, since a client could send a different (random) one with each request.
+type clientIdentification struct {
+	clientIpHeader string
+	// The number of (trusted) proxies in front of Rosetta, appending to the client IP header. The client address is the one appended by the outermost trusted proxy.
+	numTrustedProxies uint32
+}
+
+func (identification clientIdentification) identify(request *http.Request) string {
+	apiKeyName, ok := getAuthenticatedApiKeyName(request.Context())
+	if ok {
+		return "key:" + apiKeyName
+	}
+
+	forwardedIp, ok := identification.getForwardedIp(request)
+	if ok {
+		return "ip:" + forwardedIp
+	}
+
+	ip, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		return "ip:" + request.RemoteAddr
+	}
+
+	return "ip:" + ip
+}
+
+// getForwardedIp reads the client address from the client IP header, e.g. "X-Forwarded-For: client, proxy1, proxy2".
+// The leftmost entries are set by the client (thus, cannot be trusted): the entry appended by the outermost trusted proxy is used, instead (counting from the right).
+func (identification clientIdentification) getForwardedIp(request *http.Request) (string, bool) {
+	if len(identification.clientIpHeader) == 0 || identification.numTrustedProxies == 0 {
+		return "", false
+	}
+
+	entries := splitCommaSeparatedValues(strings.Join(request.Header.Values(identification.clientIpHeader), ","))
+	if len(entries) < int(identification.numTrustedProxies) {
+		// The request did not pass through all the trusted proxies.
+		return "", false
+	}
+
+	return entries[len(entries)-int(identification.numTrustedProxies)], true
+}
+
+// rateLimitsMiddleware rejects the requests of the clients exceeding their rate limit, and the (observer-bound) requests exceeding the cap on concurrent requests.
+// Rejected requests receive a retriable Rosetta error, along with a "Retry-After" hint.
+func rateLimitsMiddleware(
+	identification clientIdentification,
+	rateLimiter *rateLimiter,
+	concurrencyLimiter *concurrencyLimiter,
+	handler http.Handler,
+) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		endpoint := request.URL.Path
+		client := identification.identify(request)
+
+		ok, retryAfter := rateLimiter.take(client, getEndpointGroup(endpoint), time.Now())
+		if !ok {
+			log.Debug("rateLimitsMiddleware: rate limit exceeded", "client", client, "endpoint", endpoint, "retryAfter", retryAfter)
+			writeRejection(writer, services.NewErrRateLimitExceeded(retryAfter), retryAfter)
+			return
+		}
+
+		if isEndpointBoundToObserver(endpoint) {
+			if !concurrencyLimiter.tryAcquire() {
+				log.Debug("rateLimitsMiddleware: too many concurrent requests", "client", client, "endpoint", endpoint)
+				writeRejection(writer, services.NewErrTooManyConcurrentRequests(retryAfterTooManyConcurrentRequests), retryAfterTooManyConcurrentRequests)
+				return
+			}
+
+			defer concurrencyLimiter.release()
+		}
+
+		handler.ServeHTTP(writer, request)
+	})
+}
+
+func isEndpointBoundToObserver(endpoint string) bool {
+	if strings.HasPrefix(endpoint, "/debug/") {
+		return false
+	}
+
+	_, ok := endpointsNotBoundToObserver[endpoint]
+	return !ok
+}
+
+func writeRejection(writer http.ResponseWriter, rosettaErr *types.Error, retryAfter time.Duration) {
+	retryAfterSeconds := int(math.Ceil(retryAfter.Seconds()))
+	writer.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+	writeRosettaError(writer, rosettaErr)
+}
+
+// writeRosettaError responds with a Rosetta error. As for any other Rosetta error, the status code is 500 (which Rosetta clients expect, in order to decode the error).
+func writeRosettaError(writer http.ResponseWriter, rosettaErr *types.Error) {
+	server.EncodeJSONResponse(rosettaErr, http.StatusInternalServerError, writer)
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimitsByEndpointGroup(t *testing.T) {
+	limits, err := parseRateLimitsByEndpointGroup("")
+	require.Nil(t, err)
+	require.Len(t, limits, 0)
+
+	limits, err = parseRateLimitsByEndpointGroup("block=5:10, construction = 1,account=0.5")
+	require.Nil(t, err)
+	require.Equal(t, rateLimit{requestsPerSecond: 5, burst: 10}, limits["block"])
+	require.Equal(t, rateLimit{requestsPerSecond: 1, burst: 1}, limits["construction"])
+	require.Equal(t, rateLimit{requestsPerSecond: 0.5, burst: 1}, limits["account"])
+
+	_, err = parseRateLimitsByEndpointGroup("block")
+	require.Error(t, err)
+
+	_, err = parseRateLimitsByEndpointGroup("block=foo")
+	require.Error(t, err)
+
+	_, err = parseRateLimitsByEndpointGroup("block=-1")
+	require.Error(t, err)
+
+	_, err = parseRateLimitsByEndpointGroup("block=5:foo")
+	require.Error(t, err)
+}
+
+func TestGetEndpointGroup(t *testing.T) {
+	require.Equal(t, "block", getEndpointGroup("/block"))
+	require.Equal(t, "block", getEndpointGroup("/block/transaction"))
+	require.Equal(t, "network", getEndpointGroup("/network/status"))
+	require.Equal(t, "", getEndpointGroup("/"))
+}
+
+func TestRateLimiter_Take(t *testing.T) {
+	limiter := newRateLimiter(newRateLimit(2, 3), map[string]rateLimit{
+		"construction": newRateLimit(0, 0),
+	})
+
+	now := time.Now()
+
+	// The burst is consumed at once
+	for i := 0; i < 3; i++ {
+		ok, _ := limiter.take("alice", "block", now)
+		require.True(t, ok)
+	}
+
+	ok, retryAfter := limiter.take("alice", "block", now)
+	require.False(t, ok)
+	require.Equal(t, 500*time.Millisecond, retryAfter)
+
+	// Other clients and other endpoint groups have their own buckets
+	ok, _ = limiter.take("bob", "block", now)
+	require.True(t, ok)
+	ok, _ = limiter.take("alice", "account", now)
+	require.True(t, ok)
+
+	// Endpoint groups without limits
+	for i := 0; i < 100; i++ {
+		ok, _ = limiter.take("alice", "construction", now)
+		require.True(t, ok)
+	}
+
+	// The bucket is refilled
+	ok, _ = limiter.take("alice", "block", now.Add(500*time.Millisecond))
+	require.True(t, ok)
+	ok, _ = limiter.take("alice", "block", now.Add(500*time.Millisecond))
+	require.False(t, ok)
+
+	// Idle buckets are swept
+	require.Len(t, limiter.buckets, 3)
+	ok, _ = limiter.take("alice", "block", now.Add(2*rateLimiterSweepInterval))
+	require.True(t, ok)
+	require.Len(t, limiter.buckets, 1)
+}
+
+func TestConcurrencyLimiter(t *testing.T) {
+	limiter := newConcurrencyLimiter(2)
+	require.True(t, limiter.tryAcquire())
+	require.True(t, limiter.tryAcquire())
+	require.False(t, limiter.tryAcquire())
+
+	limiter.release()
+	require.True(t, limiter.tryAcquire())
+
+	limiter = newConcurrencyLimiter(0)
+	for i := 0; i < 100; i++ {
+		require.True(t, limiter.tryAcquire())
+	}
+}
+
+func TestClientIdentification_Identify(t *testing.T) {
+	request := httptest.NewRequest(http.MethodPost, "/block", nil)
+	request.RemoteAddr = "10.0.0.1:12345"
+	request.Header.Set("X-Forwarded-For", "1.1.1.1, 10.0.0.2")
+	request.Header.Add("X-Forwarded-For", "10.0.0.3")
+
+	require.Equal(t, "ip:10.0.0.1", clientIdentification{}.identify(request))
+	require.Equal(t, "ip:10.0.0.3", clientIdentification{clientIpHeader: "X-Forwarded-For", numTrustedProxies: 1}.identify(request))
+	require.Equal(t, "ip:10.0.0.2", clientIdentification{clientIpHeader: "X-Forwarded-For", numTrustedProxies: 2}.identify(request))
+	require.Equal(t, "ip:10.0.0.1", clientIdentification{clientIpHeader: "X-Forwarded-For", numTrustedProxies: 4}.identify(request))
+	require.Equal(t, "ip:10.0.0.1", clientIdentification{clientIpHeader: "X-Real-Ip", numTrustedProxies: 1}.identify(request))
+
+	// Unauthenticated API keys are ignored
+	request.Header.Set("X-Api-Key", "abba")
+	require.Equal(t, "ip:10.0.0.1", clientIdentification{}.identify(request))
+
+	// Authenticated API keys
+	request = request.WithContext(context.WithValue(request.Context(), apiKeyNameContextKey{}, "partner"))
+	require.Equal(t, "key:partner", clientIdentification{clientIpHeader: "X-Forwarded-For", numTrustedProxies: 1}.identify(request))
+}
+
+func TestRateLimitsMiddleware(t *testing.T) {
+	t.Run("rate limit exceeded", func(t *testing.T) {
+		handler := rateLimitsMiddleware(
+			clientIdentification{},
+			newRateLimiter(newRateLimit(1, 1), nil),
+			newConcurrencyLimiter(0),
+			http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {}),
+		)
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/block", nil))
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		// Sending a different (unauthenticated) API key does not escape the limit
+		request := httptest.NewRequest(http.MethodPost, "/block", nil)
+		request.Header.Set("X-Api-Key", "abba")
+
+		recorder = httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		require.Equal(t, http.StatusInternalServerError, recorder.Code)
+		require.Equal(t, "1", recorder.Header().Get("Retry-After"))
+
+		rosettaErr := &types.Error{}
+		err := json.Unmarshal(recorder.Body.Bytes(), rosettaErr)
+		require.Nil(t, err)
+		require.Equal(t, "rate limit exceeded", rosettaErr.Message)
+		require.True(t, rosettaErr.Retriable)
+		require.NotNil(t, rosettaErr.Details["retryAfterMilliseconds"])
+	})
+
+	t.Run("too many concurrent requests", func(t *testing.T) {
+		started := make(chan struct{})
+		unblock := make(chan struct{})
+
+		handler := rateLimitsMiddleware(
+			clientIdentification{},
+			newRateLimiter(newRateLimit(0, 0), nil),
+			newConcurrencyLimiter(1),
+			http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				if request.URL.Path == "/block" {
+					close(started)
+					<-unblock
+				}
+			}),
+		)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/block", nil))
+		}()
+
+		<-started
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/account/balance", nil))
+		require.Equal(t, http.StatusInternalServerError, recorder.Code)
+		require.Contains(t, recorder.Body.String(), "too many concurrent requests")
+
+		// Endpoints not bound to the observer aren't subject to the cap
+		recorder = httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/construction/derive", nil))
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		close(unblock)
+		wg.Wait()
+
+		recorder = httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/account/balance", nil))
+		require.Equal(t, http.StatusOK, recorder.Code)
+	})
+}
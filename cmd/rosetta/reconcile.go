@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/multiversx/mx-chain-rosetta/server/factory"
+	"github.com/multiversx/mx-chain-rosetta/server/services"
+	"github.com/urfave/cli"
+)
+
+var errReconciliationRequiresOnlineMode = errors.New("reconciliation requires online mode (an observer)")
+
+// reconcileBalances runs a balances reconciliation (in process), then reports the mismatches.
+// The command fails if mismatches are found, so that it can be used in automated checks.
+func reconcileBalances(ctx *cli.Context) error {
+	cliFlags := getParsedCliFlags(ctx)
+	reconcileFlags := getParsedReconcileCliFlags(ctx)
+
+	fileLogging, err := initializeLogger(cliFlags.logsFolder, cliFlags.logLevel)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = fileLogging.Close()
+	}()
+
+	if cliFlags.offline {
+		return errReconciliationRequiresOnlineMode
+	}
+
+	networkProvider, err := createNetworkProvider(cliFlags)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = networkProvider.Close()
+	}()
+
+	err = networkProvider.RefreshNetworkConfig()
+	if err != nil {
+		log.Warn("Cannot fetch network config from observer, using the provided one", "err", err)
+	}
+
+	// The reconciliation can be interrupted (a partial report is still produced).
+	reconcileContext, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	toBlock := reconcileFlags.toBlock
+	if !reconcileFlags.toBlockIsSet {
+		latestBlock, err := networkProvider.GetLatestBlockSummary(reconcileContext)
+		if err != nil {
+			return err
+		}
+
+		toBlock = latestBlock.Nonce
+	}
+
+	log.Info("Starting reconciliation...", "fromBlock", reconcileFlags.fromBlock, "toBlock", toBlock, "numAddresses", len(reconcileFlags.addresses))
+
+	reconciler := factory.CreateBalancesReconciler(networkProvider)
+	report, err := reconciler.Reconcile(reconcileContext, services.ArgsReconcileBalances{
+		FromBlock:  reconcileFlags.fromBlock,
+		ToBlock:    toBlock,
+		Addresses:  reconcileFlags.addresses,
+		Currencies: reconcileFlags.currencies,
+	})
+	if report == nil {
+		return err
+	}
+	if err != nil {
+		log.Error("Reconciliation stopped early", "err", err)
+	}
+
+	log.Info("Reconciliation done", "numBlocks", report.NumBlocks, "numChecks", report.NumChecks, "numMismatches", len(report.Mismatches))
+
+	errSave := saveReconciliationReport(reconcileFlags.reportFile, report)
+	if errSave != nil {
+		return errSave
+	}
+	if err != nil {
+		return err
+	}
+	if len(report.Mismatches) > 0 {
+		return fmt.Errorf("reconciliation found %d mismatches", len(report.Mismatches))
+	}
+
+	return nil
+}
+
+func saveReconciliationReport(reportFile string, report *services.ReconciliationReport) error {
+	if len(reportFile) == 0 {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(reportFile, data, 0644)
+	if err != nil {
+		return fmt.Errorf("error when saving reconciliation report: %w", err)
+	}
+
+	log.Info("Reconciliation report saved", "file", reportFile)
+	return nil
+}
@@ -0,0 +1,13 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+)
+
+var errRecordingNotFound = errors.New("recording not found")
+var errCannotSendTransactionWhileReplaying = errors.New("cannot send transactions while replaying recordings")
+
+func newErrRecordingNotFound(key string) error {
+	return fmt.Errorf("%w: %s", errRecordingNotFound, key)
+}
@@ -0,0 +1,29 @@
+package components
+
+import (
+	"context"
+
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-proxy-go/common"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+)
+
+type observerFacade interface {
+	CallGetRestEndPoint(ctx context.Context, baseUrl string, path string, value interface{}) (int, error)
+	CallPostRestEndPoint(ctx context.Context, baseUrl string, path string, data interface{}, response interface{}) (int, error)
+	ComputeShardId(pubKey []byte) uint32
+	SendTransaction(ctx context.Context, tx *data.Transaction) (int, string, error)
+	ComputeTransactionHash(tx *data.Transaction) (string, error)
+	GetTransactionByHashAndSenderAddress(ctx context.Context, hash string, sender string, withEvents bool) (*transaction.ApiTransactionResult, int, error)
+	GetBlockByHash(ctx context.Context, shardID uint32, hash string, options common.BlockQueryOptions) (*data.BlockApiResponse, error)
+	GetBlockByNonce(ctx context.Context, shardID uint32, nonce uint64, options common.BlockQueryOptions) (*data.BlockApiResponse, error)
+}
+
+type timedCache interface {
+	Put(key []byte, value interface{}, sizeInBytes int) (evicted bool)
+	Get(key []byte) (value interface{}, ok bool)
+}
+
+type observersPool interface {
+	MarkAsUnreachable(url string, err error)
+}
@@ -0,0 +1,5 @@
+package components
+
+import logger "github.com/multiversx/mx-chain-logger-go"
+
+var log = logger.GetOrCreate("server/factory/components")
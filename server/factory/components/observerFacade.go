@@ -1,18 +1,233 @@
 package components
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-proxy-go/common"
+	"github.com/multiversx/mx-chain-proxy-go/data"
 	"github.com/multiversx/mx-chain-proxy-go/facade"
 	"github.com/multiversx/mx-chain-proxy-go/process"
+	"github.com/multiversx/mx-chain-rosetta/server/provider"
+)
+
+const (
+	userAgent        = "MultiversX Rosetta / 1.0.0 <Requesting data from nodes>"
+	blockByHashPath  = "/block/by-hash"
+	blockByNoncePath = "/block/by-nonce"
+	blocksCacheScope = "block:shardID=%d"
 )
 
-// ObserverFacade holds (embeds) several components implemented in proxy-go
+// ObserverFacade holds (embeds) several components implemented in proxy-go.
+// The requests towards the observers are bound to the context of the originating request, thus they are abandoned once the client goes away (or the deadline passes).
 type ObserverFacade struct {
 	process.Processor
 	facade.TransactionProcessor
-	facade.BlockProcessor
+
+	HttpClient *http.Client
+	// Recently fetched blocks (by nonce or hash, and query options), as in proxy-go's "BlockProcessor"
+	BlocksCache timedCache
+	// The observers which cannot be reached are marked as such (thus, they are tried last)
+	ObserversPool observersPool
 }
 
 // ComputeShardId computes the shard ID for a given public key
 func (facade *ObserverFacade) ComputeShardId(pubKey []byte) uint32 {
 	return facade.GetShardCoordinator().ComputeId(pubKey)
 }
+
+// CallGetRestEndPoint calls a GET endpoint of an observer. If the observer cannot be reached at all, an "observer unreachable" error is returned (see "doRequest()").
+func (facade *ObserverFacade) CallGetRestEndPoint(ctx context.Context, baseUrl string, path string, value interface{}) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, baseUrl+path, nil)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	statusCode, responseBytes, err := facade.doRequest(request)
+	if err != nil {
+		return statusCode, err
+	}
+
+	err = json.Unmarshal(responseBytes, value)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	if statusCode != http.StatusOK {
+		return statusCode, errors.New(string(responseBytes))
+	}
+
+	return statusCode, nil
+}
+
+// CallPostRestEndPoint calls a POST endpoint of an observer. If the observer cannot be reached at all, an "observer unreachable" error is returned (see "doRequest()").
+func (facade *ObserverFacade) CallPostRestEndPoint(ctx context.Context, baseUrl string, path string, payload interface{}, response interface{}) (int, error) {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, baseUrl+path, bytes.NewReader(payloadBytes))
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	statusCode, responseBytes, err := facade.doRequest(request)
+	if err != nil {
+		return statusCode, err
+	}
+
+	if statusCode == http.StatusOK {
+		return statusCode, json.Unmarshal(responseBytes, response)
+	}
+
+	genericResponse := data.GenericAPIResponse{}
+	err = json.Unmarshal(responseBytes, &genericResponse)
+	if err != nil {
+		return statusCode, fmt.Errorf("error unmarshaling response: %w", err)
+	}
+
+	return statusCode, errors.New(genericResponse.Error)
+}
+
+// doRequest sends a request to an observer. If the observer cannot be reached (e.g. connection refused, timeout), an "observer unreachable" error is returned (without a status code),
+// so that it isn't confused with an error response (e.g. "not found") of a live observer.
+func (facade *ObserverFacade) doRequest(request *http.Request) (int, []byte, error) {
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("User-Agent", userAgent)
+
+	response, err := facade.HttpClient.Do(request)
+	if err != nil {
+		ctxErr := request.Context().Err()
+		if ctxErr != nil {
+			// The originating request is gone (cancelled or past its deadline): the observer isn't to blame.
+			return 0, nil, ctxErr
+		}
+
+		return 0, nil, provider.NewErrObserverUnreachable(err)
+	}
+
+	defer func() {
+		errNotCritical := response.Body.Close()
+		if errNotCritical != nil {
+			log.Warn("ObserverFacade.doRequest(): cannot close body", "err", errNotCritical)
+		}
+	}()
+
+	responseBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	return response.StatusCode, responseBytes, nil
+}
+
+// GetBlockByHash fetches a block from the observers of the given shard (the first one to respond wins, as in proxy-go's "BlockProcessor")
+func (facade *ObserverFacade) GetBlockByHash(ctx context.Context, shardID uint32, hash string, options common.BlockQueryOptions) (*data.BlockApiResponse, error) {
+	path := common.BuildUrlWithBlockQueryOptions(fmt.Sprintf("%s/%s", blockByHashPath, hash), options)
+	return facade.getBlock(ctx, shardID, path)
+}
+
+// GetBlockByNonce fetches a block from the observers of the given shard (the first one to respond wins, as in proxy-go's "BlockProcessor")
+func (facade *ObserverFacade) GetBlockByNonce(ctx context.Context, shardID uint32, nonce uint64, options common.BlockQueryOptions) (*data.BlockApiResponse, error) {
+	path := common.BuildUrlWithBlockQueryOptions(fmt.Sprintf("%s/%d", blockByNoncePath, nonce), options)
+	return facade.getBlock(ctx, shardID, path)
+}
+
+func (facade *ObserverFacade) getBlock(ctx context.Context, shardID uint32, path string) (*data.BlockApiResponse, error) {
+	cacheKey := []byte(fmt.Sprintf(blocksCacheScope, shardID) + path)
+
+	cachedResponse, ok := facade.BlocksCache.Get(cacheKey)
+	if ok {
+		return cachedResponse.(*data.BlockApiResponse), nil
+	}
+
+	observers, err := facade.GetObservers(shardID, data.AvailabilityAll)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &data.BlockApiResponse{}
+
+	for _, observer := range observers {
+		_, err := facade.CallGetRestEndPoint(ctx, observer.Address, path, response)
+		if err == nil {
+			_ = facade.BlocksCache.Put(cacheKey, response, 0)
+			return response, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if provider.IsObserverUnreachableError(err) {
+			facade.ObserversPool.MarkAsUnreachable(observer.Address, err)
+		}
+
+		log.Debug("ObserverFacade.getBlock()", "observer", observer.Address, "path", path, "err", err)
+	}
+
+	return nil, process.WrapObserversError(response.Error)
+}
+
+// SendTransaction broadcasts a transaction, by means of proxy-go's transaction processor.
+// The latter isn't aware of contexts: once the context is done, the caller stops waiting (though the transaction might still reach the observer).
+func (facade *ObserverFacade) SendTransaction(ctx context.Context, tx *data.Transaction) (int, string, error) {
+	type result struct {
+		statusCode int
+		hash       string
+		err        error
+	}
+
+	outcome, err := waitWithContext(ctx, func() result {
+		statusCode, hash, err := facade.TransactionProcessor.SendTransaction(tx)
+		return result{statusCode, hash, err}
+	})
+	if err != nil {
+		return 0, "", err
+	}
+
+	return outcome.statusCode, outcome.hash, outcome.err
+}
+
+// GetTransactionByHashAndSenderAddress fetches a transaction, by means of proxy-go's transaction processor (which isn't aware of contexts, see "SendTransaction()").
+func (facade *ObserverFacade) GetTransactionByHashAndSenderAddress(ctx context.Context, hash string, sender string, withEvents bool) (*transaction.ApiTransactionResult, int, error) {
+	type result struct {
+		tx         *transaction.ApiTransactionResult
+		statusCode int
+		err        error
+	}
+
+	outcome, err := waitWithContext(ctx, func() result {
+		tx, statusCode, err := facade.TransactionProcessor.GetTransactionByHashAndSenderAddress(hash, sender, withEvents)
+		return result{tx, statusCode, err}
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return outcome.tx, outcome.statusCode, outcome.err
+}
+
+// waitWithContext runs a (context-unaware) call in the background, and waits for its outcome, as long as the context isn't done
+func waitWithContext[T any](ctx context.Context, call func() T) (T, error) {
+	outcomeChannel := make(chan T, 1)
+
+	go func() {
+		outcomeChannel <- call()
+	}()
+
+	select {
+	case outcome := <-outcomeChannel:
+		return outcome, nil
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
@@ -0,0 +1,207 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-proxy-go/common"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-proxy-go/process"
+	"github.com/multiversx/mx-chain-rosetta/server/provider"
+	"github.com/multiversx/mx-chain-storage-go/timecache"
+	"github.com/stretchr/testify/require"
+)
+
+type processorStub struct {
+	process.Processor
+	observers []*data.NodeData
+}
+
+func (stub *processorStub) GetObservers(_ uint32, _ data.ObserverDataAvailabilityType) ([]*data.NodeData, error) {
+	return stub.observers, nil
+}
+
+type observersPoolStub struct {
+	unreachableUrls []string
+}
+
+func (stub *observersPoolStub) MarkAsUnreachable(url string, _ error) {
+	stub.unreachableUrls = append(stub.unreachableUrls, url)
+}
+
+func TestObserverFacade_CallGetRestEndPoint(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/node/status":
+			_, _ = fmt.Fprint(writer, `{"data": {"nonce": 42}}`)
+		case "/slow":
+			<-request.Context().Done()
+		case "/missing":
+			writer.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(writer, `{"error": "not found"}`)
+		default:
+			writer.WriteHeader(http.StatusInternalServerError)
+			_, _ = fmt.Fprint(writer, `{"error": "internal error"}`)
+		}
+	}))
+	defer server.Close()
+
+	facade := &ObserverFacade{HttpClient: &http.Client{}}
+
+	t.Run("with success", func(t *testing.T) {
+		response := &dummyResponse{}
+		statusCode, err := facade.CallGetRestEndPoint(context.Background(), server.URL, "/node/status", response)
+		require.Nil(t, err)
+		require.Equal(t, http.StatusOK, statusCode)
+		require.Equal(t, uint64(42), response.Data.Nonce)
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		response := &dummyResponse{}
+		statusCode, err := facade.CallGetRestEndPoint(context.Background(), server.URL, "/foo", response)
+		require.Equal(t, `{"error": "internal error"}`, err.Error())
+		require.Equal(t, http.StatusInternalServerError, statusCode)
+		require.Equal(t, "internal error", response.Error)
+	})
+
+	t.Run("with not found (live observer)", func(t *testing.T) {
+		statusCode, err := facade.CallGetRestEndPoint(context.Background(), server.URL, "/missing", &dummyResponse{})
+		require.NotNil(t, err)
+		require.False(t, provider.IsObserverUnreachableError(err))
+		require.Equal(t, http.StatusNotFound, statusCode)
+	})
+
+	t.Run("with unreachable observer", func(t *testing.T) {
+		statusCode, err := facade.CallGetRestEndPoint(context.Background(), "http://localhost:0", "/node/status", &dummyResponse{})
+		require.True(t, provider.IsObserverUnreachableError(err))
+		require.Equal(t, 0, statusCode)
+	})
+
+	t.Run("with deadline exceeded", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		statusCode, err := facade.CallGetRestEndPoint(ctx, server.URL, "/slow", &dummyResponse{})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, 0, statusCode)
+	})
+}
+
+func TestObserverFacade_CallPostRestEndPoint(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == "/vm-values/query" {
+			_, _ = fmt.Fprint(writer, `{"data": {"nonce": 43}}`)
+			return
+		}
+
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprint(writer, `{"error": "bad request"}`)
+	}))
+	defer server.Close()
+
+	facade := &ObserverFacade{HttpClient: &http.Client{}}
+
+	response := &dummyResponse{}
+	statusCode, err := facade.CallPostRestEndPoint(context.Background(), server.URL, "/vm-values/query", map[string]string{"scAddress": "foo"}, response)
+	require.Nil(t, err)
+	require.Equal(t, http.StatusOK, statusCode)
+	require.Equal(t, uint64(43), response.Data.Nonce)
+
+	statusCode, err = facade.CallPostRestEndPoint(context.Background(), server.URL, "/foo", nil, &dummyResponse{})
+	require.Equal(t, "bad request", err.Error())
+	require.Equal(t, http.StatusBadRequest, statusCode)
+}
+
+func TestObserverFacade_GetBlockByNonce(t *testing.T) {
+	t.Parallel()
+
+	numBlockRequests := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		numBlockRequests.Add(1)
+
+		if request.URL.Path == "/block/by-nonce/42" {
+			_, _ = fmt.Fprint(writer, `{"data": {"block": {"nonce": 42, "hash": "abba"}}, "code": "successful"}`)
+			return
+		}
+
+		writer.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprint(writer, `{"error": "block not found"}`)
+	}))
+	defer server.Close()
+
+	blocksCache, err := timecache.NewTimeCacher(timecache.ArgTimeCacher{
+		DefaultSpan: time.Minute,
+		CacheExpiry: time.Minute,
+	})
+	require.Nil(t, err)
+
+	observersPool := &observersPoolStub{}
+
+	facade := &ObserverFacade{
+		Processor: &processorStub{observers: []*data.NodeData{
+			{Address: "http://localhost:0"},
+			{Address: server.URL},
+		}},
+		HttpClient:    &http.Client{},
+		BlocksCache:   blocksCache,
+		ObserversPool: observersPool,
+	}
+
+	response, err := facade.GetBlockByNonce(context.Background(), 0, 42, common.BlockQueryOptions{})
+	require.Nil(t, err)
+	require.Equal(t, "abba", response.Data.Block.Hash)
+	require.Equal(t, int32(1), numBlockRequests.Load())
+	require.Equal(t, []string{"http://localhost:0"}, observersPool.unreachableUrls)
+
+	// Served from the cache.
+	response, err = facade.GetBlockByNonce(context.Background(), 0, 42, common.BlockQueryOptions{})
+	require.Nil(t, err)
+	require.Equal(t, "abba", response.Data.Block.Hash)
+	require.Equal(t, int32(1), numBlockRequests.Load())
+
+	// Other query options, not cached yet.
+	_, err = facade.GetBlockByNonce(context.Background(), 0, 42, common.BlockQueryOptions{WithTransactions: true})
+	require.Nil(t, err)
+	require.Equal(t, int32(2), numBlockRequests.Load())
+
+	// A live observer responding with "not found" isn't marked as unreachable.
+	_, err = facade.GetBlockByNonce(context.Background(), 0, 43, common.BlockQueryOptions{})
+	require.ErrorIs(t, err, process.ErrSendingRequest)
+	require.Contains(t, err.Error(), "block not found")
+	require.Equal(t, []string{"http://localhost:0", "http://localhost:0", "http://localhost:0"}, observersPool.unreachableUrls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = facade.GetBlockByNonce(ctx, 0, 44, common.BlockQueryOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestWaitWithContext(t *testing.T) {
+	t.Parallel()
+
+	outcome, err := waitWithContext(context.Background(), func() int {
+		return 42
+	})
+	require.Nil(t, err)
+	require.Equal(t, 42, outcome)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = waitWithContext(ctx, func() int {
+		time.Sleep(time.Second)
+		return 42
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,77 @@
+package components
+
+import (
+	"context"
+
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-proxy-go/common"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+)
+
+// RecordingObserverFacade forwards the requests to an observer facade, and saves the responses (blocks, accounts, node status, epoch start etc.) to disk.
+// The recordings can be later served by a ReplayingObserverFacade.
+type RecordingObserverFacade struct {
+	observerFacade
+	storage *recordingsStorage
+}
+
+// NewRecordingObserverFacade creates a new RecordingObserverFacade, which saves the recordings in the given folder (created if missing)
+func NewRecordingObserverFacade(facade observerFacade, folder string) (*RecordingObserverFacade, error) {
+	storage, err := newRecordingsStorage(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecordingObserverFacade{
+		observerFacade: facade,
+		storage:        storage,
+	}, nil
+}
+
+// CallGetRestEndPoint forwards the request, then records the response
+func (facade *RecordingObserverFacade) CallGetRestEndPoint(ctx context.Context, baseUrl string, path string, value interface{}) (int, error) {
+	statusCode, err := facade.observerFacade.CallGetRestEndPoint(ctx, baseUrl, path, value)
+	facade.record(ctx, getRestEndpointKey(path), statusCode, value, err)
+	return statusCode, err
+}
+
+// CallPostRestEndPoint forwards the request, then records the response
+func (facade *RecordingObserverFacade) CallPostRestEndPoint(ctx context.Context, baseUrl string, path string, data interface{}, response interface{}) (int, error) {
+	statusCode, err := facade.observerFacade.CallPostRestEndPoint(ctx, baseUrl, path, data, response)
+	facade.record(ctx, postRestEndpointKey(path, data), statusCode, response, err)
+	return statusCode, err
+}
+
+// GetTransactionByHashAndSenderAddress forwards the request, then records the response
+func (facade *RecordingObserverFacade) GetTransactionByHashAndSenderAddress(ctx context.Context, hash string, sender string, withEvents bool) (*transaction.ApiTransactionResult, int, error) {
+	tx, statusCode, err := facade.observerFacade.GetTransactionByHashAndSenderAddress(ctx, hash, sender, withEvents)
+	facade.record(ctx, transactionKey(hash, sender, withEvents), statusCode, tx, err)
+	return tx, statusCode, err
+}
+
+// GetBlockByHash forwards the request, then records the response
+func (facade *RecordingObserverFacade) GetBlockByHash(ctx context.Context, shardID uint32, hash string, options common.BlockQueryOptions) (*data.BlockApiResponse, error) {
+	response, err := facade.observerFacade.GetBlockByHash(ctx, shardID, hash, options)
+	facade.record(ctx, blockByHashKey(shardID, hash, options), 0, response, err)
+	return response, err
+}
+
+// GetBlockByNonce forwards the request, then records the response
+func (facade *RecordingObserverFacade) GetBlockByNonce(ctx context.Context, shardID uint32, nonce uint64, options common.BlockQueryOptions) (*data.BlockApiResponse, error) {
+	response, err := facade.observerFacade.GetBlockByNonce(ctx, shardID, nonce, options)
+	facade.record(ctx, blockByNonceKey(shardID, nonce, options), 0, response, err)
+	return response, err
+}
+
+// record saves a response; failing to do so doesn't fail the request (a warning is logged, instead).
+// Outcomes of abandoned requests (cancelled, or past their deadline) aren't saved, since they aren't actual responses of the observer.
+func (facade *RecordingObserverFacade) record(ctx context.Context, key string, statusCode int, response interface{}, responseErr error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	err := facade.storage.save(key, statusCode, response, responseErr)
+	if err != nil {
+		log.Warn("RecordingObserverFacade: cannot save recording", "key", key, "err", err)
+	}
+}
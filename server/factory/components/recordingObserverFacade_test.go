@@ -0,0 +1,147 @@
+package components
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/api"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-proxy-go/common"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+type dummyResponse struct {
+	Data struct {
+		Nonce uint64 `json:"nonce"`
+	} `json:"data"`
+	Error string `json:"error"`
+}
+
+func TestRecordingAndReplayingObserverFacades(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	blockQueryOptions := common.BlockQueryOptions{WithTransactions: true}
+
+	observer := testscommon.NewObserverFacadeMock()
+	observer.MockBlocks = []*api.Block{{Nonce: 42, Hash: "abba"}}
+	observer.MockTransactionsByHash["aaaa"] = &transaction.ApiTransactionResult{Hash: "aaaa", Nonce: 7}
+	observer.MockGetResponse = map[string]interface{}{"data": map[string]interface{}{"nonce": 42}}
+	observer.MockPostResponse = map[string]interface{}{"data": map[string]interface{}{"nonce": 43}}
+
+	recorder, err := NewRecordingObserverFacade(observer, folder)
+	require.Nil(t, err)
+
+	// Record
+	getResponse := &dummyResponse{}
+	statusCode, err := recorder.CallGetRestEndPoint(context.Background(), "http://observer", "/node/status", getResponse)
+	require.Nil(t, err)
+	require.Equal(t, 200, statusCode)
+	require.Equal(t, uint64(42), getResponse.Data.Nonce)
+
+	postResponse := &dummyResponse{}
+	_, err = recorder.CallPostRestEndPoint(context.Background(), "http://observer", "/vm-values/query", map[string]string{"scAddress": "foo"}, postResponse)
+	require.Nil(t, err)
+	require.Equal(t, uint64(43), postResponse.Data.Nonce)
+
+	_, err = recorder.GetBlockByNonce(context.Background(), 0, 42, blockQueryOptions)
+	require.Nil(t, err)
+
+	_, err = recorder.GetBlockByHash(context.Background(), 0, "abba", blockQueryOptions)
+	require.Nil(t, err)
+
+	_, _, err = recorder.GetTransactionByHashAndSenderAddress(context.Background(), "aaaa", "", true)
+	require.Nil(t, err)
+
+	// Errors are recorded, as well
+	observer.MockNextError = errors.New(`{"error": "account not found", "code": "internal_issue"}`)
+	_, err = recorder.CallGetRestEndPoint(context.Background(), "http://observer", "/address/erd1alice", &dummyResponse{})
+	require.NotNil(t, err)
+
+	// Replay (the observer isn't contacted anymore)
+	observer.MockNextError = errors.New("observer should not be contacted")
+
+	replayer, err := NewReplayingObserverFacade(observer, folder)
+	require.Nil(t, err)
+
+	getResponse = &dummyResponse{}
+	statusCode, err = replayer.CallGetRestEndPoint(context.Background(), "http://another-observer", "/node/status", getResponse)
+	require.Nil(t, err)
+	require.Equal(t, 200, statusCode)
+	require.Equal(t, uint64(42), getResponse.Data.Nonce)
+
+	postResponse = &dummyResponse{}
+	_, err = replayer.CallPostRestEndPoint(context.Background(), "http://observer", "/vm-values/query", map[string]string{"scAddress": "foo"}, postResponse)
+	require.Nil(t, err)
+	require.Equal(t, uint64(43), postResponse.Data.Nonce)
+
+	// Another payload
+	_, err = replayer.CallPostRestEndPoint(context.Background(), "http://observer", "/vm-values/query", map[string]string{"scAddress": "bar"}, &dummyResponse{})
+	require.ErrorIs(t, err, errRecordingNotFound)
+
+	blockResponse, err := replayer.GetBlockByNonce(context.Background(), 0, 42, blockQueryOptions)
+	require.Nil(t, err)
+	require.Equal(t, "abba", blockResponse.Data.Block.Hash)
+	require.Equal(t, data.ReturnCodeSuccess, blockResponse.Code)
+
+	blockResponse, err = replayer.GetBlockByHash(context.Background(), 0, "abba", blockQueryOptions)
+	require.Nil(t, err)
+	require.Equal(t, uint64(42), blockResponse.Data.Block.Nonce)
+
+	// Other options
+	_, err = replayer.GetBlockByNonce(context.Background(), 0, 42, common.BlockQueryOptions{})
+	require.ErrorIs(t, err, errRecordingNotFound)
+
+	tx, _, err := replayer.GetTransactionByHashAndSenderAddress(context.Background(), "aaaa", "", true)
+	require.Nil(t, err)
+	require.Equal(t, uint64(7), tx.Nonce)
+
+	_, err = replayer.CallGetRestEndPoint(context.Background(), "http://observer", "/address/erd1alice", &dummyResponse{})
+	require.Equal(t, `{"error": "account not found", "code": "internal_issue"}`, err.Error())
+
+	_, _, err = replayer.SendTransaction(context.Background(), &data.Transaction{})
+	require.ErrorIs(t, err, errCannotSendTransactionWhileReplaying)
+
+	// Local computations are still delegated
+	require.Equal(t, observer.ComputeShardId([]byte{0x01}), replayer.ComputeShardId([]byte{0x01}))
+}
+
+func TestNewReplayingObserverFacade_WhenNothingRecorded(t *testing.T) {
+	t.Parallel()
+
+	replayer, err := NewReplayingObserverFacade(testscommon.NewObserverFacadeMock(), t.TempDir())
+	require.Nil(t, err)
+
+	_, err = replayer.CallGetRestEndPoint(context.Background(), "http://observer", "/node/status", &dummyResponse{})
+	require.ErrorIs(t, err, errRecordingNotFound)
+
+	_, _, err = replayer.GetTransactionByHashAndSenderAddress(context.Background(), "aaaa", "", true)
+	require.ErrorIs(t, err, errRecordingNotFound)
+}
+
+func TestRecordingObserverFacade_DoesNotRecordAbandonedRequests(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+
+	observer := testscommon.NewObserverFacadeMock()
+	observer.MockNextError = context.Canceled
+
+	recorder, err := NewRecordingObserverFacade(observer, folder)
+	require.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = recorder.CallGetRestEndPoint(ctx, "http://observer", "/node/status", &dummyResponse{})
+	require.ErrorIs(t, err, context.Canceled)
+
+	replayer, err := NewReplayingObserverFacade(observer, folder)
+	require.Nil(t, err)
+
+	_, err = replayer.CallGetRestEndPoint(context.Background(), "http://observer", "/node/status", &dummyResponse{})
+	require.ErrorIs(t, err, errRecordingNotFound)
+}
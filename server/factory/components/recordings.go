@@ -0,0 +1,134 @@
+package components
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/multiversx/mx-chain-proxy-go/common"
+)
+
+// recording is the (on-disk) form of an observer response
+type recording struct {
+	Key        string          `json:"key"`
+	StatusCode int             `json:"statusCode"`
+	Error      string          `json:"error,omitempty"`
+	Response   json.RawMessage `json:"response"`
+}
+
+// recordingsStorage saves (and loads) observer responses, one file per request key.
+// The key of a request doesn't include the observer URL, so that recordings can be replayed regardless of where they were taken from.
+type recordingsStorage struct {
+	folder string
+}
+
+func newRecordingsStorage(folder string) (*recordingsStorage, error) {
+	err := os.MkdirAll(folder, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recordingsStorage{
+		folder: folder,
+	}, nil
+}
+
+func (storage *recordingsStorage) save(key string, statusCode int, response interface{}, responseErr error) error {
+	responseJson, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	record := &recording{
+		Key:        key,
+		StatusCode: statusCode,
+		Response:   responseJson,
+	}
+
+	if responseErr != nil {
+		record.Error = responseErr.Error()
+	}
+
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file, then rename it, so that a (concurrent) reader never sees a partially written recording.
+	file, err := os.CreateTemp(storage.folder, "tmp-*")
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(data)
+	errClose := file.Close()
+	if err == nil {
+		err = errClose
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return err
+	}
+
+	return os.Rename(file.Name(), storage.getPath(key))
+}
+
+// load loads a recording and unmarshals the recorded response into the given value.
+// The recorded error (if any) is returned as the error of the original request.
+func (storage *recordingsStorage) load(key string, response interface{}) (int, error) {
+	data, err := os.ReadFile(storage.getPath(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, newErrRecordingNotFound(key)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	record := &recording{}
+	err = json.Unmarshal(data, record)
+	if err != nil {
+		return 0, err
+	}
+
+	err = json.Unmarshal(record.Response, response)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(record.Error) > 0 {
+		return record.StatusCode, errors.New(record.Error)
+	}
+
+	return record.StatusCode, nil
+}
+
+func (storage *recordingsStorage) getPath(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(storage.folder, hex.EncodeToString(hash[:])+".json")
+}
+
+func getRestEndpointKey(path string) string {
+	return fmt.Sprintf("GET %s", path)
+}
+
+func postRestEndpointKey(path string, payload interface{}) string {
+	payloadJson, _ := json.Marshal(payload)
+	payloadHash := sha256.Sum256(payloadJson)
+	return fmt.Sprintf("POST %s %s", path, hex.EncodeToString(payloadHash[:]))
+}
+
+func blockByNonceKey(shardID uint32, nonce uint64, options common.BlockQueryOptions) string {
+	return fmt.Sprintf("block by nonce: shard = %d, nonce = %d, options = %+v", shardID, nonce, options)
+}
+
+func blockByHashKey(shardID uint32, hash string, options common.BlockQueryOptions) string {
+	return fmt.Sprintf("block by hash: shard = %d, hash = %s, options = %+v", shardID, hash, options)
+}
+
+func transactionKey(hash string, sender string, withEvents bool) string {
+	return fmt.Sprintf("transaction: hash = %s, sender = %s, withEvents = %t", hash, sender, withEvents)
+}
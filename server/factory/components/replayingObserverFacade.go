@@ -0,0 +1,77 @@
+package components
+
+import (
+	"context"
+
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-proxy-go/common"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+)
+
+// ReplayingObserverFacade serves the requests from the recordings saved by a RecordingObserverFacade, without contacting any observer.
+// Local computations (e.g. shard of an address, transaction hash) are still delegated to the wrapped facade.
+type ReplayingObserverFacade struct {
+	observerFacade
+	storage *recordingsStorage
+}
+
+// NewReplayingObserverFacade creates a new ReplayingObserverFacade, which loads the recordings from the given folder
+func NewReplayingObserverFacade(facade observerFacade, folder string) (*ReplayingObserverFacade, error) {
+	storage, err := newRecordingsStorage(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReplayingObserverFacade{
+		observerFacade: facade,
+		storage:        storage,
+	}, nil
+}
+
+// CallGetRestEndPoint replays a recorded response
+func (facade *ReplayingObserverFacade) CallGetRestEndPoint(_ context.Context, _ string, path string, value interface{}) (int, error) {
+	return facade.storage.load(getRestEndpointKey(path), value)
+}
+
+// CallPostRestEndPoint replays a recorded response
+func (facade *ReplayingObserverFacade) CallPostRestEndPoint(_ context.Context, _ string, path string, data interface{}, response interface{}) (int, error) {
+	return facade.storage.load(postRestEndpointKey(path, data), response)
+}
+
+// SendTransaction always fails, since transactions cannot be broadcasted while replaying
+func (facade *ReplayingObserverFacade) SendTransaction(_ context.Context, _ *data.Transaction) (int, string, error) {
+	return 0, "", errCannotSendTransactionWhileReplaying
+}
+
+// GetTransactionByHashAndSenderAddress replays a recorded response
+func (facade *ReplayingObserverFacade) GetTransactionByHashAndSenderAddress(_ context.Context, hash string, sender string, withEvents bool) (*transaction.ApiTransactionResult, int, error) {
+	var tx *transaction.ApiTransactionResult
+
+	statusCode, err := facade.storage.load(transactionKey(hash, sender, withEvents), &tx)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	return tx, statusCode, nil
+}
+
+// GetBlockByHash replays a recorded response
+func (facade *ReplayingObserverFacade) GetBlockByHash(_ context.Context, shardID uint32, hash string, options common.BlockQueryOptions) (*data.BlockApiResponse, error) {
+	return facade.loadBlockResponse(blockByHashKey(shardID, hash, options))
+}
+
+// GetBlockByNonce replays a recorded response
+func (facade *ReplayingObserverFacade) GetBlockByNonce(_ context.Context, shardID uint32, nonce uint64, options common.BlockQueryOptions) (*data.BlockApiResponse, error) {
+	return facade.loadBlockResponse(blockByNonceKey(shardID, nonce, options))
+}
+
+func (facade *ReplayingObserverFacade) loadBlockResponse(key string) (*data.BlockApiResponse, error) {
+	var response *data.BlockApiResponse
+
+	_, err := facade.storage.load(key, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
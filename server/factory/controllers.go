@@ -7,12 +7,36 @@ import (
 	"github.com/multiversx/mx-chain-rosetta/server/services"
 )
 
-func CreateControllers(networkProvider services.NetworkProvider) ([]server.Router, error) {
+// CreateEventsService creates the servicer of the Events API (its blocks poller has to be started separately, in online mode)
+func CreateEventsService(networkProvider services.NetworkProvider) services.EventsService {
+	return services.NewEventsService(networkProvider)
+}
+
+// CreateSearchService creates the servicer of the Search API, along with its local index (its indexer has to be started separately, in online mode).
+// If no index path is provided, or in offline mode (where nothing gets indexed), the Search API is disabled (nil is returned).
+func CreateSearchService(networkProvider services.NetworkProvider, indexPath string) (services.SearchService, error) {
+	if len(indexPath) == 0 || networkProvider.IsOffline() {
+		return nil, nil
+	}
+
+	return services.NewSearchService(services.ArgsNewSearchService{
+		Provider:  networkProvider,
+		IndexPath: indexPath,
+	})
+}
+
+// CreateBalancesReconciler creates the component which checks the operations (of /block) against the balances (of /account/balance), used by the "reconcile" command
+func CreateBalancesReconciler(networkProvider services.NetworkProvider) services.BalancesReconciler {
+	return services.NewBalancesReconciler(networkProvider)
+}
+
+// CreateControllers creates the API controllers; the search service is optional (nil, if the Search API isn't enabled)
+func CreateControllers(networkProvider services.NetworkProvider, eventsService services.EventsService, searchService services.SearchService) ([]server.Router, error) {
 	if networkProvider.IsOffline() {
 		return createOfflineControllers(networkProvider)
 	}
 
-	return createOnlineControllers(networkProvider)
+	return createOnlineControllers(networkProvider, eventsService, searchService)
 }
 
 func createOfflineControllers(networkProvider services.NetworkProvider) ([]server.Router, error) {
@@ -25,12 +49,15 @@ func createOfflineControllers(networkProvider services.NetworkProvider) ([]serve
 
 	offlineService := services.NewOfflineService()
 
-	networkService := services.NewNetworkService(networkProvider)
+	networkService := services.NewNetworkService(networkProvider, nil)
 	networkController := server.NewNetworkAPIController(networkService, asserterInstance)
 
 	accountController := server.NewAccountAPIController(offlineService, asserterInstance)
 	blockController := server.NewBlockAPIController(offlineService, asserterInstance)
 	mempoolController := server.NewMempoolAPIController(offlineService, asserterInstance)
+	callController := server.NewCallAPIController(offlineService, asserterInstance)
+	eventsController := server.NewEventsAPIController(offlineService, asserterInstance)
+	searchController := server.NewSearchAPIController(offlineService, asserterInstance)
 
 	constructionService := services.NewConstructionService(networkProvider)
 	constructionController := server.NewConstructionAPIController(constructionService, asserterInstance)
@@ -41,10 +68,13 @@ func createOfflineControllers(networkProvider services.NetworkProvider) ([]serve
 		blockController,
 		mempoolController,
 		constructionController,
+		callController,
+		eventsController,
+		searchController,
 	}, nil
 }
 
-func createOnlineControllers(networkProvider services.NetworkProvider) ([]server.Router, error) {
+func createOnlineControllers(networkProvider services.NetworkProvider, eventsService services.EventsService, searchService services.SearchService) ([]server.Router, error) {
 	log.Info("createOnlineControllers()")
 
 	asserterInstance, err := createAsserter(networkProvider)
@@ -52,7 +82,7 @@ func createOnlineControllers(networkProvider services.NetworkProvider) ([]server
 		return nil, err
 	}
 
-	networkService := services.NewNetworkService(networkProvider)
+	networkService := services.NewNetworkService(networkProvider, searchService)
 	networkController := server.NewNetworkAPIController(networkService, asserterInstance)
 
 	accountService := services.NewAccountService(networkProvider)
@@ -67,13 +97,27 @@ func createOnlineControllers(networkProvider services.NetworkProvider) ([]server
 	constructionService := services.NewConstructionService(networkProvider)
 	constructionController := server.NewConstructionAPIController(constructionService, asserterInstance)
 
-	return []server.Router{
+	callService := services.NewCallService(networkProvider)
+	callController := server.NewCallAPIController(callService, asserterInstance)
+
+	eventsController := server.NewEventsAPIController(eventsService, asserterInstance)
+
+	controllers := []server.Router{
 		networkController,
 		accountController,
 		blockController,
 		mempoolController,
 		constructionController,
-	}, nil
+		callController,
+		eventsController,
+	}
+
+	if searchService != nil {
+		searchController := server.NewSearchAPIController(searchService, asserterInstance)
+		controllers = append(controllers, searchController)
+	}
+
+	return controllers, nil
 }
 
 func createAsserter(networkProvider services.NetworkProvider) (*asserter.Asserter, error) {
@@ -87,7 +131,7 @@ func createAsserter(networkProvider services.NetworkProvider) (*asserter.Asserte
 				Network:    networkProvider.GetNetworkConfig().NetworkName,
 			},
 		},
-		nil,
+		services.SupportedCallMethods,
 		false,
 		"",
 	)
@@ -0,0 +1,6 @@
+package factory
+
+import "errors"
+
+var errRecordingOrReplayingInOfflineMode = errors.New("observer responses cannot be recorded or replayed in offline mode")
+var errBothRecordingAndReplaying = errors.New("observer responses cannot be both recorded and replayed")
@@ -1,10 +1,13 @@
 package factory
 
 import (
+	"context"
 	"math/big"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/data/api"
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-proxy-go/common"
 	"github.com/multiversx/mx-chain-proxy-go/data"
 	"github.com/multiversx/mx-chain-rosetta/server/resources"
 )
@@ -12,6 +15,7 @@ import (
 // NetworkProvider defines the actions that need to be performed by the component that handles network data fetching
 type NetworkProvider interface {
 	IsOffline() bool
+	ShouldSimulateBeforeSubmit() bool
 	GetBlockchainName() string
 	GetNativeCurrency() resources.Currency
 	GetCustomCurrencies() []resources.Currency
@@ -20,20 +24,49 @@ type NetworkProvider interface {
 	GetNetworkConfig() *resources.NetworkConfig
 	GetGenesisBlockSummary() *resources.BlockSummary
 	GetGenesisTimestamp() int64
-	GetGenesisBalances() ([]*resources.GenesisBalance, error)
-	GetNodeStatus() (*resources.AggregatedNodeStatus, error)
-	GetBlockByNonce(nonce uint64) (*api.Block, error)
-	GetBlockByHash(hash string) (*api.Block, error)
-	GetAccount(address string) (*resources.AccountOnBlock, error)
-	GetAccountBalance(address string, tokenIdentifier string, options resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error)
+	GetGenesisBalances(ctx context.Context) ([]*resources.GenesisBalance, error)
+	GetNodeStatus(ctx context.Context) (*resources.AggregatedNodeStatus, error)
+	GetBlockByNonce(ctx context.Context, nonce uint64) (*api.Block, error)
+	GetBlockSummaryByNonce(ctx context.Context, nonce uint64) (*resources.BlockSummary, error)
+	GetLatestBlockSummary(ctx context.Context) (*resources.BlockSummary, error)
+	GetBlockByHash(ctx context.Context, hash string) (*api.Block, error)
+	GetAccount(ctx context.Context, address string) (*resources.AccountOnBlock, error)
+	GetAccountNonceOnLatestBlock(ctx context.Context, address string) (uint64, error)
+	GetAccountBalance(ctx context.Context, address string, tokenIdentifier string, options resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error)
 	IsAddressObserved(address string) (bool, error)
 	ComputeShardIdOfPubKey(pubkey []byte) uint32
 	ConvertPubKeyToAddress(pubkey []byte) string
 	ConvertAddressToPubKey(address string) ([]byte, error)
-	SendTransaction(tx *data.Transaction) (string, error)
+	SendTransaction(ctx context.Context, tx *data.Transaction) (string, error)
+	GetSubmittedTransaction(hash string) (*resources.SubmittedTransaction, bool)
 	ComputeTransactionHash(tx *data.Transaction) (string, error)
+	EstimateTransactionGas(ctx context.Context, tx *data.Transaction) (uint64, error)
+	SimulateTransaction(ctx context.Context, tx *data.Transaction) (*resources.TransactionSimulationResults, error)
 	ComputeReceiptHash(apiReceipt *transaction.ApiReceipt) (string, error)
 	ComputeTransactionFeeForMoveBalance(tx *transaction.ApiTransactionResult) *big.Int
-	GetMempoolTransactionByHash(hash string) (*transaction.ApiTransactionResult, error)
+	GetMempoolTransactionByHash(ctx context.Context, hash string) (*transaction.ApiTransactionResult, error)
+	GetTransactionsPoolNoncesForSender(ctx context.Context, sender string) ([]uint64, error)
+	GetTransactionsInPool(ctx context.Context) ([]*resources.TransactionInPool, error)
+	ResolveUsername(ctx context.Context, username string) (string, error)
+	QueryContract(ctx context.Context, query *data.VmValueRequest, options resources.AccountQueryOptions) (*resources.VMQueryOutput, error)
+	GetTokenProperties(ctx context.Context, tokenIdentifier string) (*resources.TokenProperties, error)
+	RefreshNetworkConfig() error
+	StartNetworkConfigRefreshLoop(interval time.Duration)
+	RefreshSubmittedTransactions() error
+	StartSubmissionsTrackingLoop(interval time.Duration)
+	CheckObserversHealth()
+	StartObserversHealthCheckLoop(interval time.Duration)
 	LogDescription()
+	Close() error
+}
+
+type observerFacade interface {
+	CallGetRestEndPoint(ctx context.Context, baseUrl string, path string, value interface{}) (int, error)
+	CallPostRestEndPoint(ctx context.Context, baseUrl string, path string, data interface{}, response interface{}) (int, error)
+	ComputeShardId(pubKey []byte) uint32
+	SendTransaction(ctx context.Context, tx *data.Transaction) (int, string, error)
+	ComputeTransactionHash(tx *data.Transaction) (string, error)
+	GetTransactionByHashAndSenderAddress(ctx context.Context, hash string, sender string, withEvents bool) (*transaction.ApiTransactionResult, int, error)
+	GetBlockByHash(ctx context.Context, shardID uint32, hash string, options common.BlockQueryOptions) (*data.BlockApiResponse, error)
+	GetBlockByNonce(ctx context.Context, shardID uint32, nonce uint64, options common.BlockQueryOptions) (*data.BlockApiResponse, error)
 }
@@ -1,13 +1,13 @@
 package factory
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
 	hasherFactory "github.com/multiversx/mx-chain-core-go/hashing/factory"
 	marshalFactory "github.com/multiversx/mx-chain-core-go/marshal/factory"
 	"github.com/multiversx/mx-chain-go/sharding"
-	"github.com/multiversx/mx-chain-proxy-go/data"
 	"github.com/multiversx/mx-chain-proxy-go/observer"
 	"github.com/multiversx/mx-chain-proxy-go/process"
 	processFactory "github.com/multiversx/mx-chain-proxy-go/process/factory"
@@ -23,10 +23,7 @@ const (
 	pubKeyLength              = 32
 	bech32Prefix              = "erd"
 
-	notApplicableConfigurationFilePath   = "not applicable"
 	notApplicableFullHistoryNodesMessage = "not applicable"
-
-	requestTimeoutInSeconds = 60
 )
 
 type ArgsCreateNetworkProvider struct {
@@ -35,7 +32,6 @@ type ArgsCreateNetworkProvider struct {
 	ObservedActualShard         uint32
 	ObservedProjectedShard      uint32
 	ObservedProjectedShardIsSet bool
-	ObserverUrl                 string
 	BlockchainName              string
 	NetworkID                   string
 	NetworkName                 string
@@ -46,6 +42,8 @@ type ArgsCreateNetworkProvider struct {
 	MinGasLimit                 uint64
 	ExtraGasLimitGuardedTx      uint64
 	ExtraGasLimitRelayedTxV3    uint64
+	GasLimitEstimationMargin    uint64
+	NetworkConfigOverrides      resources.NetworkConfigOverrides
 	NativeCurrencySymbol        string
 	CustomCurrencies            []resources.Currency
 	GenesisBlockHash            string
@@ -53,6 +51,22 @@ type ArgsCreateNetworkProvider struct {
 	FirstHistoricalEpoch        uint32
 	NumHistoricalEpochs         uint32
 	ShouldHandleContracts       bool
+	ShouldSimulateBeforeSubmit  bool
+	NumObserverRetries          uint32
+	ObserverRetryBackoff        time.Duration
+	ObserverMaxRetryBackoff     time.Duration
+	CircuitBreakerThreshold     uint32
+	CircuitBreakerOpenDuration  time.Duration
+	// The observers of the shard, in the order of preference
+	ObserverUrls []string
+	// The first historical epoch of each observer (optional, see "FirstHistoricalEpoch")
+	ObserversFirstHistoricalEpochs []uint32
+	// The timeout of a single request towards an observer (the deadline of the originating request might be shorter)
+	ObserverRequestTimeout time.Duration
+	// Folder where the observer responses are recorded (optional)
+	RecordDir string
+	// Folder from which the (previously recorded) observer responses are replayed, instead of contacting the observer (optional)
+	ReplayDir string
 }
 
 // CreateNetworkProvider creates a network provider
@@ -67,19 +81,14 @@ func CreateNetworkProvider(args ArgsCreateNetworkProvider) (NetworkProvider, err
 		return nil, err
 	}
 
-	observers := []*data.NodeData{
-		{
-			ShardId:  args.ObservedActualShard,
-			Address:  args.ObserverUrl,
-			IsSynced: true,
-		},
-	}
-
-	observersProvider, err := observer.NewSimpleNodesProvider(
-		observers,
-		notApplicableConfigurationFilePath,
-		shardCoordinator.NumberOfShards(),
-	)
+	// The pool also acts as the observers provider of proxy-go's processors (so that they follow its choices, as well).
+	observersPool, err := provider.NewObserversPool(provider.ArgsNewObserversPool{
+		ShardID:               args.ObservedActualShard,
+		Urls:                  args.ObserverUrls,
+		FirstHistoricalEpochs: args.ObserversFirstHistoricalEpochs,
+		FirstHistoricalEpoch:  args.FirstHistoricalEpoch,
+		NumHistoricalEpochs:   args.NumHistoricalEpochs,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -90,9 +99,9 @@ func CreateNetworkProvider(args ArgsCreateNetworkProvider) (NetworkProvider, err
 	}
 
 	baseProcessor, err := process.NewBaseProcessor(
-		requestTimeoutInSeconds,
+		int(args.ObserverRequestTimeout.Seconds()),
 		shardCoordinator,
-		observersProvider,
+		observersPool,
 		disabledObserversProvider,
 		pubKeyConverter,
 		true,
@@ -123,7 +132,7 @@ func CreateNetworkProvider(args ArgsCreateNetworkProvider) (NetworkProvider, err
 	}
 
 	cacheDuration := time.Duration(30) * time.Second
-	timedCache, err := timecache.NewTimeCacher(timecache.ArgTimeCacher{
+	blocksCache, err := timecache.NewTimeCacher(timecache.ArgTimeCacher{
 		DefaultSpan: cacheDuration,
 		CacheExpiry: cacheDuration,
 	})
@@ -131,7 +140,13 @@ func CreateNetworkProvider(args ArgsCreateNetworkProvider) (NetworkProvider, err
 		return nil, err
 	}
 
-	blockProcessor, err := process.NewBlockProcessor(baseProcessor, timedCache)
+	observerFacade, err := createObserverFacade(args, &components.ObserverFacade{
+		Processor:            baseProcessor,
+		TransactionProcessor: transactionProcessor,
+		HttpClient:           &http.Client{Timeout: args.ObserverRequestTimeout},
+		BlocksCache:          blocksCache,
+		ObserversPool:        observersPool,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -141,7 +156,6 @@ func CreateNetworkProvider(args ArgsCreateNetworkProvider) (NetworkProvider, err
 		ObservedActualShard:         args.ObservedActualShard,
 		ObservedProjectedShard:      args.ObservedProjectedShard,
 		ObservedProjectedShardIsSet: args.ObservedProjectedShardIsSet,
-		ObserverUrl:                 args.ObserverUrl,
 		BlockchainName:              args.BlockchainName,
 		NetworkID:                   args.NetworkID,
 		NetworkName:                 args.NetworkName,
@@ -152,6 +166,8 @@ func CreateNetworkProvider(args ArgsCreateNetworkProvider) (NetworkProvider, err
 		MinGasLimit:                 args.MinGasLimit,
 		ExtraGasLimitGuardedTx:      args.ExtraGasLimitGuardedTx,
 		ExtraGasLimitRelayedTxV3:    args.ExtraGasLimitRelayedTxV3,
+		GasLimitEstimationMargin:    args.GasLimitEstimationMargin,
+		NetworkConfigOverrides:      args.NetworkConfigOverrides,
 		NativeCurrencySymbol:        args.NativeCurrencySymbol,
 		CustomCurrencies:            args.CustomCurrencies,
 		GenesisBlockHash:            args.GenesisBlockHash,
@@ -159,15 +175,42 @@ func CreateNetworkProvider(args ArgsCreateNetworkProvider) (NetworkProvider, err
 		FirstHistoricalEpoch:        args.FirstHistoricalEpoch,
 		NumHistoricalEpochs:         args.NumHistoricalEpochs,
 		ShouldHandleContracts:       args.ShouldHandleContracts,
+		ShouldSimulateBeforeSubmit:  args.ShouldSimulateBeforeSubmit,
+		NumObserverRetries:          args.NumObserverRetries,
+		ObserverRetryBackoff:        args.ObserverRetryBackoff,
+		ObserverMaxRetryBackoff:     args.ObserverMaxRetryBackoff,
+		CircuitBreakerThreshold:     args.CircuitBreakerThreshold,
+		CircuitBreakerOpenDuration:  args.CircuitBreakerOpenDuration,
 
-		ObserverFacade: &components.ObserverFacade{
-			Processor:            baseProcessor,
-			TransactionProcessor: transactionProcessor,
-			BlockProcessor:       blockProcessor,
-		},
+		ObserverFacade: observerFacade,
+		ObserversPool:  observersPool,
 
 		Hasher:                hasher,
 		MarshalizerForHashing: marshalizerForHashing,
 		PubKeyConverter:       pubKeyConverter,
 	})
 }
+
+// createObserverFacade decorates the observer facade, if recording or replaying the observer responses is requested
+func createObserverFacade(args ArgsCreateNetworkProvider, facade observerFacade) (observerFacade, error) {
+	hasRecordDir := len(args.RecordDir) > 0
+	hasReplayDir := len(args.ReplayDir) > 0
+
+	if (hasRecordDir || hasReplayDir) && args.IsOffline {
+		return nil, errRecordingOrReplayingInOfflineMode
+	}
+	if hasRecordDir && hasReplayDir {
+		return nil, errBothRecordingAndReplaying
+	}
+
+	if hasRecordDir {
+		log.Info("Observer responses will be recorded", "folder", args.RecordDir)
+		return components.NewRecordingObserverFacade(facade, args.RecordDir)
+	}
+	if hasReplayDir {
+		log.Info("Observer responses will be replayed (the observer won't be contacted)", "folder", args.ReplayDir)
+		return components.NewReplayingObserverFacade(facade, args.ReplayDir)
+	}
+
+	return facade, nil
+}
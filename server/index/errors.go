@@ -0,0 +1,19 @@
+package index
+
+import (
+	"errors"
+	"fmt"
+)
+
+var errNilBlockIdentifier = errors.New("nil block identifier")
+var errNonContiguousBlock = errors.New("block does not follow the last indexed block")
+var errNothingIndexed = errors.New("nothing indexed")
+var errCorruptedIndex = errors.New("corrupted index")
+
+func newErrNonContiguousBlock(nonce int64, lastIndexedNonce uint64) error {
+	return fmt.Errorf("%w: nonce = %d, last indexed nonce = %d", errNonContiguousBlock, nonce, lastIndexedNonce)
+}
+
+func newErrCorruptedIndex(key []byte, innerError error) error {
+	return fmt.Errorf("%w: %v, key = %x", errCorruptedIndex, innerError, key)
+}
@@ -0,0 +1,89 @@
+package index
+
+import (
+	"encoding/binary"
+)
+
+// Layout of the keys:
+//
+//	t/<nonce><position>                 -> the (JSON-serialized) block transaction
+//	h/<transaction hash>/<nonce><position> -> (empty)
+//	a/<address>/<nonce><position>          -> (empty)
+//	c/<currency symbol>/<nonce><position>  -> (empty)
+//	o/<operation type>/<nonce><position>   -> (empty)
+//	s/<operation status>/<nonce><position> -> (empty)
+//	r/<success: true or false>/<nonce><position> -> (empty)
+//	b/<nonce>                            -> hash of the indexed block
+//	m/first, m/last                      -> nonce of the first / last indexed block
+//
+// Nonces and positions are encoded as big-endian integers, so that the natural ordering of the keys follows the chain.
+var (
+	prefixTransaction     = []byte("t/")
+	prefixTransactionHash = []byte("h/")
+	prefixAddress         = []byte("a/")
+	prefixCurrency        = []byte("c/")
+	prefixOperationType   = []byte("o/")
+	prefixOperationStatus = []byte("s/")
+	prefixSuccess         = []byte("r/")
+	prefixBlock           = []byte("b/")
+	keyFirstIndexedBlock  = []byte("m/first")
+	keyLastIndexedBlock   = []byte("m/last")
+	keysSeparator         = byte('/')
+)
+
+const (
+	nonceLength    = 8
+	positionLength = 4
+	locationLength = nonceLength + positionLength
+)
+
+// location identifies a transaction by the nonce of its block and its position within the block
+type location []byte
+
+func newLocation(nonce uint64, position uint32) location {
+	loc := make([]byte, locationLength)
+	binary.BigEndian.PutUint64(loc[:nonceLength], nonce)
+	binary.BigEndian.PutUint32(loc[nonceLength:], position)
+	return loc
+}
+
+func (loc location) nonce() uint64 {
+	return binary.BigEndian.Uint64(loc[:nonceLength])
+}
+
+func encodeNonce(nonce uint64) []byte {
+	encoded := make([]byte, nonceLength)
+	binary.BigEndian.PutUint64(encoded, nonce)
+	return encoded
+}
+
+func decodeNonce(encoded []byte) uint64 {
+	return binary.BigEndian.Uint64(encoded)
+}
+
+func concat(parts ...[]byte) []byte {
+	result := make([]byte, 0, locationLength*2)
+
+	for _, part := range parts {
+		result = append(result, part...)
+	}
+
+	return result
+}
+
+func transactionKey(loc location) []byte {
+	return concat(prefixTransaction, loc)
+}
+
+func blockKey(nonce uint64) []byte {
+	return concat(prefixBlock, encodeNonce(nonce))
+}
+
+// secondaryKeyPrefix is the prefix shared by all the entries of a secondary index, for a given value (e.g. all entries for a given address)
+func secondaryKeyPrefix(indexPrefix []byte, value string) []byte {
+	return concat(indexPrefix, []byte(value), []byte{keysSeparator})
+}
+
+func secondaryKey(indexPrefix []byte, value string, loc location) []byte {
+	return concat(secondaryKeyPrefix(indexPrefix, value), loc)
+}
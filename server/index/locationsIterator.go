@@ -0,0 +1,142 @@
+package index
+
+import (
+	"bytes"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// locationsIterator walks through the locations of transactions, from the most recent one to the oldest one
+type locationsIterator interface {
+	next() bool
+	location() location
+	release()
+	lastError() error
+}
+
+// indexIterator walks through the locations held by the keys with a given prefix (the location is the suffix of the key), in reverse order
+type indexIterator struct {
+	prefix   []byte
+	iterator iterator.Iterator
+	started  bool
+	current  location
+}
+
+// newIndexIterator creates an iterator over the keys with the given prefix; if "maxBlock" is set, the locations of the subsequent blocks are skipped
+func newIndexIterator(reader leveldb.Reader, prefix []byte, maxBlock *int64) *indexIterator {
+	keysRange := util.BytesPrefix(prefix)
+
+	if maxBlock != nil {
+		// The limit of the range is exclusive.
+		limitNonce := uint64(0)
+		if *maxBlock >= 0 {
+			limitNonce = uint64(*maxBlock) + 1
+		}
+
+		keysRange.Limit = concat(prefix, encodeNonce(limitNonce))
+	}
+
+	return &indexIterator{
+		prefix:   prefix,
+		iterator: reader.NewIterator(keysRange, nil),
+	}
+}
+
+func (it *indexIterator) next() bool {
+	for it.advance() {
+		key := it.iterator.Key()
+
+		// E.g. for the prefix "c/FOO/", skip the keys of "c/FOO/BAR/".
+		if len(key)-len(it.prefix) != locationLength {
+			continue
+		}
+
+		it.current = location(copyBytes(key[len(it.prefix):]))
+		return true
+	}
+
+	it.current = nil
+	return false
+}
+
+func (it *indexIterator) advance() bool {
+	if it.started {
+		return it.iterator.Prev()
+	}
+
+	it.started = true
+	return it.iterator.Last()
+}
+
+func (it *indexIterator) location() location {
+	return it.current
+}
+
+func (it *indexIterator) release() {
+	it.iterator.Release()
+}
+
+func (it *indexIterator) lastError() error {
+	return it.iterator.Error()
+}
+
+// unionIterator merges several (reverse) iterators, yielding each location once
+type unionIterator struct {
+	iterators   []locationsIterator
+	hasLocation []bool
+	started     bool
+	current     location
+	err         error
+}
+
+func newUnionIterator(iterators []locationsIterator) *unionIterator {
+	return &unionIterator{
+		iterators:   iterators,
+		hasLocation: make([]bool, len(iterators)),
+	}
+}
+
+func (it *unionIterator) next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	var latest location
+
+	for i, inner := range it.iterators {
+		// The iterators positioned on the location yielded previously are moved forward.
+		shouldAdvance := !it.started || (it.hasLocation[i] && bytes.Equal(inner.location(), it.current))
+		if shouldAdvance {
+			it.hasLocation[i] = inner.next()
+
+			if inner.lastError() != nil {
+				it.err = inner.lastError()
+				return false
+			}
+		}
+
+		if it.hasLocation[i] && (latest == nil || bytes.Compare(inner.location(), latest) > 0) {
+			latest = inner.location()
+		}
+	}
+
+	it.started = true
+	it.current = latest
+	return latest != nil
+}
+
+func (it *unionIterator) location() location {
+	return it.current
+}
+
+func (it *unionIterator) release() {
+	for _, inner := range it.iterators {
+		inner.release()
+	}
+}
+
+func (it *unionIterator) lastError() error {
+	return it.err
+}
@@ -0,0 +1,99 @@
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type sliceIterator struct {
+	locations []location
+	position  int
+}
+
+func (it *sliceIterator) next() bool {
+	it.position++
+	return it.position <= len(it.locations)
+}
+
+func (it *sliceIterator) location() location {
+	if it.position < 1 || it.position > len(it.locations) {
+		return nil
+	}
+
+	return it.locations[it.position-1]
+}
+
+func (it *sliceIterator) release() {
+}
+
+func (it *sliceIterator) lastError() error {
+	return nil
+}
+
+func collectNonces(it locationsIterator) []uint64 {
+	nonces := make([]uint64, 0)
+
+	for it.next() {
+		nonces = append(nonces, it.location().nonce())
+	}
+
+	return nonces
+}
+
+func TestIndexIterator(t *testing.T) {
+	t.Parallel()
+
+	index := createTransactionsIndex(t)
+	populateIndex(t, index)
+
+	t.Run("in reverse order", func(t *testing.T) {
+		it := newIndexIterator(index.db, secondaryKeyPrefix(prefixAddress, "carol"), nil)
+		defer it.release()
+
+		require.Equal(t, []uint64{12, 12, 10}, collectNonces(it))
+		require.Nil(t, it.lastError())
+	})
+
+	t.Run("with max block", func(t *testing.T) {
+		maxBlock := int64(11)
+
+		it := newIndexIterator(index.db, secondaryKeyPrefix(prefixAddress, "carol"), &maxBlock)
+		defer it.release()
+
+		require.Equal(t, []uint64{10}, collectNonces(it))
+	})
+
+	t.Run("with negative max block", func(t *testing.T) {
+		maxBlock := int64(-1)
+
+		it := newIndexIterator(index.db, prefixTransaction, &maxBlock)
+		defer it.release()
+
+		require.Equal(t, []uint64{}, collectNonces(it))
+	})
+}
+
+func TestUnionIterator(t *testing.T) {
+	t.Parallel()
+
+	it := newUnionIterator([]locationsIterator{
+		&sliceIterator{locations: []location{newLocation(9, 0), newLocation(5, 1), newLocation(5, 0)}},
+		&sliceIterator{locations: []location{}},
+		&sliceIterator{locations: []location{newLocation(7, 0), newLocation(5, 1), newLocation(1, 0)}},
+	})
+
+	locations := make([]location, 0)
+	for it.next() {
+		locations = append(locations, it.location())
+	}
+
+	require.Equal(t, []location{
+		newLocation(9, 0),
+		newLocation(7, 0),
+		newLocation(5, 1),
+		newLocation(5, 0),
+		newLocation(1, 0),
+	}, locations)
+	require.False(t, it.next())
+}
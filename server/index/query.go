@@ -0,0 +1,153 @@
+package index
+
+import (
+	"strconv"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+// Query holds the conditions of a search, as defined by the Rosetta Search API.
+// Empty (or nil) fields are ignored. The conditions are combined using the given operator ("and", by default).
+type Query struct {
+	Operator        types.Operator
+	MaxBlock        *int64
+	Offset          int64
+	Limit           int64
+	TransactionHash string
+	Address         string
+	Currency        *types.Currency
+	OperationType   string
+	OperationStatus string
+	Success         *bool
+}
+
+// Result holds the transactions (a page of them) matching a query, sorted from the most recent block to the oldest one
+type Result struct {
+	Transactions []*types.BlockTransaction
+	TotalCount   int64
+}
+
+// condition is a criterion of a query; it's backed by a secondary index if "indexPrefix" is set (all conditions are, at the moment).
+// If "isExact" is set, the entries of the secondary index are exactly the transactions matching the condition.
+type condition struct {
+	indexPrefix []byte
+	isExact     bool
+	matches     func(tx *types.Transaction) bool
+}
+
+func (condition *condition) isIndexed() bool {
+	return condition.indexPrefix != nil
+}
+
+func (index *transactionsIndex) getConditions(query Query) []*condition {
+	conditions := make([]*condition, 0)
+
+	// The order matters: for "and" queries, the first indexed condition (presumably the most selective one) provides the candidates.
+	if len(query.TransactionHash) > 0 {
+		conditions = append(conditions, &condition{
+			indexPrefix: secondaryKeyPrefix(prefixTransactionHash, query.TransactionHash),
+			isExact:     true,
+			matches: func(tx *types.Transaction) bool {
+				return tx.TransactionIdentifier.Hash == query.TransactionHash
+			},
+		})
+	}
+
+	if len(query.Address) > 0 {
+		conditions = append(conditions, &condition{
+			indexPrefix: secondaryKeyPrefix(prefixAddress, query.Address),
+			isExact:     true,
+			matches: func(tx *types.Transaction) bool {
+				return anyOperation(tx, func(operation *types.Operation) bool {
+					return operation.Account != nil && operation.Account.Address == query.Address
+				})
+			},
+		})
+	}
+
+	if query.Currency != nil {
+		conditions = append(conditions, &condition{
+			indexPrefix: secondaryKeyPrefix(prefixCurrency, query.Currency.Symbol),
+			matches: func(tx *types.Transaction) bool {
+				return anyOperation(tx, func(operation *types.Operation) bool {
+					return operation.Amount != nil &&
+						operation.Amount.Currency != nil &&
+						operation.Amount.Currency.Symbol == query.Currency.Symbol &&
+						operation.Amount.Currency.Decimals == query.Currency.Decimals
+				})
+			},
+		})
+	}
+
+	if len(query.OperationType) > 0 {
+		conditions = append(conditions, &condition{
+			indexPrefix: secondaryKeyPrefix(prefixOperationType, query.OperationType),
+			isExact:     true,
+			matches: func(tx *types.Transaction) bool {
+				return anyOperation(tx, func(operation *types.Operation) bool {
+					return operation.Type == query.OperationType
+				})
+			},
+		})
+	}
+
+	if len(query.OperationStatus) > 0 {
+		conditions = append(conditions, &condition{
+			indexPrefix: secondaryKeyPrefix(prefixOperationStatus, query.OperationStatus),
+			isExact:     true,
+			matches: func(tx *types.Transaction) bool {
+				return anyOperation(tx, func(operation *types.Operation) bool {
+					return operation.Status != nil && *operation.Status == query.OperationStatus
+				})
+			},
+		})
+	}
+
+	if query.Success != nil {
+		conditions = append(conditions, &condition{
+			indexPrefix: secondaryKeyPrefix(prefixSuccess, strconv.FormatBool(*query.Success)),
+			isExact:     true,
+			matches: func(tx *types.Transaction) bool {
+				return index.isSuccessful(tx) == *query.Success
+			},
+		})
+	}
+
+	return conditions
+}
+
+// isSuccessful decides whether a transaction is successful, given the statuses of its operations (and the mapping of the statuses to "successful" or not)
+func (index *transactionsIndex) isSuccessful(tx *types.Transaction) bool {
+	return !anyOperation(tx, func(operation *types.Operation) bool {
+		return operation.Status != nil && !index.successfulStatuses[*operation.Status]
+	})
+}
+
+func anyOperation(tx *types.Transaction, predicate func(operation *types.Operation) bool) bool {
+	for _, operation := range tx.Operations {
+		if predicate(operation) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesConditions(tx *types.Transaction, conditions []*condition, operator types.Operator) bool {
+	if len(conditions) == 0 {
+		return true
+	}
+
+	for _, condition := range conditions {
+		matches := condition.matches(tx)
+
+		if operator == types.OR && matches {
+			return true
+		}
+		if operator != types.OR && !matches {
+			return false
+		}
+	}
+
+	return operator != types.OR
+}
@@ -0,0 +1,390 @@
+package index
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// ArgsNewTransactionsIndex holds the arguments for creating a transactions index
+type ArgsNewTransactionsIndex struct {
+	// Folder holding the database (created if missing)
+	Path string
+	// The operation statuses (and whether they are "successful" or not), used for indexing the transactions by "success"
+	OperationStatuses []*types.OperationStatus
+}
+
+type transactionsIndex struct {
+	db                 *leveldb.DB
+	writeMutex         sync.Mutex
+	successfulStatuses map[string]bool
+}
+
+// NewTransactionsIndex opens (or creates) a local index of transactions, backed by an embedded key-value store (LevelDB)
+func NewTransactionsIndex(args ArgsNewTransactionsIndex) (*transactionsIndex, error) {
+	db, err := leveldb.OpenFile(args.Path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	successfulStatuses := make(map[string]bool)
+	for _, status := range args.OperationStatuses {
+		successfulStatuses[status.Status] = status.Successful
+	}
+
+	return &transactionsIndex{
+		db:                 db,
+		successfulStatuses: successfulStatuses,
+	}, nil
+}
+
+// IndexBlock indexes the transactions of a block, which must follow the last indexed block (if any)
+func (index *transactionsIndex) IndexBlock(block *types.BlockIdentifier, transactions []*types.Transaction) error {
+	if block == nil {
+		return errNilBlockIdentifier
+	}
+
+	index.writeMutex.Lock()
+	defer index.writeMutex.Unlock()
+
+	nonce := uint64(block.Index)
+
+	lastIndexedNonce, ok, err := index.getNonce(index.db, keyLastIndexedBlock)
+	if err != nil {
+		return err
+	}
+	if ok && nonce != lastIndexedNonce+1 {
+		return newErrNonContiguousBlock(block.Index, lastIndexedNonce)
+	}
+
+	batch := new(leveldb.Batch)
+
+	for position, tx := range transactions {
+		loc := newLocation(nonce, uint32(position))
+
+		data, err := json.Marshal(&types.BlockTransaction{
+			BlockIdentifier: block,
+			Transaction:     tx,
+		})
+		if err != nil {
+			return err
+		}
+
+		batch.Put(transactionKey(loc), data)
+
+		for _, key := range index.computeSecondaryKeys(tx, loc) {
+			batch.Put(key, nil)
+		}
+	}
+
+	batch.Put(blockKey(nonce), []byte(block.Hash))
+	batch.Put(keyLastIndexedBlock, encodeNonce(nonce))
+
+	if !ok {
+		batch.Put(keyFirstIndexedBlock, encodeNonce(nonce))
+	}
+
+	return index.db.Write(batch, nil)
+}
+
+// RemoveLastBlock removes the transactions of the last indexed block (e.g. when the block isn't canonical anymore)
+func (index *transactionsIndex) RemoveLastBlock() error {
+	index.writeMutex.Lock()
+	defer index.writeMutex.Unlock()
+
+	lastIndexedNonce, ok, err := index.getNonce(index.db, keyLastIndexedBlock)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errNothingIndexed
+	}
+
+	firstIndexedNonce, _, err := index.getNonce(index.db, keyFirstIndexedBlock)
+	if err != nil {
+		return err
+	}
+
+	batch := new(leveldb.Batch)
+
+	iterator := index.db.NewIterator(util.BytesPrefix(concat(prefixTransaction, encodeNonce(lastIndexedNonce))), nil)
+	for iterator.Next() {
+		key := copyBytes(iterator.Key())
+		loc := location(key[len(prefixTransaction):])
+
+		blockTransaction := &types.BlockTransaction{}
+		err = json.Unmarshal(iterator.Value(), blockTransaction)
+		if err != nil {
+			iterator.Release()
+			return newErrCorruptedIndex(key, err)
+		}
+
+		batch.Delete(key)
+
+		for _, secondaryKey := range index.computeSecondaryKeys(blockTransaction.Transaction, loc) {
+			batch.Delete(secondaryKey)
+		}
+	}
+
+	iterator.Release()
+	err = iterator.Error()
+	if err != nil {
+		return err
+	}
+
+	batch.Delete(blockKey(lastIndexedNonce))
+
+	if lastIndexedNonce == firstIndexedNonce {
+		batch.Delete(keyFirstIndexedBlock)
+		batch.Delete(keyLastIndexedBlock)
+	} else {
+		batch.Put(keyLastIndexedBlock, encodeNonce(lastIndexedNonce-1))
+	}
+
+	return index.db.Write(batch, nil)
+}
+
+// GetFirstIndexedBlock gets the first (oldest) indexed block, if any
+func (index *transactionsIndex) GetFirstIndexedBlock() (*types.BlockIdentifier, bool, error) {
+	return index.getIndexedBlock(keyFirstIndexedBlock)
+}
+
+// GetLastIndexedBlock gets the last (most recent) indexed block, if any
+func (index *transactionsIndex) GetLastIndexedBlock() (*types.BlockIdentifier, bool, error) {
+	return index.getIndexedBlock(keyLastIndexedBlock)
+}
+
+func (index *transactionsIndex) getIndexedBlock(metadataKey []byte) (*types.BlockIdentifier, bool, error) {
+	snapshot, err := index.db.GetSnapshot()
+	if err != nil {
+		return nil, false, err
+	}
+	defer snapshot.Release()
+
+	nonce, ok, err := index.getNonce(snapshot, metadataKey)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	hash, err := snapshot.Get(blockKey(nonce), nil)
+	if err != nil {
+		return nil, false, newErrCorruptedIndex(blockKey(nonce), err)
+	}
+
+	return &types.BlockIdentifier{
+		Index: int64(nonce),
+		Hash:  string(hash),
+	}, true, nil
+}
+
+func (index *transactionsIndex) getNonce(reader leveldb.Reader, key []byte) (uint64, bool, error) {
+	data, err := reader.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	if len(data) != nonceLength {
+		return 0, false, newErrCorruptedIndex(key, nil)
+	}
+
+	return decodeNonce(data), true, nil
+}
+
+// Search gets the transactions matching a query (a page of them), from the most recent block to the oldest one.
+// The candidate transactions are walked (in reverse order) through the secondary index of the most selective condition, or through the union of the indexes, for "or" queries.
+// The scan stops right after the requested page, thus "TotalCount" is exact only when the page is the last one (otherwise, it's a lower bound, telling that more transactions exist).
+func (index *transactionsIndex) Search(query Query) (*Result, error) {
+	snapshot, err := index.db.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	defer snapshot.Release()
+
+	conditions := index.getConditions(query)
+	needsMatching := needsMatchingOnTransactions(conditions)
+
+	candidates := getCandidates(snapshot, conditions, query)
+	defer candidates.release()
+
+	pageStart := query.Offset
+	pageEnd := query.Offset + query.Limit
+	transactions := make([]*types.BlockTransaction, 0)
+	numMatches := int64(0)
+
+	// A single match past the page is looked for, as well (so that the caller knows whether there are more).
+	for numMatches <= pageEnd && candidates.next() {
+		loc := candidates.location()
+
+		if query.Operator != types.OR {
+			holdsConditions, err := holdsIndexedConditions(snapshot, conditions, loc)
+			if err != nil {
+				return nil, err
+			}
+			if !holdsConditions {
+				continue
+			}
+		}
+
+		isInPage := numMatches >= pageStart && numMatches < pageEnd
+
+		if isInPage || needsMatching {
+			blockTransaction, err := index.loadTransaction(snapshot, loc)
+			if err != nil {
+				return nil, err
+			}
+
+			if needsMatching && !matchesConditions(blockTransaction.Transaction, conditions, query.Operator) {
+				continue
+			}
+			if isInPage {
+				transactions = append(transactions, blockTransaction)
+			}
+		}
+
+		numMatches++
+	}
+
+	err = candidates.lastError()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Transactions: transactions,
+		TotalCount:   numMatches,
+	}, nil
+}
+
+// getCandidates creates an iterator over the transactions that might match the conditions.
+// For "and" queries, the first indexed condition (presumably the most selective one) provides the candidates; for "or" queries, all conditions do.
+func getCandidates(reader leveldb.Reader, conditions []*condition, query Query) locationsIterator {
+	if len(conditions) == 0 {
+		return newIndexIterator(reader, prefixTransaction, query.MaxBlock)
+	}
+
+	if query.Operator == types.OR {
+		for _, condition := range conditions {
+			if !condition.isIndexed() {
+				return newIndexIterator(reader, prefixTransaction, query.MaxBlock)
+			}
+		}
+
+		iterators := make([]locationsIterator, 0, len(conditions))
+		for _, condition := range conditions {
+			iterators = append(iterators, newIndexIterator(reader, condition.indexPrefix, query.MaxBlock))
+		}
+
+		return newUnionIterator(iterators)
+	}
+
+	for _, condition := range conditions {
+		if condition.isIndexed() {
+			return newIndexIterator(reader, condition.indexPrefix, query.MaxBlock)
+		}
+	}
+
+	return newIndexIterator(reader, prefixTransaction, query.MaxBlock)
+}
+
+// holdsIndexedConditions checks a candidate against the secondary indexes of the conditions (without loading the transaction)
+func holdsIndexedConditions(reader leveldb.Reader, conditions []*condition, loc location) (bool, error) {
+	for _, condition := range conditions {
+		if !condition.isIndexed() {
+			continue
+		}
+
+		_, err := reader.Get(concat(condition.indexPrefix, loc), nil)
+		if err == leveldb.ErrNotFound {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// needsMatchingOnTransactions returns whether the conditions have to be evaluated on the (loaded) transactions, or the secondary indexes are enough
+func needsMatchingOnTransactions(conditions []*condition) bool {
+	for _, condition := range conditions {
+		if !condition.isIndexed() || !condition.isExact {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (index *transactionsIndex) loadTransaction(reader leveldb.Reader, loc location) (*types.BlockTransaction, error) {
+	key := transactionKey(loc)
+
+	data, err := reader.Get(key, nil)
+	if err != nil {
+		return nil, newErrCorruptedIndex(key, err)
+	}
+
+	blockTransaction := &types.BlockTransaction{}
+	err = json.Unmarshal(data, blockTransaction)
+	if err != nil {
+		return nil, newErrCorruptedIndex(key, err)
+	}
+
+	return blockTransaction, nil
+}
+
+// computeSecondaryKeys computes the keys of the secondary indexes (by transaction hash, address, currency, operation type, operation status and success), for a transaction
+func (index *transactionsIndex) computeSecondaryKeys(tx *types.Transaction, loc location) [][]byte {
+	keys := make([][]byte, 0)
+	seen := make(map[string]struct{})
+
+	addKey := func(indexPrefix []byte, value string) {
+		key := secondaryKey(indexPrefix, value, loc)
+
+		_, ok := seen[string(key)]
+		if ok {
+			return
+		}
+
+		seen[string(key)] = struct{}{}
+		keys = append(keys, key)
+	}
+
+	addKey(prefixTransactionHash, tx.TransactionIdentifier.Hash)
+
+	for _, operation := range tx.Operations {
+		if operation.Account != nil {
+			addKey(prefixAddress, operation.Account.Address)
+		}
+		if operation.Amount != nil && operation.Amount.Currency != nil {
+			addKey(prefixCurrency, operation.Amount.Currency.Symbol)
+		}
+
+		if operation.Status != nil {
+			addKey(prefixOperationStatus, *operation.Status)
+		}
+
+		addKey(prefixOperationType, operation.Type)
+	}
+
+	addKey(prefixSuccess, strconv.FormatBool(index.isSuccessful(tx)))
+
+	return keys
+}
+
+func copyBytes(data []byte) []byte {
+	copied := make([]byte, len(data))
+	copy(copied, data)
+	return copied
+}
+
+// Close closes the underlying database
+func (index *transactionsIndex) Close() error {
+	return index.db.Close()
+}
@@ -0,0 +1,354 @@
+package index
+
+import (
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	statusSuccess = "Success"
+	statusFailure = "Failure"
+	currencyEGLD  = &types.Currency{Symbol: "EGLD", Decimals: 18}
+	currencyROSE  = &types.Currency{Symbol: "ROSETTA-3a2edf", Decimals: 2}
+)
+
+func createTransactionsIndex(t *testing.T) *transactionsIndex {
+	index, err := NewTransactionsIndex(ArgsNewTransactionsIndex{
+		Path: t.TempDir(),
+		OperationStatuses: []*types.OperationStatus{
+			{Status: statusSuccess, Successful: true},
+			{Status: statusFailure, Successful: false},
+		},
+	})
+	require.Nil(t, err)
+
+	t.Cleanup(func() {
+		_ = index.Close()
+	})
+
+	return index
+}
+
+func createTransaction(hash string, status string, operations ...*types.Operation) *types.Transaction {
+	for _, operation := range operations {
+		operation.Status = &status
+	}
+
+	return &types.Transaction{
+		TransactionIdentifier: &types.TransactionIdentifier{Hash: hash},
+		Operations:            operations,
+	}
+}
+
+func createTransfer(address string, value string, currency *types.Currency) *types.Operation {
+	return &types.Operation{
+		Type:    "Transfer",
+		Account: &types.AccountIdentifier{Address: address},
+		Amount:  &types.Amount{Value: value, Currency: currency},
+	}
+}
+
+func createFee(address string, value string) *types.Operation {
+	return &types.Operation{
+		Type:    "Fee",
+		Account: &types.AccountIdentifier{Address: address},
+		Amount:  &types.Amount{Value: value, Currency: currencyEGLD},
+	}
+}
+
+func getHashes(result *Result) []string {
+	hashes := make([]string, 0, len(result.Transactions))
+
+	for _, blockTransaction := range result.Transactions {
+		hashes = append(hashes, blockTransaction.Transaction.TransactionIdentifier.Hash)
+	}
+
+	return hashes
+}
+
+func populateIndex(t *testing.T, index *transactionsIndex) {
+	err := index.IndexBlock(&types.BlockIdentifier{Index: 10, Hash: "b10"}, []*types.Transaction{
+		createTransaction("tx1", statusSuccess,
+			createTransfer("alice", "-100", currencyEGLD),
+			createTransfer("bob", "100", currencyEGLD),
+			createFee("alice", "-50"),
+		),
+		createTransaction("tx2", statusSuccess,
+			createTransfer("bob", "-7", currencyROSE),
+			createTransfer("carol", "7", currencyROSE),
+			createFee("bob", "-50"),
+		),
+	})
+	require.Nil(t, err)
+
+	err = index.IndexBlock(&types.BlockIdentifier{Index: 11, Hash: "b11"}, []*types.Transaction{})
+	require.Nil(t, err)
+
+	err = index.IndexBlock(&types.BlockIdentifier{Index: 12, Hash: "b12"}, []*types.Transaction{
+		createTransaction("tx3", statusFailure,
+			createFee("carol", "-50"),
+		),
+		createTransaction("tx4", statusSuccess,
+			createTransfer("alice", "-1", currencyEGLD),
+			createTransfer("carol", "1", currencyEGLD),
+			createFee("alice", "-50"),
+		),
+	})
+	require.Nil(t, err)
+}
+
+func TestTransactionsIndex_IndexBlockAndRemoveLastBlock(t *testing.T) {
+	t.Parallel()
+
+	index := createTransactionsIndex(t)
+
+	_, ok, err := index.GetLastIndexedBlock()
+	require.Nil(t, err)
+	require.False(t, ok)
+
+	err = index.RemoveLastBlock()
+	require.ErrorIs(t, err, errNothingIndexed)
+
+	populateIndex(t, index)
+
+	first, ok, err := index.GetFirstIndexedBlock()
+	require.Nil(t, err)
+	require.True(t, ok)
+	require.Equal(t, &types.BlockIdentifier{Index: 10, Hash: "b10"}, first)
+
+	last, ok, err := index.GetLastIndexedBlock()
+	require.Nil(t, err)
+	require.True(t, ok)
+	require.Equal(t, &types.BlockIdentifier{Index: 12, Hash: "b12"}, last)
+
+	// Gaps are not allowed
+	err = index.IndexBlock(&types.BlockIdentifier{Index: 14, Hash: "b14"}, []*types.Transaction{})
+	require.ErrorIs(t, err, errNonContiguousBlock)
+
+	err = index.RemoveLastBlock()
+	require.Nil(t, err)
+
+	last, _, _ = index.GetLastIndexedBlock()
+	require.Equal(t, &types.BlockIdentifier{Index: 11, Hash: "b11"}, last)
+
+	// The secondary entries are removed, as well
+	result, err := index.Search(Query{Address: "carol", Limit: 10})
+	require.Nil(t, err)
+	require.Equal(t, []string{"tx2"}, getHashes(result))
+	require.Equal(t, int64(1), result.TotalCount)
+
+	result, err = index.Search(Query{OperationStatus: statusFailure, Limit: 10})
+	require.Nil(t, err)
+	require.Equal(t, int64(0), result.TotalCount)
+
+	// Another block is indexed, instead of the removed one
+	err = index.IndexBlock(&types.BlockIdentifier{Index: 12, Hash: "c12"}, []*types.Transaction{
+		createTransaction("tx5", statusSuccess, createFee("carol", "-50")),
+	})
+	require.Nil(t, err)
+
+	result, err = index.Search(Query{Address: "carol", Limit: 10})
+	require.Nil(t, err)
+	require.Equal(t, []string{"tx5", "tx2"}, getHashes(result))
+	require.Equal(t, "c12", result.Transactions[0].BlockIdentifier.Hash)
+
+	// Remove everything
+	require.Nil(t, index.RemoveLastBlock())
+	require.Nil(t, index.RemoveLastBlock())
+	require.Nil(t, index.RemoveLastBlock())
+
+	_, ok, err = index.GetFirstIndexedBlock()
+	require.Nil(t, err)
+	require.False(t, ok)
+
+	result, err = index.Search(Query{Limit: 10})
+	require.Nil(t, err)
+	require.Len(t, result.Transactions, 0)
+	require.Equal(t, int64(0), result.TotalCount)
+}
+
+func TestTransactionsIndex_Search(t *testing.T) {
+	t.Parallel()
+
+	index := createTransactionsIndex(t)
+	populateIndex(t, index)
+
+	success := true
+	failure := false
+	maxBlock := int64(11)
+
+	testCases := []struct {
+		name           string
+		query          Query
+		expectedHashes []string
+		expectedCount  int64
+	}{
+		{
+			name:           "all",
+			query:          Query{Limit: 10},
+			expectedHashes: []string{"tx4", "tx3", "tx2", "tx1"},
+			expectedCount:  4,
+		},
+		{
+			name:           "all, with paging",
+			query:          Query{Offset: 1, Limit: 2},
+			expectedHashes: []string{"tx3", "tx2"},
+			expectedCount:  4,
+		},
+		{
+			// The scan stops right after the page (the count tells that more transactions exist).
+			name:           "all, first page only",
+			query:          Query{Limit: 1},
+			expectedHashes: []string{"tx4"},
+			expectedCount:  2,
+		},
+		{
+			name:           "all, with offset past the end",
+			query:          Query{Offset: 10, Limit: 10},
+			expectedHashes: []string{},
+			expectedCount:  4,
+		},
+		{
+			name:           "all, with max block",
+			query:          Query{MaxBlock: &maxBlock, Limit: 10},
+			expectedHashes: []string{"tx2", "tx1"},
+			expectedCount:  2,
+		},
+		{
+			name:           "by hash",
+			query:          Query{TransactionHash: "tx2", Limit: 10},
+			expectedHashes: []string{"tx2"},
+			expectedCount:  1,
+		},
+		{
+			name:           "by address",
+			query:          Query{Address: "alice", Limit: 10},
+			expectedHashes: []string{"tx4", "tx1"},
+			expectedCount:  2,
+		},
+		{
+			name:           "by address, with paging",
+			query:          Query{Address: "alice", Offset: 1, Limit: 10},
+			expectedHashes: []string{"tx1"},
+			expectedCount:  2,
+		},
+		{
+			name:           "by address, with max block",
+			query:          Query{Address: "alice", MaxBlock: &maxBlock, Limit: 10},
+			expectedHashes: []string{"tx1"},
+			expectedCount:  1,
+		},
+		{
+			name:           "by currency",
+			query:          Query{Currency: currencyROSE, Limit: 10},
+			expectedHashes: []string{"tx2"},
+			expectedCount:  1,
+		},
+		{
+			name:           "by currency, with other decimals",
+			query:          Query{Currency: &types.Currency{Symbol: "EGLD", Decimals: 6}, Limit: 10},
+			expectedHashes: []string{},
+			expectedCount:  0,
+		},
+		{
+			name:           "by operation type",
+			query:          Query{OperationType: "Transfer", Limit: 10},
+			expectedHashes: []string{"tx4", "tx2", "tx1"},
+			expectedCount:  3,
+		},
+		{
+			name:           "by status",
+			query:          Query{OperationStatus: statusFailure, Limit: 10},
+			expectedHashes: []string{"tx3"},
+			expectedCount:  1,
+		},
+		{
+			name:           "by success",
+			query:          Query{Success: &failure, Limit: 10},
+			expectedHashes: []string{"tx3"},
+			expectedCount:  1,
+		},
+		{
+			name:           "by success, when successful",
+			query:          Query{Success: &success, Limit: 10},
+			expectedHashes: []string{"tx4", "tx2", "tx1"},
+			expectedCount:  3,
+		},
+		{
+			// Conditions apply to the transaction as a whole (not to a single operation).
+			name:           "by address and currency",
+			query:          Query{Address: "carol", Currency: currencyEGLD, Limit: 10},
+			expectedHashes: []string{"tx4", "tx3", "tx2"},
+			expectedCount:  3,
+		},
+		{
+			name:           "by address and operation type and success, with paging",
+			query:          Query{Address: "carol", OperationType: "Fee", Success: &success, Offset: 1, Limit: 1},
+			expectedHashes: []string{"tx2"},
+			expectedCount:  2,
+		},
+		{
+			name:           "by address or address",
+			query:          Query{Operator: types.OR, Address: "alice", TransactionHash: "tx3", Limit: 10},
+			expectedHashes: []string{"tx4", "tx3", "tx1"},
+			expectedCount:  3,
+		},
+		{
+			name:           "by address or success",
+			query:          Query{Operator: types.OR, Address: "bob", Success: &failure, Limit: 10},
+			expectedHashes: []string{"tx3", "tx2", "tx1"},
+			expectedCount:  3,
+		},
+		{
+			name:           "by status or success",
+			query:          Query{Operator: types.OR, OperationStatus: statusFailure, Success: &success, Limit: 10},
+			expectedHashes: []string{"tx4", "tx3", "tx2", "tx1"},
+			expectedCount:  4,
+		},
+		{
+			name:           "by address or currency, with max block",
+			query:          Query{Operator: types.OR, Address: "alice", Currency: currencyROSE, MaxBlock: &maxBlock, Limit: 10},
+			expectedHashes: []string{"tx2", "tx1"},
+			expectedCount:  2,
+		},
+		{
+			name:           "by address or address, first page only",
+			query:          Query{Operator: types.OR, Address: "alice", TransactionHash: "tx3", Limit: 2},
+			expectedHashes: []string{"tx4", "tx3"},
+			expectedCount:  3,
+		},
+		{
+			name:           "by unknown address",
+			query:          Query{Address: "dave", Limit: 10},
+			expectedHashes: []string{},
+			expectedCount:  0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := index.Search(testCase.query)
+			require.Nil(t, err)
+			require.Equal(t, testCase.expectedHashes, getHashes(result))
+			require.Equal(t, testCase.expectedCount, result.TotalCount)
+		})
+	}
+}
+
+func TestTransactionsIndex_SearchDoesNotConfuseKeysSharingPrefixes(t *testing.T) {
+	t.Parallel()
+
+	index := createTransactionsIndex(t)
+
+	err := index.IndexBlock(&types.BlockIdentifier{Index: 1, Hash: "b1"}, []*types.Transaction{
+		createTransaction("tx1", statusSuccess, createTransfer("FOO", "1", currencyEGLD)),
+		createTransaction("tx2", statusSuccess, createTransfer("FOO/BAR", "1", currencyEGLD)),
+	})
+	require.Nil(t, err)
+
+	result, err := index.Search(Query{Address: "FOO", Limit: 10})
+	require.Nil(t, err)
+	require.Equal(t, []string{"tx1"}, getHashes(result))
+}
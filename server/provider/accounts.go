@@ -1,16 +1,18 @@
 package provider
 
 import (
+	"context"
+
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-rosetta/server/resources"
 )
 
 // GetAccount gets an account by address
-func (provider *networkProvider) GetAccount(address string) (*resources.AccountOnBlock, error) {
+func (provider *networkProvider) GetAccount(ctx context.Context, address string) (*resources.AccountOnBlock, error) {
 	url := buildUrlGetAccount(address)
 	response := &resources.AccountApiResponse{}
 
-	err := provider.getResource(url, response)
+	err := provider.getResource(ctx, url, response)
 	if err != nil {
 		return nil, newErrCannotGetAccount(address, err)
 	}
@@ -28,21 +30,32 @@ func (provider *networkProvider) GetAccount(address string) (*resources.AccountO
 	return data, nil
 }
 
+// GetAccountNonceOnLatestBlock gets the nonce of an account at the latest block (which isn't necessarily final).
+// The nonce moves as soon as a transaction of the account is executed (and leaves the pool), before the block gets final.
+func (provider *networkProvider) GetAccountNonceOnLatestBlock(ctx context.Context, address string) (uint64, error) {
+	accountBalance, err := provider.getNativeBalance(ctx, address, resources.NewAccountQueryOptionsOnLatestBlock())
+	if err != nil {
+		return 0, err
+	}
+
+	return accountBalance.Nonce.Value, nil
+}
+
 // GetAccountBalance gets the native balance by address
-func (provider *networkProvider) GetAccountBalance(address string, tokenIdentifier string, options resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error) {
+func (provider *networkProvider) GetAccountBalance(ctx context.Context, address string, tokenIdentifier string, options resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error) {
 	isNativeBalance := tokenIdentifier == provider.nativeCurrency.Symbol
 	if isNativeBalance {
-		return provider.getNativeBalance(address, options)
+		return provider.getNativeBalance(ctx, address, options)
 	}
 
-	return provider.getCustomTokenBalance(address, tokenIdentifier, options)
+	return provider.getCustomTokenBalance(ctx, address, tokenIdentifier, options)
 }
 
-func (provider *networkProvider) getNativeBalance(address string, options resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error) {
+func (provider *networkProvider) getNativeBalance(ctx context.Context, address string, options resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error) {
 	url := buildUrlGetAccountNativeBalance(address, options)
 	response := &resources.AccountApiResponse{}
 
-	err := provider.getResource(url, response)
+	err := provider.getResourceWithOptions(ctx, url, options, response)
 	if err != nil {
 		return nil, newErrCannotGetAccount(address, err)
 	}
@@ -65,7 +78,7 @@ func (provider *networkProvider) getNativeBalance(address string, options resour
 	}, nil
 }
 
-func (provider *networkProvider) getCustomTokenBalance(address string, tokenIdentifier string, options resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error) {
+func (provider *networkProvider) getCustomTokenBalance(ctx context.Context, address string, tokenIdentifier string, options resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error) {
 	url, err := decideCustomTokenBalanceUrl(address, tokenIdentifier, options)
 	if err != nil {
 		return nil, err
@@ -73,7 +86,7 @@ func (provider *networkProvider) getCustomTokenBalance(address string, tokenIden
 
 	response := &resources.AccountESDTBalanceApiResponse{}
 
-	err = provider.getResource(url, response)
+	err = provider.getResourceWithOptions(ctx, url, options, response)
 	if err != nil {
 		return nil, newErrCannotGetAccount(address, err)
 	}
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -32,12 +33,12 @@ func TestNetworkProvider_GetAccount(t *testing.T) {
 			},
 		}
 
-		account, err := provider.GetAccount(testscommon.TestAddressAlice)
+		account, err := provider.GetAccount(context.Background(), testscommon.TestAddressAlice)
 		require.Nil(t, err)
 		require.Equal(t, testscommon.TestAddressAlice, account.Account.Address)
 		require.Equal(t, "1", account.Account.Balance)
 		require.Equal(t, uint64(1000), account.BlockCoordinates.Nonce)
-		require.Equal(t, args.ObserverUrl, observerFacade.RecordedBaseUrl)
+		require.Equal(t, testObserverUrl, observerFacade.RecordedBaseUrl)
 		require.Equal(t, "/address/erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th?onFinalBlock=true", observerFacade.RecordedPath)
 	})
 
@@ -45,14 +46,49 @@ func TestNetworkProvider_GetAccount(t *testing.T) {
 		observerFacade.MockNextError = errors.New("arbitrary error")
 		observerFacade.MockGetResponse = nil
 
-		account, err := provider.GetAccount(testscommon.TestAddressAlice)
+		account, err := provider.GetAccount(context.Background(), testscommon.TestAddressAlice)
 		require.ErrorIs(t, err, errCannotGetAccount)
 		require.Nil(t, account)
-		require.Equal(t, args.ObserverUrl, observerFacade.RecordedBaseUrl)
+		require.Equal(t, testObserverUrl, observerFacade.RecordedBaseUrl)
 		require.Equal(t, "/address/erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th?onFinalBlock=true", observerFacade.RecordedPath)
 	})
 }
 
+func TestNetworkProvider_GetAccountNonceOnLatestBlock(t *testing.T) {
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+	require.NotNil(t, provider)
+
+	t.Run("with success", func(t *testing.T) {
+		observerFacade.MockNextError = nil
+		observerFacade.MockGetResponse = resources.AccountApiResponse{
+			Data: resources.AccountOnBlock{
+				Account: resources.Account{
+					Balance: "1",
+					Nonce:   42,
+				},
+			},
+		}
+
+		nonce, err := provider.GetAccountNonceOnLatestBlock(context.Background(), testscommon.TestAddressAlice)
+		require.Nil(t, err)
+		require.Equal(t, uint64(42), nonce)
+		require.Equal(t, "/address/erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th", observerFacade.RecordedPath)
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		observerFacade.MockNextError = errors.New("arbitrary error")
+		observerFacade.MockGetResponse = nil
+
+		_, err := provider.GetAccountNonceOnLatestBlock(context.Background(), testscommon.TestAddressAlice)
+		require.ErrorIs(t, err, errCannotGetAccount)
+	})
+}
+
 func TestNetworkProvider_GetAccountBalance(t *testing.T) {
 	observerFacade := testscommon.NewObserverFacadeMock()
 	args := createDefaultArgsNewNetworkProvider()
@@ -78,12 +114,12 @@ func TestNetworkProvider_GetAccountBalance(t *testing.T) {
 			},
 		}
 
-		accountBalance, err := provider.GetAccountBalance(testscommon.TestAddressAlice, "XeGLD", optionsOnFinal)
+		accountBalance, err := provider.GetAccountBalance(context.Background(), testscommon.TestAddressAlice, "XeGLD", optionsOnFinal)
 		require.Nil(t, err)
 		require.Equal(t, "1", accountBalance.Balance)
 		require.Equal(t, uint64(42), accountBalance.Nonce.Value)
 		require.Equal(t, uint64(1000), accountBalance.BlockCoordinates.Nonce)
-		require.Equal(t, args.ObserverUrl, observerFacade.RecordedBaseUrl)
+		require.Equal(t, testObserverUrl, observerFacade.RecordedBaseUrl)
 		require.Equal(t, "/address/erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th?onFinalBlock=true", observerFacade.RecordedPath)
 	})
 
@@ -91,10 +127,10 @@ func TestNetworkProvider_GetAccountBalance(t *testing.T) {
 		observerFacade.MockNextError = errors.New("arbitrary error")
 		observerFacade.MockGetResponse = nil
 
-		accountBalance, err := provider.GetAccountBalance(testscommon.TestAddressAlice, "XeGLD", optionsOnFinal)
+		accountBalance, err := provider.GetAccountBalance(context.Background(), testscommon.TestAddressAlice, "XeGLD", optionsOnFinal)
 		require.ErrorIs(t, err, errCannotGetAccount)
 		require.Nil(t, accountBalance)
-		require.Equal(t, args.ObserverUrl, observerFacade.RecordedBaseUrl)
+		require.Equal(t, testObserverUrl, observerFacade.RecordedBaseUrl)
 		require.Equal(t, "/address/erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th?onFinalBlock=true", observerFacade.RecordedPath)
 	})
 
@@ -111,12 +147,12 @@ func TestNetworkProvider_GetAccountBalance(t *testing.T) {
 			},
 		}
 
-		accountBalance, err := provider.GetAccountBalance(testscommon.TestAddressAlice, "ABC-abcdef", optionsOnFinal)
+		accountBalance, err := provider.GetAccountBalance(context.Background(), testscommon.TestAddressAlice, "ABC-abcdef", optionsOnFinal)
 		require.Nil(t, err)
 		require.Equal(t, "1", accountBalance.Balance)
 		require.False(t, accountBalance.Nonce.HasValue)
 		require.Equal(t, uint64(1000), accountBalance.BlockCoordinates.Nonce)
-		require.Equal(t, args.ObserverUrl, observerFacade.RecordedBaseUrl)
+		require.Equal(t, testObserverUrl, observerFacade.RecordedBaseUrl)
 		require.Equal(t, "/address/erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th/esdt/ABC-abcdef?onFinalBlock=true", observerFacade.RecordedPath)
 	})
 
@@ -124,10 +160,10 @@ func TestNetworkProvider_GetAccountBalance(t *testing.T) {
 		observerFacade.MockNextError = errors.New("arbitrary error")
 		observerFacade.MockGetResponse = nil
 
-		accountBalance, err := provider.GetAccountBalance(testscommon.TestAddressAlice, "ABC-abcdef", optionsOnFinal)
+		accountBalance, err := provider.GetAccountBalance(context.Background(), testscommon.TestAddressAlice, "ABC-abcdef", optionsOnFinal)
 		require.ErrorIs(t, err, errCannotGetAccount)
 		require.Nil(t, accountBalance)
-		require.Equal(t, args.ObserverUrl, observerFacade.RecordedBaseUrl)
+		require.Equal(t, testObserverUrl, observerFacade.RecordedBaseUrl)
 		require.Equal(t, "/address/erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th/esdt/ABC-abcdef?onFinalBlock=true", observerFacade.RecordedPath)
 	})
 
@@ -144,12 +180,12 @@ func TestNetworkProvider_GetAccountBalance(t *testing.T) {
 			},
 		}
 
-		accountBalance, err := provider.GetAccountBalance(testscommon.TestAddressAlice, "ABC-abcdef-0a", optionsOnFinal)
+		accountBalance, err := provider.GetAccountBalance(context.Background(), testscommon.TestAddressAlice, "ABC-abcdef-0a", optionsOnFinal)
 		require.Nil(t, err)
 		require.Equal(t, "1", accountBalance.Balance)
 		require.False(t, accountBalance.Nonce.HasValue)
 		require.Equal(t, uint64(1000), accountBalance.BlockCoordinates.Nonce)
-		require.Equal(t, args.ObserverUrl, observerFacade.RecordedBaseUrl)
+		require.Equal(t, testObserverUrl, observerFacade.RecordedBaseUrl)
 		require.Equal(t, "/address/erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th/nft/ABC-abcdef/nonce/10?onFinalBlock=true", observerFacade.RecordedPath)
 	})
 
@@ -157,10 +193,10 @@ func TestNetworkProvider_GetAccountBalance(t *testing.T) {
 		observerFacade.MockNextError = errors.New("arbitrary error")
 		observerFacade.MockGetResponse = nil
 
-		accountBalance, err := provider.GetAccountBalance(testscommon.TestAddressAlice, "ABC-abcdef-0a", optionsOnFinal)
+		accountBalance, err := provider.GetAccountBalance(context.Background(), testscommon.TestAddressAlice, "ABC-abcdef-0a", optionsOnFinal)
 		require.ErrorIs(t, err, errCannotGetAccount)
 		require.Nil(t, accountBalance)
-		require.Equal(t, args.ObserverUrl, observerFacade.RecordedBaseUrl)
+		require.Equal(t, testObserverUrl, observerFacade.RecordedBaseUrl)
 		require.Equal(t, "/address/erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th/nft/ABC-abcdef/nonce/10?onFinalBlock=true", observerFacade.RecordedPath)
 	})
 }
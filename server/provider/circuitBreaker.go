@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"sync"
+	"time"
+)
+
+// circuitBreaker stops calling the observer(s) for a while, after a number of consecutive failures (unavailability).
+// Once the open duration elapses, a single (trial) call is let through: if it succeeds, the breaker closes; otherwise, it stays open for another period.
+type circuitBreaker struct {
+	// The number of consecutive failures which opens the breaker (0 disables the breaker)
+	threshold    uint32
+	openDuration time.Duration
+
+	numConsecutiveFailures uint32
+	isOpen                 bool
+	openedAt               time.Time
+	mutex                  sync.Mutex
+}
+
+func newCircuitBreaker(threshold uint32, openDuration time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold:    threshold,
+		openDuration: openDuration,
+	}
+}
+
+// allow decides whether a call should be attempted
+func (breaker *circuitBreaker) allow() bool {
+	if breaker.threshold == 0 {
+		return true
+	}
+
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	if !breaker.isOpen {
+		return true
+	}
+
+	if time.Since(breaker.openedAt) < breaker.openDuration {
+		return false
+	}
+
+	// Let a single trial call through, while the others still fail fast.
+	breaker.openedAt = time.Now()
+	return true
+}
+
+func (breaker *circuitBreaker) recordSuccess() {
+	if breaker.threshold == 0 {
+		return
+	}
+
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	if breaker.isOpen {
+		log.Info("circuitBreaker: closed, the observer is available again", "numConsecutiveFailures", breaker.numConsecutiveFailures)
+	}
+
+	breaker.numConsecutiveFailures = 0
+	breaker.isOpen = false
+}
+
+func (breaker *circuitBreaker) recordFailure() {
+	if breaker.threshold == 0 {
+		return
+	}
+
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	breaker.numConsecutiveFailures++
+
+	if breaker.isOpen {
+		// The trial call has failed.
+		breaker.openedAt = time.Now()
+		return
+	}
+
+	if breaker.numConsecutiveFailures >= breaker.threshold {
+		log.Warn("circuitBreaker: opened, calls will fail fast", "numConsecutiveFailures", breaker.numConsecutiveFailures, "openDuration", breaker.openDuration)
+
+		breaker.isOpen = true
+		breaker.openedAt = time.Now()
+	}
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		breaker := newCircuitBreaker(0, time.Hour)
+
+		for i := 0; i < 10; i++ {
+			breaker.recordFailure()
+		}
+
+		require.True(t, breaker.allow())
+	})
+
+	t.Run("opens after consecutive failures", func(t *testing.T) {
+		t.Parallel()
+
+		breaker := newCircuitBreaker(3, time.Hour)
+
+		breaker.recordFailure()
+		breaker.recordFailure()
+		breaker.recordSuccess()
+		breaker.recordFailure()
+		breaker.recordFailure()
+		require.True(t, breaker.allow())
+
+		breaker.recordFailure()
+		require.False(t, breaker.allow())
+	})
+
+	t.Run("lets a single trial call through, then closes or stays open", func(t *testing.T) {
+		t.Parallel()
+
+		breaker := newCircuitBreaker(1, 20*time.Millisecond)
+
+		breaker.recordFailure()
+		require.False(t, breaker.allow())
+
+		time.Sleep(30 * time.Millisecond)
+		require.True(t, breaker.allow())
+		require.False(t, breaker.allow())
+
+		// The trial call fails.
+		breaker.recordFailure()
+		require.False(t, breaker.allow())
+
+		time.Sleep(30 * time.Millisecond)
+		require.True(t, breaker.allow())
+
+		// The trial call succeeds.
+		breaker.recordSuccess()
+		require.True(t, breaker.allow())
+		require.True(t, breaker.allow())
+	})
+}
@@ -1,8 +1,22 @@
 package provider
 
+import "time"
+
 var (
-	nativeCurrencyNumDecimals = 18
-	genesisBlockNonce         = 0
-	blocksCacheCapacity       = 1024
-	miniblockTypeArtificial   = "Artificial"
+	nativeCurrencyNumDecimals        = 18
+	genesisBlockNonce                = 0
+	blocksCacheCapacity              = 1024
+	miniblockTypeArtificial          = "Artificial"
+	submittedTransactionsDropTimeout = 10 * time.Minute
+	submittedTransactionsRetention   = 1 * time.Hour
+	transactionsPoolFieldNonce       = "nonce"
+	transactionsPoolFieldHash        = "hash"
+	transactionsPoolFieldSender      = "sender"
+	transactionsPoolFieldReceiver    = "receiver"
+)
+
+const (
+	healthRankSynced = iota
+	healthRankSyncing
+	healthRankUnreachable
 )
@@ -13,21 +13,119 @@ var errCannotGetTransaction = errors.New("cannot get transaction")
 var errCannotGetLatestBlockNonce = errors.New("cannot get latest block nonce, maybe the node didn't start syncing")
 var errInvalidCustomCurrencySymbol = errors.New("invalid custom currency symbol")
 var errCannotParseTokenIdentifier = errors.New("cannot parse token identifier")
+var errCannotEstimateTransactionGas = errors.New("cannot estimate transaction gas")
+var errSimulatedExecutionFailed = errors.New("simulated execution failed")
+var errCannotGetNetworkConfig = errors.New("cannot get network config")
+var errCannotSimulateTransaction = errors.New("cannot simulate transaction")
+var errCannotGetTransactionsPool = errors.New("cannot get transactions pool")
+var errCannotResolveUsername = errors.New("cannot resolve username")
+var errUsernameNotFound = errors.New("username not found")
+var errCannotQueryContract = errors.New("cannot query contract")
+var errCannotGetTokenProperties = errors.New("cannot get token properties")
+var errNoObservers = errors.New("no observers")
+var errBadNumFirstHistoricalEpochs = errors.New("the number of first historical epochs must match the number of observers")
+var errDuplicatedObserver = errors.New("duplicated observer")
+var errNoObserverHavingEpoch = errors.New("no observer holds the state of the requested epoch")
+var errShardNotObserved = errors.New("shard not observed")
+var errObserverUnavailable = errors.New("observer unavailable")
+var errObserverUnreachable = errors.New("observer unreachable")
+var errCircuitBreakerOpen = errors.New("circuit breaker is open (the observer has been failing repeatedly)")
 
 func newErrCannotGetBlockByNonce(nonce uint64, innerError error) error {
-	return fmt.Errorf("%w: %v, nonce = %d", errCannotGetBlock, innerError, nonce)
+	return fmt.Errorf("%w: %w, nonce = %d", errCannotGetBlock, innerError, nonce)
 }
 
 func newErrCannotGetBlockByHash(hash string, innerError error) error {
-	return fmt.Errorf("%w: %v, hash = %s", errCannotGetBlock, innerError, hash)
+	return fmt.Errorf("%w: %w, hash = %s", errCannotGetBlock, innerError, hash)
 }
 
 func newErrCannotGetAccount(address string, innerError error) error {
-	return fmt.Errorf("%w: %v, address = %s", errCannotGetAccount, innerError, address)
+	return fmt.Errorf("%w: %w, address = %s", errCannotGetAccount, innerError, address)
 }
 
 func newErrCannotGetTransaction(hash string, innerError error) error {
-	return fmt.Errorf("%w: %v, address = %s", errCannotGetTransaction, innerError, hash)
+	return fmt.Errorf("%w: %w, address = %s", errCannotGetTransaction, innerError, hash)
+}
+
+func newErrCannotEstimateTransactionGas(innerError error) error {
+	return fmt.Errorf("%w: %w", errCannotEstimateTransactionGas, innerError)
+}
+
+// NewErrSimulatedExecutionFailed creates the error of a transaction whose (simulated) execution fails, given the message reported by the observer
+func NewErrSimulatedExecutionFailed(returnMessage string) error {
+	return fmt.Errorf("%w: %s", errSimulatedExecutionFailed, returnMessage)
+}
+
+// IsSimulatedExecutionFailedError returns whether the error is caused by the (simulated) execution of a transaction failing (i.e. a deterministic error, as opposed to a transient one)
+func IsSimulatedExecutionFailedError(err error) bool {
+	return errors.Is(err, errSimulatedExecutionFailed)
+}
+
+func newErrCannotGetNetworkConfig(innerError error) error {
+	return fmt.Errorf("%w: %w", errCannotGetNetworkConfig, innerError)
+}
+
+func newErrCannotSimulateTransaction(innerError error) error {
+	return fmt.Errorf("%w: %w", errCannotSimulateTransaction, innerError)
+}
+
+func newErrCannotResolveUsername(username string, innerError error) error {
+	return fmt.Errorf("%w: %w, username = %s", errCannotResolveUsername, innerError, username)
+}
+
+func newErrUsernameNotFound(username string) error {
+	return fmt.Errorf("%w: %s", errUsernameNotFound, username)
+}
+
+func newErrCannotGetTransactionsPoolForSender(sender string, innerError error) error {
+	return fmt.Errorf("%w: %w, sender = %s", errCannotGetTransactionsPool, innerError, sender)
+}
+
+func newErrCannotGetTransactionsPool(innerError error) error {
+	return fmt.Errorf("%w: %w", errCannotGetTransactionsPool, innerError)
+}
+
+func newErrCannotQueryContract(contract string, function string, innerError error) error {
+	return fmt.Errorf("%w: %w, contract = %s, function = %s", errCannotQueryContract, innerError, contract, function)
+}
+
+func newErrCannotGetTokenProperties(tokenIdentifier string, innerError error) error {
+	return fmt.Errorf("%w: %w, tokenIdentifier = %s", errCannotGetTokenProperties, innerError, tokenIdentifier)
+}
+
+func newErrBadNumFirstHistoricalEpochs(numEpochs int, numObservers int) error {
+	return fmt.Errorf("%w: got %d epochs, for %d observers", errBadNumFirstHistoricalEpochs, numEpochs, numObservers)
+}
+
+func newErrDuplicatedObserver(url string) error {
+	return fmt.Errorf("%w: %s", errDuplicatedObserver, url)
+}
+
+func newErrNoObserverHavingEpoch(epoch uint32) error {
+	return fmt.Errorf("%w: epoch = %d", errNoObserverHavingEpoch, epoch)
+}
+
+func newErrShardNotObserved(shard uint32) error {
+	return fmt.Errorf("%w: shard = %d", errShardNotObserved, shard)
+}
+
+func newErrObserverUnavailable(innerError error) error {
+	return fmt.Errorf("%w: %w", errObserverUnavailable, innerError)
+}
+
+// IsObserverUnavailableError returns whether the error is caused by the observer(s) being unreachable (i.e. a transient error)
+func IsObserverUnavailableError(err error) bool {
+	return errors.Is(err, errObserverUnavailable)
+}
+
+// NewErrObserverUnreachable creates the error of an observer which cannot be reached at all (as opposed to an observer responding with an error)
+func NewErrObserverUnreachable(innerError error) error {
+	return fmt.Errorf("%w: %w", errObserverUnreachable, innerError)
+}
+
+// IsObserverUnreachableError returns whether the error is caused by an observer which cannot be reached at all
+func IsObserverUnreachableError(err error) bool {
+	return errors.Is(err, errObserverUnreachable)
 }
 
 func newInvalidCustomCurrency(index int) error {
@@ -14,3 +14,10 @@ func TestConvertStructuredApiErrToFlatErr(t *testing.T) {
 	err = convertStructuredApiErrToFlatErr(errors.New("this is not a structured error"))
 	require.Equal(t, errors.New("this is not a structured error"), err)
 }
+
+func TestIsObserverUnavailableError(t *testing.T) {
+	require.True(t, IsObserverUnavailableError(newErrObserverUnavailable(errors.New("connection refused"))))
+	require.True(t, IsObserverUnavailableError(newErrCannotGetAccount("erd1test", newErrObserverUnavailable(errCircuitBreakerOpen))))
+	require.False(t, IsObserverUnavailableError(newErrCannotGetAccount("erd1test", errors.New("internal error"))))
+	require.False(t, IsObserverUnavailableError(nil))
+}
@@ -1,19 +1,23 @@
 package provider
 
 import (
+	"context"
+
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
 	"github.com/multiversx/mx-chain-proxy-go/common"
 	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
 )
 
 type observerFacade interface {
-	CallGetRestEndPoint(baseUrl string, path string, value interface{}) (int, error)
+	CallGetRestEndPoint(ctx context.Context, baseUrl string, path string, value interface{}) (int, error)
+	CallPostRestEndPoint(ctx context.Context, baseUrl string, path string, data interface{}, response interface{}) (int, error)
 	ComputeShardId(pubKey []byte) uint32
-	SendTransaction(tx *data.Transaction) (int, string, error)
+	SendTransaction(ctx context.Context, tx *data.Transaction) (int, string, error)
 	ComputeTransactionHash(tx *data.Transaction) (string, error)
-	GetTransactionByHashAndSenderAddress(hash string, sender string, withEvents bool) (*transaction.ApiTransactionResult, int, error)
-	GetBlockByHash(shardID uint32, hash string, options common.BlockQueryOptions) (*data.BlockApiResponse, error)
-	GetBlockByNonce(shardID uint32, nonce uint64, options common.BlockQueryOptions) (*data.BlockApiResponse, error)
+	GetTransactionByHashAndSenderAddress(ctx context.Context, hash string, sender string, withEvents bool) (*transaction.ApiTransactionResult, int, error)
+	GetBlockByHash(ctx context.Context, shardID uint32, hash string, options common.BlockQueryOptions) (*data.BlockApiResponse, error)
+	GetBlockByNonce(ctx context.Context, shardID uint32, nonce uint64, options common.BlockQueryOptions) (*data.BlockApiResponse, error)
 }
 
 type resourceApiResponseHandler interface {
@@ -27,3 +31,12 @@ type blocksCache interface {
 	Keys() [][]byte
 	Clear()
 }
+
+type observersPoolHandler interface {
+	Len() int
+	GetAllUrls() []string
+	GetUrlsOrderedByHealth() []string
+	GetUrlsHavingEpoch(epoch uint32) ([]string, error)
+	UpdateHealth(url string, status *resources.NodeStatus, err error)
+	MarkAsUnreachable(url string, err error)
+}
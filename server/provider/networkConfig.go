@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+)
+
+// GetNetworkConfig gets the (live) network config. The returned object must not be mutated by the caller.
+func (provider *networkProvider) GetNetworkConfig() *resources.NetworkConfig {
+	provider.networkConfigMutex.RLock()
+	defer provider.networkConfigMutex.RUnlock()
+
+	return provider.networkConfig
+}
+
+// RefreshNetworkConfig fetches the network parameters (e.g. chain ID, gas price, gas limits) from the observer, and updates the network config.
+// Parameters explicitly provided by the operator (overrides) take precedence over the fetched ones.
+// The chain ID cannot be overridden: transactions constructed with a chain ID other than the observer's would be rejected, anyway.
+func (provider *networkProvider) RefreshNetworkConfig() error {
+	if provider.isOffline {
+		return errIsOffline
+	}
+
+	response := &resources.NetworkConfigApiResponse{}
+	err := provider.getResource(provider.backgroundContext, urlPathGetNetworkConfig, response)
+	if err != nil {
+		return newErrCannotGetNetworkConfig(err)
+	}
+
+	fetched := &response.Data.Config
+
+	provider.networkConfigMutex.Lock()
+	defer provider.networkConfigMutex.Unlock()
+
+	current := provider.networkConfig
+	if len(fetched.ChainID) > 0 && fetched.ChainID != current.NetworkID {
+		log.Error("Chain ID reported by the observer differs from the configured network ID, using the one of the observer", "observer", fetched.ChainID, "configured", current.NetworkID)
+	}
+
+	if provider.lastFetchedNetworkConfig != nil {
+		alertOnChangedNetworkParameters(provider.lastFetchedNetworkConfig, fetched, provider.networkConfigOverrides)
+	}
+
+	overrides := provider.networkConfigOverrides
+
+	// The network config is never mutated in-place (it might be held by concurrent readers); we replace it, instead.
+	updated := *current
+	if len(fetched.ChainID) > 0 {
+		updated.NetworkID = fetched.ChainID
+	}
+
+	updated.MinGasPrice = decideNetworkParameter(current.MinGasPrice, fetched.MinGasPrice, overrides.MinGasPrice)
+	updated.MinGasLimit = decideNetworkParameter(current.MinGasLimit, fetched.MinGasLimit, overrides.MinGasLimit)
+	updated.GasPerDataByte = decideNetworkParameter(current.GasPerDataByte, fetched.GasPerDataByte, overrides.GasPerDataByte)
+	updated.ExtraGasLimitGuardedTx = decideNetworkParameter(current.ExtraGasLimitGuardedTx, fetched.ExtraGasLimitGuardedTx, overrides.ExtraGasLimitGuardedTx)
+	updated.ExtraGasLimitRelayedTxV3 = decideNetworkParameter(current.ExtraGasLimitRelayedTxV3, fetched.ExtraGasLimitRelayedTxV3, overrides.ExtraGasLimitRelayedTxV3)
+
+	fetchedGasPriceModifier, err := fetched.GasPriceModifier.Float64()
+	if err == nil && fetchedGasPriceModifier > 0 && !overrides.GasPriceModifier {
+		updated.GasPriceModifier = fetchedGasPriceModifier
+	}
+
+	provider.networkConfig = &updated
+	provider.lastFetchedNetworkConfig = fetched
+
+	log.Debug("networkProvider.RefreshNetworkConfig()",
+		"chainID", updated.NetworkID,
+		"minGasPrice", updated.MinGasPrice,
+		"minGasLimit", updated.MinGasLimit,
+		"gasPerDataByte", updated.GasPerDataByte,
+		"gasPriceModifier", updated.GasPriceModifier,
+		"extraGasLimitGuardedTx", updated.ExtraGasLimitGuardedTx,
+		"extraGasLimitRelayedTxV3", updated.ExtraGasLimitRelayedTxV3,
+	)
+
+	return nil
+}
+
+// StartNetworkConfigRefreshLoop periodically refreshes the network config (in the background), until the provider is closed.
+// A zero interval disables the refresh.
+func (provider *networkProvider) StartNetworkConfigRefreshLoop(interval time.Duration) {
+	if interval == 0 {
+		log.Info("networkProvider.StartNetworkConfigRefreshLoop(): network config refresh is disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				err := provider.RefreshNetworkConfig()
+				if err != nil {
+					log.Warn("networkProvider.StartNetworkConfigRefreshLoop(): cannot refresh network config", "err", err)
+				}
+			case <-provider.closing:
+				return
+			}
+		}
+	}()
+}
+
+// decideNetworkParameter picks the fetched value, unless it's missing (zero) or the parameter is overridden by the operator
+func decideNetworkParameter(current uint64, fetched uint64, isOverridden bool) uint64 {
+	if isOverridden || fetched == 0 {
+		return current
+	}
+
+	return fetched
+}
+
+func alertOnChangedNetworkParameters(previous *resources.ObserverNetworkConfig, fetched *resources.ObserverNetworkConfig, overrides resources.NetworkConfigOverrides) {
+	alertIfChanged := func(name string, previousValue interface{}, fetchedValue interface{}, isOverridden bool) {
+		if previousValue == fetchedValue {
+			return
+		}
+
+		log.Warn("Network parameter has changed (as reported by the observer)",
+			"name", name,
+			"previous", previousValue,
+			"current", fetchedValue,
+			"isOverridden", isOverridden,
+		)
+	}
+
+	alertIfChanged("chainID", previous.ChainID, fetched.ChainID, false)
+	alertIfChanged("minGasPrice", previous.MinGasPrice, fetched.MinGasPrice, overrides.MinGasPrice)
+	alertIfChanged("minGasLimit", previous.MinGasLimit, fetched.MinGasLimit, overrides.MinGasLimit)
+	alertIfChanged("gasPerDataByte", previous.GasPerDataByte, fetched.GasPerDataByte, overrides.GasPerDataByte)
+	alertIfChanged("gasPriceModifier", previous.GasPriceModifier.String(), fetched.GasPriceModifier.String(), overrides.GasPriceModifier)
+	alertIfChanged("extraGasLimitGuardedTx", previous.ExtraGasLimitGuardedTx, fetched.ExtraGasLimitGuardedTx, overrides.ExtraGasLimitGuardedTx)
+	alertIfChanged("extraGasLimitRelayedTxV3", previous.ExtraGasLimitRelayedTxV3, fetched.ExtraGasLimitRelayedTxV3, overrides.ExtraGasLimitRelayedTxV3)
+}
@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkProvider_RefreshNetworkConfig(t *testing.T) {
+	t.Parallel()
+
+	fetchedConfig := resources.ObserverNetworkConfig{
+		ChainID:                  "T",
+		MinGasPrice:              2000000000,
+		MinGasLimit:              70000,
+		GasPerDataByte:           2000,
+		GasPriceModifier:         "0.02",
+		ExtraGasLimitGuardedTx:   60000,
+		ExtraGasLimitRelayedTxV3: 80000,
+	}
+
+	newProvider := func(args ArgsNewNetworkProvider, config resources.ObserverNetworkConfig, fetchErr error) *networkProvider {
+		observerFacade := testscommon.NewObserverFacadeMock()
+		observerFacade.CallGetRestEndPointCalled = func(baseUrl string, path string, value interface{}) (int, error) {
+			require.Equal(t, "/network/config", path)
+
+			if fetchErr != nil {
+				return 500, fetchErr
+			}
+
+			value.(*resources.NetworkConfigApiResponse).Data.Config = config
+			return 200, nil
+		}
+
+		args.ObserverFacade = observerFacade
+
+		provider, err := NewNetworkProvider(args)
+		require.Nil(t, err)
+		return provider
+	}
+
+	t.Run("without overrides", func(t *testing.T) {
+		t.Parallel()
+
+		provider := newProvider(createDefaultArgsNewNetworkProvider(), fetchedConfig, nil)
+		previousConfig := provider.GetNetworkConfig()
+
+		err := provider.RefreshNetworkConfig()
+		require.Nil(t, err)
+
+		config := provider.GetNetworkConfig()
+		require.Equal(t, uint64(2000000000), config.MinGasPrice)
+		require.Equal(t, uint64(70000), config.MinGasLimit)
+		require.Equal(t, uint64(2000), config.GasPerDataByte)
+		require.Equal(t, 0.02, config.GasPriceModifier)
+		require.Equal(t, uint64(60000), config.ExtraGasLimitGuardedTx)
+		require.Equal(t, uint64(80000), config.ExtraGasLimitRelayedTxV3)
+		require.Equal(t, "T", config.NetworkID)
+		require.Equal(t, "XeGLD", config.NativeCurrencySymbol)
+
+		// Previous config isn't mutated
+		require.Equal(t, uint64(1000000000), previousConfig.MinGasPrice)
+		require.Equal(t, uint64(50000), previousConfig.MinGasLimit)
+	})
+
+	t.Run("with overrides", func(t *testing.T) {
+		t.Parallel()
+
+		args := createDefaultArgsNewNetworkProvider()
+		args.NetworkConfigOverrides = resources.NetworkConfigOverrides{
+			MinGasPrice:      true,
+			GasPriceModifier: true,
+		}
+
+		provider := newProvider(args, fetchedConfig, nil)
+
+		err := provider.RefreshNetworkConfig()
+		require.Nil(t, err)
+
+		config := provider.GetNetworkConfig()
+		require.Equal(t, uint64(1000000000), config.MinGasPrice)
+		require.Equal(t, uint64(70000), config.MinGasLimit)
+		require.Equal(t, uint64(2000), config.GasPerDataByte)
+		require.Equal(t, 0.01, config.GasPriceModifier)
+	})
+
+	t.Run("with missing fields", func(t *testing.T) {
+		t.Parallel()
+
+		provider := newProvider(createDefaultArgsNewNetworkProvider(), resources.ObserverNetworkConfig{MinGasLimit: 70000}, nil)
+		previousConfig := provider.GetNetworkConfig()
+
+		err := provider.RefreshNetworkConfig()
+		require.Nil(t, err)
+
+		config := provider.GetNetworkConfig()
+		require.Equal(t, previousConfig.NetworkID, config.NetworkID)
+		require.Equal(t, uint64(1000000000), config.MinGasPrice)
+		require.Equal(t, uint64(70000), config.MinGasLimit)
+		require.Equal(t, uint64(1500), config.GasPerDataByte)
+		require.Equal(t, 0.01, config.GasPriceModifier)
+	})
+
+	t.Run("with different chain ID", func(t *testing.T) {
+		t.Parallel()
+
+		args := createDefaultArgsNewNetworkProvider()
+		args.NetworkID = "D"
+		args.NetworkConfigOverrides = resources.NetworkConfigOverrides{
+			MinGasPrice: true,
+		}
+
+		provider := newProvider(args, fetchedConfig, nil)
+
+		err := provider.RefreshNetworkConfig()
+		require.Nil(t, err)
+
+		// The chain ID of the observer is applied (even if other parameters are overridden)
+		config := provider.GetNetworkConfig()
+		require.Equal(t, "T", config.NetworkID)
+		require.Equal(t, uint64(1000000000), config.MinGasPrice)
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		t.Parallel()
+
+		provider := newProvider(createDefaultArgsNewNetworkProvider(), fetchedConfig, errors.New("arbitrary error"))
+
+		err := provider.RefreshNetworkConfig()
+		require.ErrorIs(t, err, errCannotGetNetworkConfig)
+		require.ErrorContains(t, err, "arbitrary error")
+
+		config := provider.GetNetworkConfig()
+		require.Equal(t, uint64(1000000000), config.MinGasPrice)
+	})
+
+	t.Run("when offline", func(t *testing.T) {
+		t.Parallel()
+
+		args := createDefaultArgsNewNetworkProvider()
+		args.IsOffline = true
+
+		provider := newProvider(args, fetchedConfig, nil)
+
+		err := provider.RefreshNetworkConfig()
+		require.ErrorIs(t, err, errIsOffline)
+	})
+}
@@ -1,9 +1,12 @@
 package provider
 
 import (
+	"context"
 	"encoding/hex"
 	"errors"
 	"math/big"
+	"sync"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/data/api"
@@ -14,6 +17,7 @@ import (
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-proxy-go/common"
 	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-proxy-go/process"
 	"github.com/multiversx/mx-chain-rosetta/server/resources"
 	"github.com/multiversx/mx-chain-storage-go/lrucache"
 )
@@ -25,7 +29,6 @@ type ArgsNewNetworkProvider struct {
 	ObservedActualShard         uint32
 	ObservedProjectedShard      uint32
 	ObservedProjectedShardIsSet bool
-	ObserverUrl                 string
 	BlockchainName              string
 	NetworkID                   string
 	NetworkName                 string
@@ -36,6 +39,8 @@ type ArgsNewNetworkProvider struct {
 	MinGasLimit                 uint64
 	ExtraGasLimitGuardedTx      uint64
 	ExtraGasLimitRelayedTxV3    uint64
+	GasLimitEstimationMargin    uint64
+	NetworkConfigOverrides      resources.NetworkConfigOverrides
 	NativeCurrencySymbol        string
 	CustomCurrencies            []resources.Currency
 	GenesisBlockHash            string
@@ -43,8 +48,15 @@ type ArgsNewNetworkProvider struct {
 	FirstHistoricalEpoch        uint32
 	NumHistoricalEpochs         uint32
 	ShouldHandleContracts       bool
+	ShouldSimulateBeforeSubmit  bool
+	NumObserverRetries          uint32
+	ObserverRetryBackoff        time.Duration
+	ObserverMaxRetryBackoff     time.Duration
+	CircuitBreakerThreshold     uint32
+	CircuitBreakerOpenDuration  time.Duration
 
 	ObserverFacade observerFacade
+	ObserversPool  observersPoolHandler
 
 	Hasher                hashing.Hasher
 	MarshalizerForHashing marshal.Marshalizer
@@ -59,22 +71,41 @@ type networkProvider struct {
 	observedActualShard         uint32
 	observedProjectedShard      uint32
 	observedProjectedShardIsSet bool
-	observerUrl                 string
 	genesisBlockHash            string
 	genesisTimestamp            int64
 	firstHistoricalEpoch        uint32
 	numHistoricalEpochs         uint32
 	shouldHandleContracts       bool
+	shouldSimulateBeforeSubmit  bool
+	numObserverRetries          uint32
+	observerRetryBackoff        time.Duration
+	observerMaxRetryBackoff     time.Duration
 
 	observerFacade observerFacade
+	observersPool  observersPoolHandler
 
 	hasher                hashing.Hasher
 	marshalizerForHashing marshal.Marshalizer
 	pubKeyConverter       core.PubkeyConverter
 
-	networkConfig *resources.NetworkConfig
+	networkConfig            *resources.NetworkConfig
+	networkConfigOverrides   resources.NetworkConfigOverrides
+	lastFetchedNetworkConfig *resources.ObserverNetworkConfig
+	networkConfigMutex       sync.RWMutex
 
-	blocksCache blocksCache
+	blocksCache    blocksCache
+	circuitBreaker *circuitBreaker
+
+	submissions         map[string]*resources.SubmittedTransaction
+	submissionsInFlight map[string]chan struct{}
+	submissionsMutex    sync.RWMutex
+
+	// The context of the background activities (e.g. refreshing the network config), cancelled on "Close()"
+	backgroundContext       context.Context
+	cancelBackgroundContext context.CancelFunc
+
+	closing     chan struct{}
+	closingOnce sync.Once
 }
 
 // NewNetworkProvider (future-to-be renamed to NewNetworkFacade) creates a new networkProvider
@@ -91,6 +122,8 @@ func NewNetworkProvider(args ArgsNewNetworkProvider) (*networkProvider, error) {
 		return nil, err
 	}
 
+	backgroundContext, cancelBackgroundContext := context.WithCancel(context.Background())
+
 	return &networkProvider{
 		currenciesProvider: currenciesProvider,
 
@@ -99,14 +132,18 @@ func NewNetworkProvider(args ArgsNewNetworkProvider) (*networkProvider, error) {
 		observedActualShard:         args.ObservedActualShard,
 		observedProjectedShard:      args.ObservedProjectedShard,
 		observedProjectedShardIsSet: args.ObservedProjectedShardIsSet,
-		observerUrl:                 args.ObserverUrl,
 		genesisBlockHash:            args.GenesisBlockHash,
 		genesisTimestamp:            args.GenesisTimestamp,
 		firstHistoricalEpoch:        args.FirstHistoricalEpoch,
 		numHistoricalEpochs:         args.NumHistoricalEpochs,
 		shouldHandleContracts:       args.ShouldHandleContracts,
+		shouldSimulateBeforeSubmit:  args.ShouldSimulateBeforeSubmit,
+		numObserverRetries:          args.NumObserverRetries,
+		observerRetryBackoff:        args.ObserverRetryBackoff,
+		observerMaxRetryBackoff:     args.ObserverMaxRetryBackoff,
 
 		observerFacade: args.ObserverFacade,
+		observersPool:  args.ObserversPool,
 
 		hasher:                args.Hasher,
 		marshalizerForHashing: args.MarshalizerForHashing,
@@ -123,9 +160,20 @@ func NewNetworkProvider(args ArgsNewNetworkProvider) (*networkProvider, error) {
 			MinGasLimit:              args.MinGasLimit,
 			ExtraGasLimitGuardedTx:   args.ExtraGasLimitGuardedTx,
 			ExtraGasLimitRelayedTxV3: args.ExtraGasLimitRelayedTxV3,
+			GasLimitEstimationMargin: args.GasLimitEstimationMargin,
 		},
+		networkConfigOverrides: args.NetworkConfigOverrides,
+
+		blocksCache:    blocksCache,
+		circuitBreaker: newCircuitBreaker(args.CircuitBreakerThreshold, args.CircuitBreakerOpenDuration),
 
-		blocksCache: blocksCache,
+		submissions:         make(map[string]*resources.SubmittedTransaction),
+		submissionsInFlight: make(map[string]chan struct{}),
+
+		backgroundContext:       backgroundContext,
+		cancelBackgroundContext: cancelBackgroundContext,
+
+		closing: make(chan struct{}),
 	}, nil
 }
 
@@ -134,14 +182,14 @@ func (provider *networkProvider) IsOffline() bool {
 	return provider.isOffline
 }
 
-// GetBlockchainName returns the name of the network
-func (provider *networkProvider) GetBlockchainName() string {
-	return provider.networkConfig.BlockchainName
+// ShouldSimulateBeforeSubmit returns whether transactions should be simulated (dry-run) before being broadcasted
+func (provider *networkProvider) ShouldSimulateBeforeSubmit() bool {
+	return provider.shouldSimulateBeforeSubmit
 }
 
-// GetNetworkConfig gets the network config (once fetched, the network config is indefinitely held in memory)
-func (provider *networkProvider) GetNetworkConfig() *resources.NetworkConfig {
-	return provider.networkConfig
+// GetBlockchainName returns the name of the network
+func (provider *networkProvider) GetBlockchainName() string {
+	return provider.GetNetworkConfig().BlockchainName
 }
 
 // GetGenesisBlockSummary gets a summary of the genesis block
@@ -158,13 +206,13 @@ func (provider *networkProvider) GetGenesisTimestamp() int64 {
 	return provider.genesisTimestamp
 }
 
-func (provider *networkProvider) GetGenesisBalances() ([]*resources.GenesisBalance, error) {
+func (provider *networkProvider) GetGenesisBalances(ctx context.Context) ([]*resources.GenesisBalance, error) {
 	if provider.isOffline {
 		return nil, errIsOffline
 	}
 
 	response := &resources.GenesisBalancesApiResponse{}
-	err := provider.getResource(urlPathGetGenesisBalances, response)
+	err := provider.getResource(ctx, urlPathGetGenesisBalances, response)
 	if err != nil {
 		return nil, err
 	}
@@ -172,7 +220,17 @@ func (provider *networkProvider) GetGenesisBalances() ([]*resources.GenesisBalan
 	return response.Data.Balances, nil
 }
 
-func (provider *networkProvider) getBlockSummaryByNonce(nonce uint64) (resources.BlockSummary, error) {
+// GetBlockSummaryByNonce gets a summary of a block (e.g. hash, timestamp), without fetching its transactions
+func (provider *networkProvider) GetBlockSummaryByNonce(ctx context.Context, nonce uint64) (*resources.BlockSummary, error) {
+	summary, err := provider.getBlockSummaryByNonce(ctx, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return &summary, nil
+}
+
+func (provider *networkProvider) getBlockSummaryByNonce(ctx context.Context, nonce uint64) (resources.BlockSummary, error) {
 	if provider.isOffline {
 		return resources.BlockSummary{}, errIsOffline
 	}
@@ -182,11 +240,7 @@ func (provider *networkProvider) getBlockSummaryByNonce(nonce uint64) (resources
 		WithLogs:         false,
 	}
 
-	blockResponse, err := provider.observerFacade.GetBlockByNonce(
-		provider.observedActualShard,
-		nonce,
-		queryOptions,
-	)
+	blockResponse, err := provider.fetchBlockByNonce(ctx, nonce, queryOptions)
 	if err != nil {
 		return resources.BlockSummary{}, newErrCannotGetBlockByNonce(nonce, err)
 	}
@@ -197,16 +251,45 @@ func (provider *networkProvider) getBlockSummaryByNonce(nonce uint64) (resources
 		PreviousBlockHash: blockResponse.Data.Block.PrevBlockHash,
 		Timestamp:         blockResponse.Data.Block.Timestamp,
 		TimestampMs:       blockResponse.Data.Block.TimestampMs,
+		Epoch:             blockResponse.Data.Block.Epoch,
+	}, nil
+}
+
+func (provider *networkProvider) getBlockSummaryByHash(ctx context.Context, hash string) (resources.BlockSummary, error) {
+	if provider.isOffline {
+		return resources.BlockSummary{}, errIsOffline
+	}
+
+	queryOptions := common.BlockQueryOptions{
+		WithTransactions: false,
+		WithLogs:         false,
+	}
+
+	blockResponse, err := provider.fetchBlockByHash(ctx, hash, queryOptions)
+	if err != nil {
+		return resources.BlockSummary{}, newErrCannotGetBlockByHash(hash, err)
+	}
+	if blockResponse.Error != "" {
+		return resources.BlockSummary{}, newErrCannotGetBlockByHash(hash, errors.New(blockResponse.Error))
+	}
+
+	return resources.BlockSummary{
+		Nonce:             blockResponse.Data.Block.Nonce,
+		Hash:              blockResponse.Data.Block.Hash,
+		PreviousBlockHash: blockResponse.Data.Block.PrevBlockHash,
+		Timestamp:         blockResponse.Data.Block.Timestamp,
+		TimestampMs:       blockResponse.Data.Block.TimestampMs,
+		Epoch:             blockResponse.Data.Block.Epoch,
 	}, nil
 }
 
 // GetBlockByNonce gets a block by nonce
-func (provider *networkProvider) GetBlockByNonce(nonce uint64) (*api.Block, error) {
+func (provider *networkProvider) GetBlockByNonce(ctx context.Context, nonce uint64) (*api.Block, error) {
 	if provider.isOffline {
 		return nil, errIsOffline
 	}
 
-	latestNonce, err := provider.getLatestBlockNonce()
+	latestNonce, err := provider.getLatestBlockNonce(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -215,7 +298,7 @@ func (provider *networkProvider) GetBlockByNonce(nonce uint64) (*api.Block, erro
 		return nil, errCannotGetBlock
 	}
 
-	block, err := provider.doGetBlockByNonce(nonce)
+	block, err := provider.doGetBlockByNonce(ctx, nonce)
 	if err != nil {
 		log.Warn("GetBlockByNonce()", "nonce", nonce, "err", err)
 		return nil, err
@@ -223,7 +306,7 @@ func (provider *networkProvider) GetBlockByNonce(nonce uint64) (*api.Block, erro
 
 	// The block (copy) returned by doGetBlockByNonce() is now mutated.
 	// The mutated copy is not held in a cache (not needed).
-	err = provider.simplifyBlockWithScheduledTransactions(block)
+	err = provider.simplifyBlockWithScheduledTransactions(ctx, block)
 	if err != nil {
 		return nil, err
 	}
@@ -231,7 +314,7 @@ func (provider *networkProvider) GetBlockByNonce(nonce uint64) (*api.Block, erro
 	return block, nil
 }
 
-func (provider *networkProvider) doGetBlockByNonce(nonce uint64) (*api.Block, error) {
+func (provider *networkProvider) doGetBlockByNonce(ctx context.Context, nonce uint64) (*api.Block, error) {
 	queryOptions := common.BlockQueryOptions{
 		WithTransactions: true,
 		WithLogs:         true,
@@ -242,7 +325,7 @@ func (provider *networkProvider) doGetBlockByNonce(nonce uint64) (*api.Block, er
 		return createBlockCopy(block), nil
 	}
 
-	response, err := provider.observerFacade.GetBlockByNonce(provider.observedActualShard, nonce, queryOptions)
+	response, err := provider.fetchBlockByNonce(ctx, nonce, queryOptions)
 	if err != nil {
 		return nil, newErrCannotGetBlockByNonce(nonce, convertStructuredApiErrToFlatErr(err))
 	}
@@ -308,18 +391,18 @@ func (provider *networkProvider) cacheBlockByNonce(nonce uint64, block *api.Bloc
 }
 
 // GetBlockByHash gets a block by hash
-func (provider *networkProvider) GetBlockByHash(hash string) (*api.Block, error) {
+func (provider *networkProvider) GetBlockByHash(ctx context.Context, hash string) (*api.Block, error) {
 	if provider.isOffline {
 		return nil, errIsOffline
 	}
 
-	block, err := provider.doGetBlockByHash(hash)
+	block, err := provider.doGetBlockByHash(ctx, hash)
 	if err != nil {
 		log.Warn("GetBlockByHash()", "hash", hash, "err", err)
 		return nil, err
 	}
 
-	err = provider.simplifyBlockWithScheduledTransactions(block)
+	err = provider.simplifyBlockWithScheduledTransactions(ctx, block)
 	if err != nil {
 		return nil, err
 	}
@@ -327,13 +410,13 @@ func (provider *networkProvider) GetBlockByHash(hash string) (*api.Block, error)
 	return block, nil
 }
 
-func (provider *networkProvider) doGetBlockByHash(hash string) (*api.Block, error) {
+func (provider *networkProvider) doGetBlockByHash(ctx context.Context, hash string) (*api.Block, error) {
 	queryOptions := common.BlockQueryOptions{
 		WithTransactions: true,
 		WithLogs:         true,
 	}
 
-	response, err := provider.observerFacade.GetBlockByHash(provider.observedActualShard, hash, queryOptions)
+	response, err := provider.fetchBlockByHash(ctx, hash, queryOptions)
 	if err != nil {
 		return nil, newErrCannotGetBlockByHash(hash, convertStructuredApiErrToFlatErr(err))
 	}
@@ -344,6 +427,42 @@ func (provider *networkProvider) doGetBlockByHash(hash string) (*api.Block, erro
 	return &response.Data.Block, nil
 }
 
+// fetchBlockByNonce fetches a block from the observers, retrying while they are unavailable
+func (provider *networkProvider) fetchBlockByNonce(ctx context.Context, nonce uint64, queryOptions common.BlockQueryOptions) (*data.BlockApiResponse, error) {
+	var response *data.BlockApiResponse
+
+	err := provider.doWithRetries(ctx, func() error {
+		var err error
+		response, err = provider.observerFacade.GetBlockByNonce(ctx, provider.observedActualShard, nonce, queryOptions)
+		return convertBlockFetchErr(err)
+	})
+
+	return response, err
+}
+
+// fetchBlockByHash fetches a block from the observers, retrying while they are unavailable
+func (provider *networkProvider) fetchBlockByHash(ctx context.Context, hash string, queryOptions common.BlockQueryOptions) (*data.BlockApiResponse, error) {
+	var response *data.BlockApiResponse
+
+	err := provider.doWithRetries(ctx, func() error {
+		var err error
+		response, err = provider.observerFacade.GetBlockByHash(ctx, provider.observedActualShard, hash, queryOptions)
+		return convertBlockFetchErr(err)
+	})
+
+	return response, err
+}
+
+// convertBlockFetchErr interprets the errors of proxy-go's block processor: the bare "ErrSendingRequest" means that no observer has responded at all
+// (otherwise, the error of the observer's response would have been attached).
+func convertBlockFetchErr(err error) error {
+	if err == process.ErrSendingRequest {
+		return newErrObserverUnavailable(err)
+	}
+
+	return err
+}
+
 // IsAddressObserved returns whether the address is observed (i.e. is located in an observed shard)
 func (provider *networkProvider) IsAddressObserved(address string) (bool, error) {
 	pubKey, err := provider.ConvertAddressToPubKey(address)
@@ -417,28 +536,47 @@ func (provider *networkProvider) ComputeReceiptHash(apiReceipt *transaction.ApiR
 	return receiptHashHex, nil
 }
 
-// SendTransaction broadcasts an already-signed transaction
-func (provider *networkProvider) SendTransaction(tx *data.Transaction) (string, error) {
+// SendTransaction broadcasts an already-signed transaction, then tracks it (until it lands in a final block or gets dropped).
+// Re-sending a tracked transaction (not dropped) is idempotent: the transaction isn't broadcasted again, and its known hash is returned.
+func (provider *networkProvider) SendTransaction(ctx context.Context, tx *data.Transaction) (string, error) {
 	if provider.isOffline {
 		return "", errIsOffline
 	}
 
-	_, hash, err := provider.observerFacade.SendTransaction(tx)
+	knownHash, err := provider.observerFacade.ComputeTransactionHash(tx)
+	if err != nil {
+		return "", err
+	}
+
+	// Concurrent submissions of the same transaction are serialized, so that only the first one is broadcasted.
+	release, err := provider.acquireSubmissionGuard(ctx, knownHash)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	if provider.isSubmissionTracked(knownHash) {
+		log.Debug("SendTransaction(): transaction already submitted", "sender", tx.Sender, "nonce", tx.Nonce, "hash", knownHash)
+		return knownHash, nil
+	}
+
+	_, hash, err := provider.observerFacade.SendTransaction(ctx, tx)
 	if err != nil {
 		log.Warn("SendTransaction()", "sender", tx.Sender, "nonce", tx.Nonce, "err", err)
 		return "", err
 	}
 
+	provider.trackSubmission(hash, tx)
 	return hash, nil
 }
 
 // GetMempoolTransactionByHash gets a transaction from the pool
-func (provider *networkProvider) GetMempoolTransactionByHash(hash string) (*transaction.ApiTransactionResult, error) {
+func (provider *networkProvider) GetMempoolTransactionByHash(ctx context.Context, hash string) (*transaction.ApiTransactionResult, error) {
 	if provider.isOffline {
 		return nil, errIsOffline
 	}
 
-	tx, _, err := provider.observerFacade.GetTransactionByHashAndSenderAddress(hash, "", false)
+	tx, _, err := provider.observerFacade.GetTransactionByHashAndSenderAddress(ctx, hash, "", false)
 	if err != nil {
 		return nil, newErrCannotGetTransaction(hash, err)
 	}
@@ -452,10 +590,11 @@ func (provider *networkProvider) GetMempoolTransactionByHash(hash string) (*tran
 
 // ComputeTransactionFeeForMoveBalance computes the fee for a move-balance transaction
 func (provider *networkProvider) ComputeTransactionFeeForMoveBalance(tx *transaction.ApiTransactionResult) *big.Int {
-	minGasLimit := provider.networkConfig.MinGasLimit
-	extraGasLimitGuardedTx := provider.networkConfig.ExtraGasLimitGuardedTx
-	extraGasLimitRelayedTxV3 := provider.networkConfig.ExtraGasLimitRelayedTxV3
-	gasPerDataByte := provider.networkConfig.GasPerDataByte
+	networkConfig := provider.GetNetworkConfig()
+	minGasLimit := networkConfig.MinGasLimit
+	extraGasLimitGuardedTx := networkConfig.ExtraGasLimitGuardedTx
+	extraGasLimitRelayedTxV3 := networkConfig.ExtraGasLimitRelayedTxV3
+	gasPerDataByte := networkConfig.GasPerDataByte
 	gasLimit := minGasLimit + gasPerDataByte*uint64(len(tx.Data))
 
 	isGuarded := len(tx.GuardianAddr) > 0
@@ -474,18 +613,35 @@ func (provider *networkProvider) ComputeTransactionFeeForMoveBalance(tx *transac
 
 // LogDescription writes a description of the network provider in the log output
 func (provider *networkProvider) LogDescription() {
+	networkConfig := provider.GetNetworkConfig()
+
 	log.Info("Description of network provider",
-		"blockchain", provider.networkConfig.BlockchainName,
-		"network", provider.networkConfig.NetworkName,
+		"blockchain", networkConfig.BlockchainName,
+		"network", networkConfig.NetworkName,
 		"isOffline", provider.isOffline,
-		"observerUrl", provider.observerUrl,
+		"observers", provider.observersPool.GetAllUrls(),
 		"observedActualShard", provider.observedActualShard,
 		"observedProjectedShard", provider.observedProjectedShard,
 		"observedProjectedShardIsSet", provider.observedProjectedShardIsSet,
 		"firstHistoricalEpoch", provider.firstHistoricalEpoch,
 		"numHistoricalEpochs", provider.numHistoricalEpochs,
 		"shouldHandleContracts", provider.shouldHandleContracts,
+		"shouldSimulateBeforeSubmit", provider.shouldSimulateBeforeSubmit,
 		"nativeCurrency", provider.GetNativeCurrency().Symbol,
 		"customCurrencies", provider.GetCustomCurrenciesSymbols(),
+		"minGasPrice", networkConfig.MinGasPrice,
+		"minGasLimit", networkConfig.MinGasLimit,
+		"gasPerDataByte", networkConfig.GasPerDataByte,
+		"gasPriceModifier", networkConfig.GasPriceModifier,
 	)
 }
+
+// Close stops the background activities of the network provider
+func (provider *networkProvider) Close() error {
+	provider.closingOnce.Do(func() {
+		close(provider.closing)
+		provider.cancelBackgroundContext()
+	})
+
+	return nil
+}
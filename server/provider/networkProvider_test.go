@@ -1,27 +1,31 @@
 package provider
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/data/api"
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
 	"github.com/multiversx/mx-chain-proxy-go/common"
 	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-proxy-go/process"
 	"github.com/multiversx/mx-chain-rosetta/server/resources"
 	"github.com/multiversx/mx-chain-rosetta/testscommon"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+const testObserverUrl = "http://my-observer:8080"
+
 func TestNewNetworkProvider(t *testing.T) {
 	args := ArgsNewNetworkProvider{
 		IsOffline:                   true,
 		ObservedActualShard:         42,
 		ObservedProjectedShard:      42,
 		ObservedProjectedShardIsSet: true,
-		ObserverUrl:                 "http://my-observer:8080",
 		NetworkID:                   "T",
 		NetworkName:                 "testnet",
 		GasPerDataByte:              1501,
@@ -31,6 +35,7 @@ func TestNewNetworkProvider(t *testing.T) {
 		MinGasLimit:                 50001,
 		ExtraGasLimitGuardedTx:      50002,
 		ExtraGasLimitRelayedTxV3:    50003,
+		GasLimitEstimationMargin:    10,
 		NativeCurrencySymbol:        "XeGLD",
 		CustomCurrencies: []resources.Currency{
 			{Symbol: "FOO-abcdef", Decimals: 6},
@@ -42,6 +47,7 @@ func TestNewNetworkProvider(t *testing.T) {
 		NumHistoricalEpochs:   1024,
 		ShouldHandleContracts: true,
 		ObserverFacade:        testscommon.NewObserverFacadeMock(),
+		ObserversPool:         createObserversPool(testObserverUrl),
 		Hasher:                testscommon.RealWorldBlake2bHasher,
 		MarshalizerForHashing: testscommon.MarshalizerForHashing,
 		PubKeyConverter:       testscommon.RealWorldBech32PubkeyConverter,
@@ -55,7 +61,7 @@ func TestNewNetworkProvider(t *testing.T) {
 	assert.Equal(t, uint32(42), provider.observedActualShard)
 	assert.Equal(t, uint32(42), provider.observedProjectedShard)
 	assert.Equal(t, true, provider.observedProjectedShardIsSet)
-	assert.Equal(t, "http://my-observer:8080", provider.observerUrl)
+	assert.Equal(t, []string{testObserverUrl}, provider.observersPool.GetAllUrls())
 	assert.Equal(t, "T", provider.GetNetworkConfig().NetworkID)
 	assert.Equal(t, "testnet", provider.GetNetworkConfig().NetworkName)
 	assert.Equal(t, uint64(1501), provider.GetNetworkConfig().GasPerDataByte)
@@ -65,6 +71,7 @@ func TestNewNetworkProvider(t *testing.T) {
 	assert.Equal(t, uint64(50001), provider.GetNetworkConfig().MinGasLimit)
 	assert.Equal(t, uint64(50002), provider.GetNetworkConfig().ExtraGasLimitGuardedTx)
 	assert.Equal(t, uint64(50003), provider.GetNetworkConfig().ExtraGasLimitRelayedTxV3)
+	assert.Equal(t, uint64(10), provider.GetNetworkConfig().GasLimitEstimationMargin)
 	assert.Equal(t, "XeGLD", provider.GetNativeCurrency().Symbol)
 	assert.Equal(t, []resources.Currency{
 		{Symbol: "FOO-abcdef", Decimals: 6},
@@ -91,7 +98,7 @@ func TestNetworkProvider_DoGetBlockByNonce(t *testing.T) {
 			return nil, errors.New("arbitrary error")
 		}
 
-		block, err := provider.doGetBlockByNonce(42)
+		block, err := provider.doGetBlockByNonce(context.Background(), 42)
 		require.Nil(t, block)
 		require.ErrorContains(t, err, "arbitrary error")
 		require.Equal(t, 0, provider.blocksCache.Len())
@@ -113,7 +120,7 @@ func TestNetworkProvider_DoGetBlockByNonce(t *testing.T) {
 			return nil, errors.New("unexpected request")
 		}
 
-		block, err := provider.doGetBlockByNonce(42)
+		block, err := provider.doGetBlockByNonce(context.Background(), 42)
 		require.Nil(t, err)
 		require.Equal(t, uint64(42), block.Nonce)
 		require.Equal(t, 1, provider.blocksCache.Len())
@@ -122,7 +129,7 @@ func TestNetworkProvider_DoGetBlockByNonce(t *testing.T) {
 			return nil, errors.New("unexpected request")
 		}
 
-		cachedBlock, err := provider.doGetBlockByNonce(42)
+		cachedBlock, err := provider.doGetBlockByNonce(context.Background(), 42)
 		require.Nil(t, err)
 		require.Equal(t, block, cachedBlock)
 	})
@@ -139,7 +146,7 @@ func TestNetworkProvider_DoGetBlockByNonce(t *testing.T) {
 		}
 
 		for i := uint64(0); i < uint64(blocksCacheCapacity*2); i++ {
-			block, err := provider.doGetBlockByNonce(i)
+			block, err := provider.doGetBlockByNonce(context.Background(), i)
 			require.Nil(t, err)
 			require.Equal(t, i, block.Nonce)
 
@@ -165,7 +172,7 @@ func TestNetworkProvider_DoGetBlockByNonce(t *testing.T) {
 			}, nil
 		}
 
-		block, err := provider.doGetBlockByNonce(7)
+		block, err := provider.doGetBlockByNonce(context.Background(), 7)
 		require.Nil(t, err)
 		require.Equal(t, uint64(7), block.Nonce)
 		require.Len(t, block.MiniBlocks, 2)
@@ -174,7 +181,7 @@ func TestNetworkProvider_DoGetBlockByNonce(t *testing.T) {
 		// Simulate mutations performed by downstream handling of blocks, i.e. "simplifyBlockWithScheduledTransactions":
 		block.MiniBlocks = []*api.MiniBlock{}
 
-		cachedBlock, err := provider.doGetBlockByNonce(7)
+		cachedBlock, err := provider.doGetBlockByNonce(context.Background(), 7)
 		require.Nil(t, err)
 		require.Equal(t, uint64(7), cachedBlock.Nonce)
 		// Miniblocks removal (above) does not reflect in the cached data
@@ -207,7 +214,7 @@ func TestNetworkProvider_DoGetBlockByNonce(t *testing.T) {
 			}, nil
 		}
 
-		block, err := provider.doGetBlockByNonce(7)
+		block, err := provider.doGetBlockByNonce(context.Background(), 7)
 		require.Nil(t, err)
 		require.Equal(t, uint64(7), block.Nonce)
 		require.Len(t, block.MiniBlocks, 2)
@@ -218,7 +225,7 @@ func TestNetworkProvider_DoGetBlockByNonce(t *testing.T) {
 			{Hash: "aaaa"},
 		}
 
-		cachedBlock, err := provider.doGetBlockByNonce(7)
+		cachedBlock, err := provider.doGetBlockByNonce(context.Background(), 7)
 		require.Nil(t, err)
 		require.Equal(t, uint64(7), cachedBlock.Nonce)
 		require.Len(t, cachedBlock.MiniBlocks, 2)
@@ -382,7 +389,6 @@ func createDefaultArgsNewNetworkProvider() ArgsNewNetworkProvider {
 		ObservedActualShard:         0,
 		ObservedProjectedShard:      0,
 		ObservedProjectedShardIsSet: false,
-		ObserverUrl:                 "http://my-observer:8080",
 		NetworkID:                   "T",
 		GasPerDataByte:              1500,
 		GasPriceModifier:            0.01,
@@ -395,8 +401,78 @@ func createDefaultArgsNewNetworkProvider() ArgsNewNetworkProvider {
 		GenesisBlockHash:            strings.Repeat("0", 64),
 		GenesisTimestamp:            123456789,
 		ObserverFacade:              testscommon.NewObserverFacadeMock(),
+		ObserversPool:               createObserversPool(testObserverUrl),
 		Hasher:                      testscommon.RealWorldBlake2bHasher,
 		MarshalizerForHashing:       testscommon.MarshalizerForHashing,
 		PubKeyConverter:             testscommon.RealWorldBech32PubkeyConverter,
 	}
 }
+
+func createObserversPool(urls ...string) *observersPool {
+	pool, _ := NewObserversPool(ArgsNewObserversPool{
+		Urls:                urls,
+		NumHistoricalEpochs: 2,
+	})
+
+	return pool
+}
+
+func TestNetworkProvider_FetchBlockWithRetries(t *testing.T) {
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+	args.NumObserverRetries = 1
+	args.ObserverRetryBackoff = time.Millisecond
+	args.ObserverMaxRetryBackoff = time.Millisecond
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	numCalls := 0
+
+	t.Run("no observer responds, then the observer recovers", func(t *testing.T) {
+		numCalls = 0
+
+		observerFacade.GetBlockByNonceCalled = func(shardID uint32, nonce uint64, options common.BlockQueryOptions) (*data.BlockApiResponse, error) {
+			numCalls++
+			if numCalls == 1 {
+				return nil, process.ErrSendingRequest
+			}
+
+			return &data.BlockApiResponse{Data: data.BlockApiResponsePayload{Block: api.Block{Nonce: nonce, Epoch: 7}}}, nil
+		}
+
+		summary, err := provider.GetBlockSummaryByNonce(context.Background(), 42)
+		require.Nil(t, err)
+		require.Equal(t, uint32(7), summary.Epoch)
+		require.Equal(t, 2, numCalls)
+	})
+
+	t.Run("no observer responds", func(t *testing.T) {
+		numCalls = 0
+
+		observerFacade.GetBlockByNonceCalled = func(shardID uint32, nonce uint64, options common.BlockQueryOptions) (*data.BlockApiResponse, error) {
+			numCalls++
+			return nil, process.ErrSendingRequest
+		}
+
+		_, err := provider.GetBlockSummaryByNonce(context.Background(), 42)
+		require.ErrorIs(t, err, errCannotGetBlock)
+		require.True(t, IsObserverUnavailableError(err))
+		require.Equal(t, 2, numCalls)
+	})
+
+	t.Run("an observer responds with error", func(t *testing.T) {
+		numCalls = 0
+
+		observerFacade.GetBlockByNonceCalled = func(shardID uint32, nonce uint64, options common.BlockQueryOptions) (*data.BlockApiResponse, error) {
+			numCalls++
+			return nil, process.WrapObserversError("block not found")
+		}
+
+		_, err := provider.GetBlockSummaryByNonce(context.Background(), 42)
+		require.ErrorIs(t, err, errCannotGetBlock)
+		require.False(t, IsObserverUnavailableError(err))
+		require.Equal(t, 1, numCalls)
+	})
+}
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"strconv"
 	"strings"
 
@@ -8,12 +9,12 @@ import (
 )
 
 // GetNodeStatus gets an aggregated node status (e.g. current block, oldest available block etc.)
-func (provider *networkProvider) GetNodeStatus() (*resources.AggregatedNodeStatus, error) {
+func (provider *networkProvider) GetNodeStatus(ctx context.Context) (*resources.AggregatedNodeStatus, error) {
 	if provider.isOffline {
 		return nil, errIsOffline
 	}
 
-	plainNodeStatus, err := provider.getPlainNodeStatus()
+	plainNodeStatus, err := provider.getPlainNodeStatus(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -23,17 +24,17 @@ func (provider *networkProvider) GetNodeStatus() (*resources.AggregatedNodeStatu
 		return nil, err
 	}
 
-	latestBlockSummary, err := provider.getBlockSummaryByNonce(latestNonce)
+	latestBlockSummary, err := provider.getBlockSummaryByNonce(ctx, latestNonce)
 	if err != nil {
 		return nil, err
 	}
 
-	oldestNonceWithHistoricalState, err := provider.getOldestNonceWithHistoricalStateGivenNodeStatus(plainNodeStatus)
+	oldestNonceWithHistoricalState, err := provider.getOldestNonceWithHistoricalStateGivenNodeStatus(ctx, plainNodeStatus)
 	if err != nil {
 		return nil, err
 	}
 
-	oldestBlockWithHistoricalState, err := provider.getBlockSummaryByNonce(oldestNonceWithHistoricalState)
+	oldestBlockWithHistoricalState, err := provider.getBlockSummaryByNonce(ctx, oldestNonceWithHistoricalState)
 	if err != nil {
 		return nil, err
 	}
@@ -47,16 +48,56 @@ func (provider *networkProvider) GetNodeStatus() (*resources.AggregatedNodeStatu
 		Synced:                         plainNodeStatus.IsSyncing == 0,
 		LatestBlock:                    latestBlockSummary,
 		OldestBlockWithHistoricalState: oldestBlockWithHistoricalState,
+		Sync:                           getSyncStatusGivenNodeStatus(plainNodeStatus),
 	}, nil
 }
 
-func (provider *networkProvider) getPlainNodeStatus() (*resources.NodeStatus, error) {
+// GetLatestBlockSummary gets a summary of the latest (final) block, without the overhead of GetNodeStatus()
+func (provider *networkProvider) GetLatestBlockSummary(ctx context.Context) (*resources.BlockSummary, error) {
+	if provider.isOffline {
+		return nil, errIsOffline
+	}
+
+	latestNonce, err := provider.getLatestBlockNonce(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider.GetBlockSummaryByNonce(ctx, latestNonce)
+}
+
+func getSyncStatusGivenNodeStatus(status *resources.NodeStatus) resources.NodeSyncStatus {
+	// Older nodes might not report the probable highest nonce.
+	probableHighestNonce := status.ProbableHighestNonce
+	if probableHighestNonce < status.HighestNonce {
+		probableHighestNonce = status.HighestNonce
+	}
+
+	// The "current round" is given by the clock (it's the round of the network), while the "synchronized round" is the one of the latest block known by the observer.
+	lagInRounds := uint64(0)
+	if status.CurrentRound > status.SynchronizedRound {
+		lagInRounds = status.CurrentRound - status.SynchronizedRound
+	}
+
+	return resources.NodeSyncStatus{
+		CurrentEpoch:         status.CurrentEpoch,
+		CurrentNonce:         status.HighestNonce,
+		ProbableHighestNonce: probableHighestNonce,
+		HighestFinalNonce:    status.HighestFinalNonce,
+		LastExecutedNonce:    status.LastExecutedNonce,
+		SynchronizedRound:    status.SynchronizedRound,
+		NetworkRound:         status.CurrentRound,
+		LagInMilliseconds:    lagInRounds * status.RoundDuration,
+	}
+}
+
+func (provider *networkProvider) getPlainNodeStatus(ctx context.Context) (*resources.NodeStatus, error) {
 	if provider.isOffline {
 		return nil, errIsOffline
 	}
 
 	response := &resources.NodeStatusApiResponse{}
-	err := provider.getResource(urlPathGetNodeStatus, response)
+	err := provider.getResource(ctx, urlPathGetNodeStatus, response)
 	if err != nil {
 		return nil, err
 	}
@@ -64,8 +105,8 @@ func (provider *networkProvider) getPlainNodeStatus() (*resources.NodeStatus, er
 	return &response.Data.Status, nil
 }
 
-func (provider *networkProvider) getLatestBlockNonce() (uint64, error) {
-	nodeStatus, err := provider.getPlainNodeStatus()
+func (provider *networkProvider) getLatestBlockNonce(ctx context.Context) (uint64, error) {
+	nodeStatus, err := provider.getPlainNodeStatus(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -90,9 +131,9 @@ func getLatestNonceGivenHighestFinalNonceAndLastExecutedNonce(highestFinalNonce
 	return nonceToReturn, nil
 }
 
-func (provider *networkProvider) getOldestNonceWithHistoricalStateGivenNodeStatus(status *resources.NodeStatus) (uint64, error) {
+func (provider *networkProvider) getOldestNonceWithHistoricalStateGivenNodeStatus(ctx context.Context, status *resources.NodeStatus) (uint64, error) {
 	oldestEligibleEpoch := provider.getOldestEligibleEpoch(status.CurrentEpoch)
-	epochStartInfo, err := provider.getEpochStartInfo(oldestEligibleEpoch)
+	epochStartInfo, err := provider.getEpochStartInfo(ctx, oldestEligibleEpoch)
 	if err != nil {
 		return 0, err
 	}
@@ -109,14 +150,14 @@ func (provider *networkProvider) getOldestEligibleEpoch(currentEpoch uint32) uin
 	return uint32(oldestEpoch)
 }
 
-func (provider *networkProvider) getEpochStartInfo(epoch uint32) (*resources.EpochStart, error) {
+func (provider *networkProvider) getEpochStartInfo(ctx context.Context, epoch uint32) (*resources.EpochStart, error) {
 	if provider.isOffline {
 		return nil, errIsOffline
 	}
 
 	url := buildUrlGetEpochStartInfo(epoch)
 	response := &resources.EpochStartApiResponse{}
-	err := provider.getResource(url, response)
+	err := provider.getResource(ctx, url, response)
 	if err != nil {
 		return nil, err
 	}
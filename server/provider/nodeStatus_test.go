@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -34,8 +35,13 @@ func TestNetworkProvider_GetNodeStatusWithSuccess(t *testing.T) {
 					ConnectedPeersCounts: "intraVal:0,crossVal:3,intraObs:1,crossObs:3,fullObs:2,unknown:0,",
 					IsSyncing:            1,
 					HighestNonce:         1005,
+					ProbableHighestNonce: 1010,
 					HighestFinalNonce:    1000,
+					LastExecutedNonce:    1004,
 					CurrentEpoch:         11,
+					CurrentRound:         1020,
+					SynchronizedRound:    1015,
+					RoundDuration:        6000,
 				},
 			}
 
@@ -115,7 +121,7 @@ func TestNetworkProvider_GetNodeStatusWithSuccess(t *testing.T) {
 		TimestampMs:       3002000,
 	}
 
-	nodeStatus, err := provider.GetNodeStatus()
+	nodeStatus, err := provider.GetNodeStatus(context.Background())
 	require.Nil(t, err)
 	require.Equal(t, "v1.2.3", nodeStatus.Version)
 	require.Equal(t, "abba", nodeStatus.ObserverPublicKey)
@@ -123,6 +129,62 @@ func TestNetworkProvider_GetNodeStatusWithSuccess(t *testing.T) {
 	require.False(t, nodeStatus.Synced)
 	require.Equal(t, expectedSummaryOfLatest, nodeStatus.LatestBlock)
 	require.Equal(t, expectedSummaryOfOldest, nodeStatus.OldestBlockWithHistoricalState)
+	require.Equal(t, resources.NodeSyncStatus{
+		CurrentEpoch:         11,
+		CurrentNonce:         1005,
+		ProbableHighestNonce: 1010,
+		HighestFinalNonce:    1000,
+		LastExecutedNonce:    1004,
+		SynchronizedRound:    1015,
+		NetworkRound:         1020,
+		LagInMilliseconds:    30000,
+	}, nodeStatus.Sync)
+}
+
+func TestGetSyncStatusGivenNodeStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("synced", func(t *testing.T) {
+		syncStatus := getSyncStatusGivenNodeStatus(&resources.NodeStatus{
+			HighestNonce:         1000,
+			ProbableHighestNonce: 1000,
+			CurrentRound:         1200,
+			SynchronizedRound:    1200,
+			RoundDuration:        6000,
+		})
+
+		require.Equal(t, uint64(1000), syncStatus.CurrentNonce)
+		require.Equal(t, uint64(1000), syncStatus.ProbableHighestNonce)
+		require.Equal(t, uint64(0), syncStatus.LagInMilliseconds)
+	})
+
+	t.Run("lagging, with sub-second rounds", func(t *testing.T) {
+		syncStatus := getSyncStatusGivenNodeStatus(&resources.NodeStatus{
+			CurrentRound:      1203,
+			SynchronizedRound: 1200,
+			RoundDuration:     600,
+		})
+
+		require.Equal(t, uint64(1800), syncStatus.LagInMilliseconds)
+	})
+
+	t.Run("without probable highest nonce", func(t *testing.T) {
+		syncStatus := getSyncStatusGivenNodeStatus(&resources.NodeStatus{
+			HighestNonce: 1000,
+		})
+
+		require.Equal(t, uint64(1000), syncStatus.ProbableHighestNonce)
+	})
+
+	t.Run("synchronized round ahead of the clock", func(t *testing.T) {
+		syncStatus := getSyncStatusGivenNodeStatus(&resources.NodeStatus{
+			CurrentRound:      1199,
+			SynchronizedRound: 1200,
+			RoundDuration:     6000,
+		})
+
+		require.Equal(t, uint64(0), syncStatus.LagInMilliseconds)
+	})
 }
 
 func TestNetworkProvider_GetNodeStatusWithError(t *testing.T) {
@@ -154,11 +216,64 @@ func TestNetworkProvider_GetNodeStatusWithError(t *testing.T) {
 		return nil, errors.New("arbitrary error")
 	}
 
-	nodeStatus, err := provider.GetNodeStatus()
+	nodeStatus, err := provider.GetNodeStatus(context.Background())
 	require.Nil(t, nodeStatus)
 	require.ErrorContains(t, err, "arbitrary error")
 }
 
+func TestNetworkProvider_GetLatestBlockSummary(t *testing.T) {
+	t.Parallel()
+
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+	require.NotNil(t, provider)
+
+	observerFacade.CallGetRestEndPointCalled = func(baseUrl, path string, value interface{}) (int, error) {
+		if path == "/node/status" {
+			value.(*resources.NodeStatusApiResponse).Data = resources.NodeStatusApiResponsePayload{
+				Status: resources.NodeStatus{
+					HighestFinalNonce: 1000,
+				},
+			}
+
+			return 0, nil
+		}
+
+		return 0, errors.New("unexpected request")
+	}
+
+	observerFacade.GetBlockByNonceCalled = func(shardID uint32, nonce uint64, options common.BlockQueryOptions) (*data.BlockApiResponse, error) {
+		// 998 = HighestFinalNonce - 2
+		if nonce == 998 {
+			return &data.BlockApiResponse{
+				Data: data.BlockApiResponsePayload{
+					Block: api.Block{
+						Nonce:         998,
+						Hash:          "00000998",
+						PrevBlockHash: "00000997",
+						Timestamp:     998,
+					},
+				},
+			}, nil
+		}
+
+		return nil, errors.New("unexpected request")
+	}
+
+	summary, err := provider.GetLatestBlockSummary(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, &resources.BlockSummary{
+		Nonce:             998,
+		Hash:              "00000998",
+		PreviousBlockHash: "00000997",
+		Timestamp:         998,
+	}, summary)
+}
+
 func TestNetworkProvider_GetLatestBlockNonce(t *testing.T) {
 	t.Parallel()
 
@@ -189,7 +304,7 @@ func TestNetworkProvider_GetLatestBlockNonce(t *testing.T) {
 			return 0, errors.New("unexpected request")
 		}
 
-		nonce, err := provider.getLatestBlockNonce()
+		nonce, err := provider.getLatestBlockNonce(context.Background())
 		require.Error(t, errCannotGetLatestBlockNonce, err)
 		require.Equal(t, uint64(0), nonce)
 	})
@@ -211,7 +326,7 @@ func TestNetworkProvider_GetLatestBlockNonce(t *testing.T) {
 			return 0, errors.New("unexpected request")
 		}
 
-		nonce, err := provider.getLatestBlockNonce()
+		nonce, err := provider.getLatestBlockNonce(context.Background())
 		require.Nil(t, err)
 		require.Equal(t, uint64(40), nonce)
 	})
@@ -233,7 +348,7 @@ func TestNetworkProvider_GetLatestBlockNonce(t *testing.T) {
 			return 0, errors.New("unexpected request")
 		}
 
-		nonce, err := provider.getLatestBlockNonce()
+		nonce, err := provider.getLatestBlockNonce(context.Background())
 		require.Nil(t, err)
 		require.Equal(t, uint64(39), nonce)
 	})
@@ -256,7 +371,7 @@ func TestNetworkProvider_GetLatestBlockNonce(t *testing.T) {
 			return 0, errors.New("unexpected request")
 		}
 
-		nonce, err := provider.getLatestBlockNonce()
+		nonce, err := provider.getLatestBlockNonce(context.Background())
 		require.Nil(t, err)
 		require.Equal(t, uint64(40), nonce)
 	})
@@ -279,7 +394,7 @@ func TestNetworkProvider_GetLatestBlockNonce(t *testing.T) {
 			return 0, errors.New("unexpected request")
 		}
 
-		nonce, err := provider.getLatestBlockNonce()
+		nonce, err := provider.getLatestBlockNonce(context.Background())
 		require.Nil(t, err)
 		require.Equal(t, uint64(40), nonce)
 	})
@@ -329,19 +444,19 @@ func TestGetOldestNonceWithHistoricalStateGivenNodeStatus(t *testing.T) {
 		return 0, errors.New("unexpected request")
 	}
 
-	oldestNonce, err := provider.getOldestNonceWithHistoricalStateGivenNodeStatus(&resources.NodeStatus{
+	oldestNonce, err := provider.getOldestNonceWithHistoricalStateGivenNodeStatus(context.Background(), &resources.NodeStatus{
 		CurrentEpoch: 7,
 	})
 	require.Nil(t, err)
 	require.Equal(t, uint64(200), oldestNonce)
 
-	oldestNonce, err = provider.getOldestNonceWithHistoricalStateGivenNodeStatus(&resources.NodeStatus{
+	oldestNonce, err = provider.getOldestNonceWithHistoricalStateGivenNodeStatus(context.Background(), &resources.NodeStatus{
 		CurrentEpoch: 11,
 	})
 	require.Nil(t, err)
 	require.Equal(t, uint64(300), oldestNonce)
 
-	oldestNonce, err = provider.getOldestNonceWithHistoricalStateGivenNodeStatus(&resources.NodeStatus{
+	oldestNonce, err = provider.getOldestNonceWithHistoricalStateGivenNodeStatus(context.Background(), &resources.NodeStatus{
 		CurrentEpoch: 50,
 	})
 	require.Equal(t, uint64(0), oldestNonce)
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+)
+
+// CheckObserversHealth fetches the node status of each observer (individually, without failover), and records the outcome in the observers pool
+func (provider *networkProvider) CheckObserversHealth() {
+	if provider.isOffline {
+		return
+	}
+
+	for _, observerUrl := range provider.observersPool.GetAllUrls() {
+		status, err := provider.getPlainNodeStatusOfObserver(provider.backgroundContext, observerUrl)
+		provider.observersPool.UpdateHealth(observerUrl, status, err)
+	}
+}
+
+func (provider *networkProvider) getPlainNodeStatusOfObserver(ctx context.Context, observerUrl string) (*resources.NodeStatus, error) {
+	response := &resources.NodeStatusApiResponse{}
+
+	err := provider.getResourceWithErrConversion(ctx, []string{observerUrl}, urlPathGetNodeStatus, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Data.Status, nil
+}
+
+// StartObserversHealthCheckLoop periodically checks the health of the observers (in the background), until the provider is closed.
+// With a single observer, there's nothing to choose from, thus the loop isn't started. A zero interval disables the health check, as well.
+func (provider *networkProvider) StartObserversHealthCheckLoop(interval time.Duration) {
+	if provider.observersPool.Len() <= 1 {
+		return
+	}
+	if interval == 0 {
+		log.Info("networkProvider.StartObserversHealthCheckLoop(): observers health check is disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		provider.CheckObserversHealth()
+
+		for {
+			select {
+			case <-ticker.C:
+				provider.CheckObserversHealth()
+			case <-provider.closing:
+				return
+			}
+		}
+	}()
+}
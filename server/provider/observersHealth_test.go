@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkProvider_CheckObserversHealth(t *testing.T) {
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+	args.ObserversPool = createObserversPool("http://a", "http://b", "http://c")
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	observerFacade.CallGetRestEndPointCalled = func(baseUrl string, path string, value interface{}) (int, error) {
+		require.Equal(t, urlPathGetNodeStatus, path)
+
+		response := value.(*resources.NodeStatusApiResponse)
+
+		switch baseUrl {
+		case "http://a":
+			response.Data.Status = resources.NodeStatus{IsSyncing: 1, HighestFinalNonce: 100}
+		case "http://b":
+			return 0, NewErrObserverUnreachable(errors.New("connection refused"))
+		case "http://c":
+			response.Data.Status = resources.NodeStatus{IsSyncing: 0, HighestFinalNonce: 200}
+		}
+
+		return http.StatusOK, nil
+	}
+
+	provider.CheckObserversHealth()
+	require.Equal(t, []string{"http://c", "http://a", "http://b"}, args.ObserversPool.GetUrlsOrderedByHealth())
+}
@@ -0,0 +1,320 @@
+package provider
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+)
+
+// ArgsNewObserversPool holds the arguments for creating an observersPool
+type ArgsNewObserversPool struct {
+	ShardID uint32
+	// The observers of the shard, in the order of preference
+	Urls []string
+	// The first historical epoch of each observer (optional). If not provided, "FirstHistoricalEpoch" applies to all observers.
+	FirstHistoricalEpochs []uint32
+	FirstHistoricalEpoch  uint32
+	NumHistoricalEpochs   uint32
+}
+
+type observerHealth struct {
+	url                  string
+	firstHistoricalEpoch uint32
+
+	isChecked         bool
+	isReachable       bool
+	isSynced          bool
+	highestFinalNonce uint64
+	currentEpoch      uint32
+}
+
+// observersPool tracks the health of the observers (of the same shard), and decides which of them should serve a request.
+// It also acts as the nodes provider of proxy-go's processors (e.g. for fetching blocks, for sending transactions).
+type observersPool struct {
+	shardID             uint32
+	numHistoricalEpochs uint32
+	observers           []*observerHealth
+	mutex               sync.RWMutex
+}
+
+// NewObserversPool creates a new observersPool
+func NewObserversPool(args ArgsNewObserversPool) (*observersPool, error) {
+	if len(args.Urls) == 0 {
+		return nil, errNoObservers
+	}
+
+	hasFirstHistoricalEpochs := len(args.FirstHistoricalEpochs) > 0
+	if hasFirstHistoricalEpochs && len(args.FirstHistoricalEpochs) != len(args.Urls) {
+		return nil, newErrBadNumFirstHistoricalEpochs(len(args.FirstHistoricalEpochs), len(args.Urls))
+	}
+
+	observers := make([]*observerHealth, 0, len(args.Urls))
+	seen := make(map[string]struct{})
+
+	for i, url := range args.Urls {
+		_, isDuplicated := seen[url]
+		if isDuplicated {
+			return nil, newErrDuplicatedObserver(url)
+		}
+
+		seen[url] = struct{}{}
+
+		firstHistoricalEpoch := args.FirstHistoricalEpoch
+		if hasFirstHistoricalEpochs {
+			firstHistoricalEpoch = args.FirstHistoricalEpochs[i]
+		}
+
+		observers = append(observers, &observerHealth{
+			url:                  url,
+			firstHistoricalEpoch: firstHistoricalEpoch,
+		})
+	}
+
+	return &observersPool{
+		shardID:             args.ShardID,
+		numHistoricalEpochs: args.NumHistoricalEpochs,
+		observers:           observers,
+	}, nil
+}
+
+// Len returns the number of observers
+func (pool *observersPool) Len() int {
+	return len(pool.observers)
+}
+
+// GetAllUrls gets the URLs of all observers, in the order of preference (as configured)
+func (pool *observersPool) GetAllUrls() []string {
+	urls := make([]string, 0, len(pool.observers))
+	for _, observer := range pool.observers {
+		urls = append(urls, observer.url)
+	}
+
+	return urls
+}
+
+// GetUrlsOrderedByHealth gets the URLs of all observers, the best one first.
+// Synced observers come first (in the order of preference), then the ones still syncing (the most advanced first), then the unreachable ones (as a last resort).
+// Observers not yet checked are considered healthy.
+func (pool *observersPool) GetUrlsOrderedByHealth() []string {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+
+	return getUrls(pool.getObserversOrderedByHealth())
+}
+
+// GetUrlsHavingEpoch gets the URLs of the observers holding the state of the given epoch, the best one first
+func (pool *observersPool) GetUrlsHavingEpoch(epoch uint32) ([]string, error) {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+
+	eligible := make([]*observerHealth, 0, len(pool.observers))
+
+	for _, observer := range pool.getObserversOrderedByHealth() {
+		if pool.hasEpoch(observer, epoch) {
+			eligible = append(eligible, observer)
+		}
+	}
+
+	if len(eligible) == 0 {
+		return nil, newErrNoObserverHavingEpoch(epoch)
+	}
+
+	return getUrls(eligible), nil
+}
+
+// hasEpoch decides whether an observer holds the state of an epoch: the same rule as for the oldest block with historical state applies (see "getOldestEligibleEpoch")
+func (pool *observersPool) hasEpoch(observer *observerHealth, epoch uint32) bool {
+	if epoch < observer.firstHistoricalEpoch {
+		return false
+	}
+	if !observer.isChecked || !observer.isReachable {
+		return true
+	}
+
+	oldestEpoch := int(observer.currentEpoch) - int(pool.numHistoricalEpochs)
+	return int(epoch) >= oldestEpoch && epoch <= observer.currentEpoch
+}
+
+func (pool *observersPool) getObserversOrderedByHealth() []*observerHealth {
+	ordered := make([]*observerHealth, len(pool.observers))
+	copy(ordered, pool.observers)
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		rankI := getHealthRank(ordered[i])
+		rankJ := getHealthRank(ordered[j])
+		if rankI != rankJ {
+			return rankI < rankJ
+		}
+
+		// Among the observers still syncing, the most advanced one is preferred.
+		if rankI == healthRankSyncing {
+			return ordered[i].highestFinalNonce > ordered[j].highestFinalNonce
+		}
+
+		return false
+	})
+
+	return ordered
+}
+
+func getHealthRank(observer *observerHealth) int {
+	if !observer.isChecked {
+		return healthRankSynced
+	}
+	if !observer.isReachable {
+		return healthRankUnreachable
+	}
+	if !observer.isSynced {
+		return healthRankSyncing
+	}
+
+	return healthRankSynced
+}
+
+func getUrls(observers []*observerHealth) []string {
+	urls := make([]string, 0, len(observers))
+	for _, observer := range observers {
+		urls = append(urls, observer.url)
+	}
+
+	return urls
+}
+
+// UpdateHealth records the outcome of a health check (a node status request)
+func (pool *observersPool) UpdateHealth(url string, status *resources.NodeStatus, err error) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	observer, ok := pool.getObserver(url)
+	if !ok {
+		return
+	}
+
+	wasHealthy := !observer.isChecked || (observer.isReachable && observer.isSynced)
+
+	observer.isChecked = true
+	observer.isReachable = err == nil
+
+	if err == nil {
+		observer.isSynced = status.IsSyncing == 0
+		observer.highestFinalNonce = status.HighestFinalNonce
+		observer.currentEpoch = status.CurrentEpoch
+	}
+
+	isHealthy := observer.isReachable && observer.isSynced
+	if wasHealthy && !isHealthy {
+		log.Warn("observersPool: observer isn't healthy anymore", "url", url, "isReachable", observer.isReachable, "isSynced", observer.isSynced, "err", err)
+	}
+	if !wasHealthy && isHealthy {
+		log.Info("observersPool: observer is healthy", "url", url, "highestFinalNonce", observer.highestFinalNonce)
+	}
+}
+
+// MarkAsUnreachable marks an observer as unreachable (e.g. after a failed request), until the next (successful) health check
+func (pool *observersPool) MarkAsUnreachable(url string, err error) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	observer, ok := pool.getObserver(url)
+	if !ok {
+		return
+	}
+
+	if !observer.isChecked || observer.isReachable {
+		log.Warn("observersPool: observer is unreachable", "url", url, "err", err)
+	}
+
+	observer.isChecked = true
+	observer.isReachable = false
+}
+
+func (pool *observersPool) getObserver(url string) (*observerHealth, bool) {
+	for _, observer := range pool.observers {
+		if observer.url == url {
+			return observer, true
+		}
+	}
+
+	return nil, false
+}
+
+// GetNodesByShardId gets the observers of the shard, the best one first (implements proxy-go's NodesProviderHandler)
+func (pool *observersPool) GetNodesByShardId(shardId uint32, _ data.ObserverDataAvailabilityType) ([]*data.NodeData, error) {
+	if shardId != pool.shardID {
+		return nil, newErrShardNotObserved(shardId)
+	}
+
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+
+	return pool.toNodesData(pool.getObserversOrderedByHealth()), nil
+}
+
+// GetAllNodes gets all observers, the best one first (implements proxy-go's NodesProviderHandler)
+func (pool *observersPool) GetAllNodes(_ data.ObserverDataAvailabilityType) ([]*data.NodeData, error) {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+
+	return pool.toNodesData(pool.getObserversOrderedByHealth()), nil
+}
+
+// UpdateNodesBasedOnSyncState does nothing, since the health of the observers is tracked by the pool itself (implements proxy-go's NodesProviderHandler)
+func (pool *observersPool) UpdateNodesBasedOnSyncState(_ []*data.NodeData) {
+}
+
+// GetAllNodesWithSyncState gets all observers, along with their sync state (implements proxy-go's NodesProviderHandler)
+func (pool *observersPool) GetAllNodesWithSyncState() []*data.NodeData {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+
+	return pool.toNodesData(pool.observers)
+}
+
+// ReloadNodes isn't applicable (implements proxy-go's NodesProviderHandler)
+func (pool *observersPool) ReloadNodes(_ data.NodeType) data.NodesReloadResponse {
+	return data.NodesReloadResponse{
+		OkRequest:   false,
+		Description: "not applicable",
+		Error:       "not applicable",
+	}
+}
+
+// PrintNodesInShards logs the observers, along with their health (implements proxy-go's NodesProviderHandler)
+func (pool *observersPool) PrintNodesInShards() {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+
+	for _, observer := range pool.observers {
+		log.Info("observersPool: observer",
+			"url", observer.url,
+			"shard", pool.shardID,
+			"isChecked", observer.isChecked,
+			"isReachable", observer.isReachable,
+			"isSynced", observer.isSynced,
+			"highestFinalNonce", observer.highestFinalNonce,
+			"currentEpoch", observer.currentEpoch,
+			"firstHistoricalEpoch", observer.firstHistoricalEpoch,
+		)
+	}
+}
+
+func (pool *observersPool) toNodesData(observers []*observerHealth) []*data.NodeData {
+	nodes := make([]*data.NodeData, 0, len(observers))
+
+	for _, observer := range observers {
+		nodes = append(nodes, &data.NodeData{
+			ShardId:  pool.shardID,
+			Address:  observer.url,
+			IsSynced: getHealthRank(observer) == healthRankSynced,
+		})
+	}
+
+	return nodes
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (pool *observersPool) IsInterfaceNil() bool {
+	return pool == nil
+}
@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewObserversPool(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with success", func(t *testing.T) {
+		pool, err := NewObserversPool(ArgsNewObserversPool{
+			Urls:                  []string{"http://a", "http://b"},
+			FirstHistoricalEpochs: []uint32{0, 100},
+		})
+		require.Nil(t, err)
+		require.Equal(t, 2, pool.Len())
+		require.Equal(t, []string{"http://a", "http://b"}, pool.GetAllUrls())
+		require.Equal(t, uint32(0), pool.observers[0].firstHistoricalEpoch)
+		require.Equal(t, uint32(100), pool.observers[1].firstHistoricalEpoch)
+	})
+
+	t.Run("with common first historical epoch", func(t *testing.T) {
+		pool, err := NewObserversPool(ArgsNewObserversPool{
+			Urls:                 []string{"http://a", "http://b"},
+			FirstHistoricalEpoch: 42,
+		})
+		require.Nil(t, err)
+		require.Equal(t, uint32(42), pool.observers[0].firstHistoricalEpoch)
+		require.Equal(t, uint32(42), pool.observers[1].firstHistoricalEpoch)
+	})
+
+	t.Run("without observers", func(t *testing.T) {
+		_, err := NewObserversPool(ArgsNewObserversPool{})
+		require.ErrorIs(t, err, errNoObservers)
+	})
+
+	t.Run("with bad number of first historical epochs", func(t *testing.T) {
+		_, err := NewObserversPool(ArgsNewObserversPool{
+			Urls:                  []string{"http://a", "http://b"},
+			FirstHistoricalEpochs: []uint32{0},
+		})
+		require.ErrorIs(t, err, errBadNumFirstHistoricalEpochs)
+	})
+
+	t.Run("with duplicated observer", func(t *testing.T) {
+		_, err := NewObserversPool(ArgsNewObserversPool{
+			Urls: []string{"http://a", "http://b", "http://a"},
+		})
+		require.ErrorIs(t, err, errDuplicatedObserver)
+	})
+}
+
+func TestObserversPool_GetUrlsOrderedByHealth(t *testing.T) {
+	t.Parallel()
+
+	pool := createObserversPool("http://a", "http://b", "http://c", "http://d")
+
+	// Not yet checked: the configured order applies.
+	require.Equal(t, []string{"http://a", "http://b", "http://c", "http://d"}, pool.GetUrlsOrderedByHealth())
+
+	pool.UpdateHealth("http://a", nil, errors.New("connection refused"))
+	pool.UpdateHealth("http://b", &resources.NodeStatus{IsSyncing: 1, HighestFinalNonce: 100}, nil)
+	pool.UpdateHealth("http://c", &resources.NodeStatus{IsSyncing: 1, HighestFinalNonce: 200}, nil)
+	pool.UpdateHealth("http://d", &resources.NodeStatus{IsSyncing: 0, HighestFinalNonce: 300}, nil)
+	require.Equal(t, []string{"http://d", "http://c", "http://b", "http://a"}, pool.GetUrlsOrderedByHealth())
+
+	pool.MarkAsUnreachable("http://d", errors.New("timeout"))
+	require.Equal(t, []string{"http://c", "http://b", "http://a", "http://d"}, pool.GetUrlsOrderedByHealth())
+
+	// Recovered.
+	pool.UpdateHealth("http://a", &resources.NodeStatus{IsSyncing: 0, HighestFinalNonce: 300}, nil)
+	require.Equal(t, []string{"http://a", "http://c", "http://b", "http://d"}, pool.GetUrlsOrderedByHealth())
+
+	// Unknown observers are ignored.
+	pool.MarkAsUnreachable("http://unknown", errors.New("timeout"))
+	require.Equal(t, 4, pool.Len())
+}
+
+func TestObserversPool_GetUrlsHavingEpoch(t *testing.T) {
+	t.Parallel()
+
+	pool, err := NewObserversPool(ArgsNewObserversPool{
+		Urls:                  []string{"http://recent", "http://archive"},
+		FirstHistoricalEpochs: []uint32{110, 0},
+		NumHistoricalEpochs:   100,
+	})
+	require.Nil(t, err)
+
+	// Not yet checked: only the first historical epoch is known.
+	urls, err := pool.GetUrlsHavingEpoch(115)
+	require.Nil(t, err)
+	require.Equal(t, []string{"http://recent", "http://archive"}, urls)
+
+	urls, err = pool.GetUrlsHavingEpoch(50)
+	require.Nil(t, err)
+	require.Equal(t, []string{"http://archive"}, urls)
+
+	pool.UpdateHealth("http://recent", &resources.NodeStatus{CurrentEpoch: 120}, nil)
+	pool.UpdateHealth("http://archive", &resources.NodeStatus{CurrentEpoch: 120}, nil)
+
+	urls, err = pool.GetUrlsHavingEpoch(115)
+	require.Nil(t, err)
+	require.Equal(t, []string{"http://recent", "http://archive"}, urls)
+
+	urls, err = pool.GetUrlsHavingEpoch(105)
+	require.Nil(t, err)
+	require.Equal(t, []string{"http://archive"}, urls)
+
+	// Older than the number of historical epochs (kept by any observer).
+	_, err = pool.GetUrlsHavingEpoch(10)
+	require.ErrorIs(t, err, errNoObserverHavingEpoch)
+
+	// Not yet reached.
+	_, err = pool.GetUrlsHavingEpoch(121)
+	require.ErrorIs(t, err, errNoObserverHavingEpoch)
+}
+
+func TestObserversPool_NodesProvider(t *testing.T) {
+	t.Parallel()
+
+	pool, err := NewObserversPool(ArgsNewObserversPool{
+		ShardID: 1,
+		Urls:    []string{"http://a", "http://b"},
+	})
+	require.Nil(t, err)
+
+	pool.MarkAsUnreachable("http://a", errors.New("timeout"))
+
+	nodes, err := pool.GetNodesByShardId(1, data.AvailabilityAll)
+	require.Nil(t, err)
+	require.Equal(t, []*data.NodeData{
+		{ShardId: 1, Address: "http://b", IsSynced: true},
+		{ShardId: 1, Address: "http://a", IsSynced: false},
+	}, nodes)
+
+	_, err = pool.GetNodesByShardId(0, data.AvailabilityAll)
+	require.ErrorIs(t, err, errShardNotObserved)
+
+	nodes, err = pool.GetAllNodes(data.AvailabilityRecent)
+	require.Nil(t, err)
+	require.Len(t, nodes, 2)
+
+	require.Len(t, pool.GetAllNodesWithSyncState(), 2)
+	require.False(t, pool.ReloadNodes(data.Observer).OkRequest)
+	require.False(t, pool.IsInterfaceNil())
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+)
+
+// GetTransactionsPoolNoncesForSender gets the nonces of the sender's transactions currently in the pool (sorted, ascending)
+func (provider *networkProvider) GetTransactionsPoolNoncesForSender(ctx context.Context, sender string) ([]uint64, error) {
+	if provider.isOffline {
+		return nil, errIsOffline
+	}
+
+	url := buildUrlGetTransactionsPoolForSender(sender, []string{transactionsPoolFieldNonce})
+	response := &resources.TransactionsPoolForSenderApiResponse{}
+
+	err := provider.getResource(ctx, url, response)
+	if err != nil {
+		return nil, newErrCannotGetTransactionsPoolForSender(sender, err)
+	}
+
+	transactions := response.Data.TxPool.Transactions
+	nonces := make([]uint64, 0, len(transactions))
+
+	for _, tx := range transactions {
+		// Numbers held in "interface{}" are decoded (by the JSON unmarshaller) as float64.
+		nonce, ok := tx.TxFields[transactionsPoolFieldNonce].(float64)
+		if !ok {
+			return nil, newErrCannotGetTransactionsPoolForSender(sender, fmt.Errorf("bad nonce: %v", tx.TxFields[transactionsPoolFieldNonce]))
+		}
+
+		nonces = append(nonces, uint64(nonce))
+	}
+
+	sort.Slice(nonces, func(i, j int) bool {
+		return nonces[i] < nonces[j]
+	})
+
+	log.Trace("networkProvider.GetTransactionsPoolNoncesForSender()", "sender", sender, "nonces", nonces)
+
+	return nonces, nil
+}
+
+// isTransactionInPoolOfSender tells whether a transaction (given its hash) is in the pool, among the transactions of its sender
+func (provider *networkProvider) isTransactionInPoolOfSender(ctx context.Context, sender string, hash string) (bool, error) {
+	url := buildUrlGetTransactionsPoolForSender(sender, []string{transactionsPoolFieldHash})
+	response := &resources.TransactionsPoolForSenderApiResponse{}
+
+	err := provider.getResource(ctx, url, response)
+	if err != nil {
+		return false, newErrCannotGetTransactionsPoolForSender(sender, err)
+	}
+
+	for _, tx := range response.Data.TxPool.Transactions {
+		if tx.TxFields[transactionsPoolFieldHash] == hash {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// GetTransactionsInPool gets (a summary of) the regular transactions currently in the pool of the observer
+func (provider *networkProvider) GetTransactionsInPool(ctx context.Context) ([]*resources.TransactionInPool, error) {
+	if provider.isOffline {
+		return nil, errIsOffline
+	}
+
+	url := buildUrlGetTransactionsPool([]string{transactionsPoolFieldHash, transactionsPoolFieldSender, transactionsPoolFieldReceiver})
+	response := &resources.TransactionsPoolApiResponse{}
+
+	err := provider.getResource(ctx, url, response)
+	if err != nil {
+		return nil, newErrCannotGetTransactionsPool(err)
+	}
+
+	transactions := response.Data.TxPool.RegularTransactions
+	result := make([]*resources.TransactionInPool, 0, len(transactions))
+
+	for _, tx := range transactions {
+		hash, ok := tx.TxFields[transactionsPoolFieldHash].(string)
+		if !ok || len(hash) == 0 {
+			return nil, newErrCannotGetTransactionsPool(fmt.Errorf("bad hash: %v", tx.TxFields[transactionsPoolFieldHash]))
+		}
+
+		// Sender and receiver are optional (for robustness); they are only used for filtering.
+		sender, _ := tx.TxFields[transactionsPoolFieldSender].(string)
+		receiver, _ := tx.TxFields[transactionsPoolFieldReceiver].(string)
+
+		result = append(result, &resources.TransactionInPool{
+			Hash:     hash,
+			Sender:   sender,
+			Receiver: receiver,
+		})
+	}
+
+	log.Trace("networkProvider.GetTransactionsInPool()", "numTransactions", len(result))
+
+	return result, nil
+}
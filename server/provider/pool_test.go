@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkProvider_GetTransactionsPoolNoncesForSender(t *testing.T) {
+	t.Parallel()
+
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	t.Run("with success", func(t *testing.T) {
+		observerFacade.CallGetRestEndPointCalled = func(baseUrl string, path string, value interface{}) (int, error) {
+			require.Equal(t, "/transaction/pool?by-sender=erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th&fields=nonce", path)
+
+			value.(*resources.TransactionsPoolForSenderApiResponse).Data.TxPool.Transactions = []data.WrappedTransaction{
+				{TxFields: map[string]interface{}{"nonce": float64(44)}},
+				{TxFields: map[string]interface{}{"nonce": float64(42)}},
+			}
+
+			return 200, nil
+		}
+
+		nonces, err := provider.GetTransactionsPoolNoncesForSender(context.Background(), testscommon.TestAddressAlice)
+		require.Nil(t, err)
+		require.Equal(t, []uint64{42, 44}, nonces)
+	})
+
+	t.Run("with empty pool", func(t *testing.T) {
+		observerFacade.CallGetRestEndPointCalled = func(baseUrl string, path string, value interface{}) (int, error) {
+			return 200, nil
+		}
+
+		nonces, err := provider.GetTransactionsPoolNoncesForSender(context.Background(), testscommon.TestAddressAlice)
+		require.Nil(t, err)
+		require.Empty(t, nonces)
+	})
+
+	t.Run("with bad nonce", func(t *testing.T) {
+		observerFacade.CallGetRestEndPointCalled = func(baseUrl string, path string, value interface{}) (int, error) {
+			value.(*resources.TransactionsPoolForSenderApiResponse).Data.TxPool.Transactions = []data.WrappedTransaction{
+				{TxFields: map[string]interface{}{"hash": "aaaa"}},
+			}
+
+			return 200, nil
+		}
+
+		nonces, err := provider.GetTransactionsPoolNoncesForSender(context.Background(), testscommon.TestAddressAlice)
+		require.ErrorIs(t, err, errCannotGetTransactionsPool)
+		require.Nil(t, nonces)
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		observerFacade.CallGetRestEndPointCalled = func(baseUrl string, path string, value interface{}) (int, error) {
+			return 500, errors.New("arbitrary error")
+		}
+
+		nonces, err := provider.GetTransactionsPoolNoncesForSender(context.Background(), testscommon.TestAddressAlice)
+		require.ErrorIs(t, err, errCannotGetTransactionsPool)
+		require.ErrorContains(t, err, "arbitrary error")
+		require.Nil(t, nonces)
+	})
+}
+
+func TestNetworkProvider_GetTransactionsInPool(t *testing.T) {
+	t.Parallel()
+
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	t.Run("with success", func(t *testing.T) {
+		observerFacade.CallGetRestEndPointCalled = func(baseUrl string, path string, value interface{}) (int, error) {
+			require.Equal(t, "/transaction/pool?fields=hash%2Csender%2Creceiver", path)
+
+			value.(*resources.TransactionsPoolApiResponse).Data.TxPool.RegularTransactions = []data.WrappedTransaction{
+				{TxFields: map[string]interface{}{"hash": "aaaa", "sender": testscommon.TestAddressAlice, "receiver": testscommon.TestAddressBob}},
+				{TxFields: map[string]interface{}{"hash": "bbbb", "sender": testscommon.TestAddressBob}},
+			}
+
+			return 200, nil
+		}
+
+		transactions, err := provider.GetTransactionsInPool(context.Background())
+		require.Nil(t, err)
+		require.Equal(t, []*resources.TransactionInPool{
+			{Hash: "aaaa", Sender: testscommon.TestAddressAlice, Receiver: testscommon.TestAddressBob},
+			{Hash: "bbbb", Sender: testscommon.TestAddressBob},
+		}, transactions)
+	})
+
+	t.Run("with bad hash", func(t *testing.T) {
+		observerFacade.CallGetRestEndPointCalled = func(baseUrl string, path string, value interface{}) (int, error) {
+			value.(*resources.TransactionsPoolApiResponse).Data.TxPool.RegularTransactions = []data.WrappedTransaction{
+				{TxFields: map[string]interface{}{"sender": testscommon.TestAddressAlice}},
+			}
+
+			return 200, nil
+		}
+
+		transactions, err := provider.GetTransactionsInPool(context.Background())
+		require.ErrorIs(t, err, errCannotGetTransactionsPool)
+		require.Nil(t, transactions)
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		observerFacade.CallGetRestEndPointCalled = func(baseUrl string, path string, value interface{}) (int, error) {
+			return 500, errors.New("arbitrary error")
+		}
+
+		transactions, err := provider.GetTransactionsInPool(context.Background())
+		require.ErrorIs(t, err, errCannotGetTransactionsPool)
+		require.Nil(t, transactions)
+	})
+}
@@ -1,13 +1,30 @@
 package provider
 
-import "errors"
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"time"
 
-func (provider *networkProvider) getResource(url string, response resourceApiResponseHandler) error {
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+)
+
+func (provider *networkProvider) getResource(ctx context.Context, url string, response resourceApiResponseHandler) error {
+	return provider.getResourceWithOptions(ctx, url, resources.NewAccountQueryOptionsOnFinalBlock(), response)
+}
+
+// getResourceWithOptions fetches a resource from the observers able to serve the given query options (e.g. historical queries)
+func (provider *networkProvider) getResourceWithOptions(ctx context.Context, url string, options resources.AccountQueryOptions, response resourceApiResponseHandler) error {
 	if provider.isOffline {
 		return errIsOffline
 	}
 
-	err := provider.getResourceWithErrConversion(url, response)
+	observerUrls, err := provider.decideObserversGivenQueryOptions(ctx, options)
+	if err == nil {
+		err = provider.doWithRetries(ctx, func() error {
+			return provider.getResourceWithErrConversion(ctx, observerUrls, url, response)
+		})
+	}
 	if err != nil {
 		log.Warn("getResource()", "url", url, "err", err)
 		return err
@@ -16,8 +33,51 @@ func (provider *networkProvider) getResource(url string, response resourceApiRes
 	return nil
 }
 
-func (provider *networkProvider) getResourceWithErrConversion(url string, response resourceApiResponseHandler) error {
-	_, err := provider.observerFacade.CallGetRestEndPoint(provider.observerUrl, url, response)
+func (provider *networkProvider) getResourceWithErrConversion(ctx context.Context, observerUrls []string, url string, response resourceApiResponseHandler) error {
+	err := provider.callObservers(ctx, observerUrls, func(observerUrl string) error {
+		_, err := provider.observerFacade.CallGetRestEndPoint(ctx, observerUrl, url, response)
+		return err
+	})
+	if err != nil {
+		return convertStructuredApiErrToFlatErr(err)
+	}
+	if response.GetErrorMessage() != "" {
+		return errors.New(response.GetErrorMessage())
+	}
+
+	return nil
+}
+
+func (provider *networkProvider) postResource(ctx context.Context, url string, payload interface{}, response resourceApiResponseHandler) error {
+	return provider.postResourceWithOptions(ctx, url, resources.NewAccountQueryOptionsOnFinalBlock(), payload, response)
+}
+
+// postResourceWithOptions posts a request to the observers able to serve the given query options (e.g. historical VM queries)
+func (provider *networkProvider) postResourceWithOptions(ctx context.Context, url string, options resources.AccountQueryOptions, payload interface{}, response resourceApiResponseHandler) error {
+	if provider.isOffline {
+		return errIsOffline
+	}
+
+	observerUrls, err := provider.decideObserversGivenQueryOptions(ctx, options)
+	if err == nil {
+		// POST requests (e.g. VM queries, simulations) aren't retried, but they are still subject to the circuit breaker.
+		err = provider.doWithCircuitBreaker(ctx, func() error {
+			return provider.postResourceWithErrConversion(ctx, observerUrls, url, payload, response)
+		})
+	}
+	if err != nil {
+		log.Warn("postResource()", "url", url, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (provider *networkProvider) postResourceWithErrConversion(ctx context.Context, observerUrls []string, url string, payload interface{}, response resourceApiResponseHandler) error {
+	err := provider.callObservers(ctx, observerUrls, func(observerUrl string) error {
+		_, err := provider.observerFacade.CallPostRestEndPoint(ctx, observerUrl, url, payload, response)
+		return err
+	})
 	if err != nil {
 		return convertStructuredApiErrToFlatErr(err)
 	}
@@ -27,3 +87,113 @@ func (provider *networkProvider) getResourceWithErrConversion(url string, respon
 
 	return nil
 }
+
+// callObservers calls the observers one after the other, until one of them is reachable.
+// The response of a reachable observer is final, even if it's an error (e.g. "not found"), since another observer would most likely respond the same.
+func (provider *networkProvider) callObservers(ctx context.Context, observerUrls []string, call func(observerUrl string) error) error {
+	var err error
+
+	for _, observerUrl := range observerUrls {
+		err = call(observerUrl)
+		if err == nil || !IsObserverUnreachableError(err) {
+			return err
+		}
+		if ctx.Err() != nil {
+			// The originating request is gone (cancelled or past its deadline): the observer isn't to blame.
+			return ctx.Err()
+		}
+
+		provider.observersPool.MarkAsUnreachable(observerUrl, err)
+	}
+
+	return newErrObserverUnavailable(err)
+}
+
+// doWithRetries invokes an (idempotent) call against the observers, retrying it (with exponential backoff) as long as the observers are unavailable.
+func (provider *networkProvider) doWithRetries(ctx context.Context, call func() error) error {
+	backoff := provider.observerRetryBackoff
+
+	for attempt := uint32(0); ; attempt++ {
+		err := provider.doWithCircuitBreaker(ctx, call)
+
+		shouldRetry := IsObserverUnavailableError(err) && !errors.Is(err, errCircuitBreakerOpen) && attempt < provider.numObserverRetries
+		if !shouldRetry {
+			return err
+		}
+
+		log.Debug("doWithRetries(): observer unavailable, will retry", "attempt", attempt+1, "backoff", backoff, "err", err)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		case <-provider.closing:
+			return err
+		}
+
+		backoff = computeNextRetryBackoff(backoff, provider.observerMaxRetryBackoff)
+	}
+}
+
+// doWithCircuitBreaker invokes a call against the observers, unless they've been failing repeatedly (in which case, it fails fast)
+func (provider *networkProvider) doWithCircuitBreaker(ctx context.Context, call func() error) error {
+	if !provider.circuitBreaker.allow() {
+		return newErrObserverUnavailable(errCircuitBreakerOpen)
+	}
+
+	err := call()
+	if ctx.Err() != nil {
+		// A cancelled request (or one past its deadline) tells nothing about the availability of the observer.
+		return err
+	}
+	if IsObserverUnavailableError(err) {
+		provider.circuitBreaker.recordFailure()
+	} else {
+		// Any response (even an error) means that the observer is available.
+		provider.circuitBreaker.recordSuccess()
+	}
+
+	return err
+}
+
+func computeNextRetryBackoff(backoff time.Duration, maxBackoff time.Duration) time.Duration {
+	next := backoff * 2
+	if next > maxBackoff {
+		return maxBackoff
+	}
+
+	return next
+}
+
+// decideObserversGivenQueryOptions selects the observers able to serve a query. Queries on a past block are routed to the observers holding the state of the block's epoch.
+func (provider *networkProvider) decideObserversGivenQueryOptions(ctx context.Context, options resources.AccountQueryOptions) ([]string, error) {
+	isHistoricalQuery := options.BlockNonce.HasValue || len(options.BlockHash) > 0
+	if options.OnFinalBlock || !isHistoricalQuery || provider.observersPool.Len() == 1 {
+		return provider.observersPool.GetUrlsOrderedByHealth(), nil
+	}
+
+	epoch, err := provider.getEpochGivenQueryOptions(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider.observersPool.GetUrlsHavingEpoch(epoch)
+}
+
+func (provider *networkProvider) getEpochGivenQueryOptions(ctx context.Context, options resources.AccountQueryOptions) (uint32, error) {
+	if options.BlockNonce.HasValue {
+		summary, err := provider.getBlockSummaryByNonce(ctx, options.BlockNonce.Value)
+		if err != nil {
+			return 0, err
+		}
+
+		return summary.Epoch, nil
+	}
+
+	summary, err := provider.getBlockSummaryByHash(ctx, hex.EncodeToString(options.BlockHash))
+	if err != nil {
+		return 0, err
+	}
+
+	return summary.Epoch, nil
+}
@@ -1,9 +1,14 @@
 package provider
 
 import (
+	"context"
 	"errors"
+	"net/http"
 	"testing"
+	"time"
 
+	"github.com/multiversx/mx-chain-core-go/data/api"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
 	"github.com/multiversx/mx-chain-rosetta/testscommon"
 	"github.com/stretchr/testify/require"
 )
@@ -38,7 +43,7 @@ func TestNetworkProvider_GetResource(t *testing.T) {
 		}
 
 		response := &dummyResourceApiResponse{}
-		err = provider.getResource("/test", response)
+		err = provider.getResource(context.Background(), "/test", response)
 		require.Nil(t, err)
 		require.Equal(t, "foo", response.Foo)
 		require.Equal(t, "bar", response.Bar)
@@ -49,7 +54,7 @@ func TestNetworkProvider_GetResource(t *testing.T) {
 		observerFacade.MockGetResponse = nil
 
 		response := &dummyResourceApiResponse{}
-		err = provider.getResource("/test", response)
+		err = provider.getResource(context.Background(), "/test", response)
 		require.Equal(t, err, errors.New("arbitrary error"))
 		require.Equal(t, &dummyResourceApiResponse{}, response)
 	})
@@ -59,7 +64,7 @@ func TestNetworkProvider_GetResource(t *testing.T) {
 		observerFacade.MockGetResponse = nil
 
 		response := &dummyResourceApiResponse{}
-		err = provider.getResource("/test", response)
+		err = provider.getResource(context.Background(), "/test", response)
 		require.Equal(t, errors.New("internal error: err"), err)
 		require.Equal(t, &dummyResourceApiResponse{}, response)
 	})
@@ -74,8 +79,316 @@ func TestNetworkProvider_GetResource(t *testing.T) {
 		}
 
 		response := &dummyResourceApiResponse{}
-		err = provider.getResource("/test", response)
+		err = provider.getResource(context.Background(), "/test", response)
 		require.Equal(t, err, errors.New("error on payload"))
 		require.Equal(t, &dummyResourceApiResponse{Error: "error on payload"}, response)
 	})
 }
+
+func TestNetworkProvider_GetResourceWithFailover(t *testing.T) {
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+	args.ObserversPool = createObserversPool("http://a", "http://b", "http://c")
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	calledUrls := make([]string, 0)
+
+	observerFacade.CallGetRestEndPointCalled = func(baseUrl string, path string, value interface{}) (int, error) {
+		calledUrls = append(calledUrls, baseUrl)
+
+		switch baseUrl {
+		case "http://a":
+			return 0, NewErrObserverUnreachable(errors.New("connection refused"))
+		case "http://b":
+			return http.StatusInternalServerError, errors.New("internal error")
+		default:
+			return http.StatusOK, nil
+		}
+	}
+
+	t.Run("unreachable observer is skipped, error of reachable observer is final", func(t *testing.T) {
+		calledUrls = calledUrls[:0]
+
+		err = provider.getResource(context.Background(), "/test", &dummyResourceApiResponse{})
+		require.Equal(t, errors.New("internal error"), err)
+		require.Equal(t, []string{"http://a", "http://b"}, calledUrls)
+	})
+
+	t.Run("unreachable observer is tried last", func(t *testing.T) {
+		calledUrls = calledUrls[:0]
+
+		err = provider.getResource(context.Background(), "/test", &dummyResourceApiResponse{})
+		require.Equal(t, errors.New("internal error"), err)
+		require.Equal(t, []string{"http://b"}, calledUrls)
+		require.Equal(t, []string{"http://b", "http://c", "http://a"}, args.ObserversPool.GetUrlsOrderedByHealth())
+	})
+
+	t.Run("all observers unreachable", func(t *testing.T) {
+		calledUrls = calledUrls[:0]
+
+		observerFacade.CallGetRestEndPointCalled = func(baseUrl string, path string, value interface{}) (int, error) {
+			calledUrls = append(calledUrls, baseUrl)
+			return 0, NewErrObserverUnreachable(errors.New("timeout"))
+		}
+
+		err = provider.getResource(context.Background(), "/test", &dummyResourceApiResponse{})
+		require.ErrorIs(t, err, errObserverUnavailable)
+		require.True(t, IsObserverUnavailableError(err))
+		require.Equal(t, []string{"http://b", "http://c", "http://a"}, calledUrls)
+	})
+}
+
+func TestNetworkProvider_GetResourceWithOptions(t *testing.T) {
+	observerFacade := testscommon.NewObserverFacadeMock()
+	observerFacade.MockBlocks = []*api.Block{
+		{Nonce: 0, Hash: "0000", Epoch: 0},
+		{Nonce: 1, Hash: "0001", Epoch: 5},
+	}
+
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+	args.ObserversPool, _ = NewObserversPool(ArgsNewObserversPool{
+		Urls:                  []string{"http://recent", "http://archive"},
+		FirstHistoricalEpochs: []uint32{10, 0},
+	})
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	t.Run("on final block", func(t *testing.T) {
+		err = provider.getResourceWithOptions(context.Background(), "/test", resources.NewAccountQueryOptionsOnFinalBlock(), &dummyResourceApiResponse{})
+		require.Nil(t, err)
+		require.Equal(t, "http://recent", observerFacade.RecordedBaseUrl)
+	})
+
+	t.Run("on past block, by nonce", func(t *testing.T) {
+		err = provider.getResourceWithOptions(context.Background(), "/test", resources.NewAccountQueryOptionsWithBlockNonce(1), &dummyResourceApiResponse{})
+		require.Nil(t, err)
+		require.Equal(t, "http://archive", observerFacade.RecordedBaseUrl)
+	})
+
+	t.Run("on past block, by hash", func(t *testing.T) {
+		err = provider.postResourceWithOptions(context.Background(), "/test", resources.NewAccountQueryOptionsWithBlockHash([]byte{0x00, 0x01}), nil, &dummyResourceApiResponse{})
+		require.Nil(t, err)
+		require.Equal(t, "http://archive", observerFacade.RecordedBaseUrl)
+	})
+}
+
+func TestNetworkProvider_GetResourceWithRetries(t *testing.T) {
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+	args.NumObserverRetries = 2
+	args.ObserverRetryBackoff = time.Millisecond
+	args.ObserverMaxRetryBackoff = 2 * time.Millisecond
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	numCalls := 0
+
+	t.Run("observer recovers", func(t *testing.T) {
+		numCalls = 0
+
+		observerFacade.CallGetRestEndPointCalled = func(baseUrl string, path string, value interface{}) (int, error) {
+			numCalls++
+			if numCalls < 3 {
+				return 0, NewErrObserverUnreachable(errors.New("connection refused"))
+			}
+
+			return http.StatusOK, nil
+		}
+
+		err = provider.getResource(context.Background(), "/test", &dummyResourceApiResponse{})
+		require.Nil(t, err)
+		require.Equal(t, 3, numCalls)
+	})
+
+	t.Run("observer doesn't recover", func(t *testing.T) {
+		numCalls = 0
+
+		observerFacade.CallGetRestEndPointCalled = func(baseUrl string, path string, value interface{}) (int, error) {
+			numCalls++
+			return 0, NewErrObserverUnreachable(errors.New("connection refused"))
+		}
+
+		err = provider.getResource(context.Background(), "/test", &dummyResourceApiResponse{})
+		require.True(t, IsObserverUnavailableError(err))
+		require.Equal(t, 3, numCalls)
+	})
+
+	t.Run("errors of an available observer aren't retried", func(t *testing.T) {
+		numCalls = 0
+
+		observerFacade.CallGetRestEndPointCalled = func(baseUrl string, path string, value interface{}) (int, error) {
+			numCalls++
+			return http.StatusInternalServerError, errors.New("internal error")
+		}
+
+		err = provider.getResource(context.Background(), "/test", &dummyResourceApiResponse{})
+		require.Equal(t, errors.New("internal error"), err)
+		require.Equal(t, 1, numCalls)
+	})
+
+	t.Run("POST requests aren't retried", func(t *testing.T) {
+		numCalls = 0
+
+		observerFacade.CallPostRestEndPointCalled = func(baseUrl string, path string, data interface{}, response interface{}) (int, error) {
+			numCalls++
+			return 0, NewErrObserverUnreachable(errors.New("timeout"))
+		}
+
+		err = provider.postResource(context.Background(), "/test", nil, &dummyResourceApiResponse{})
+		require.True(t, IsObserverUnavailableError(err))
+		require.Equal(t, 1, numCalls)
+	})
+}
+
+func TestNetworkProvider_GetResourceWithCircuitBreaker(t *testing.T) {
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+	args.CircuitBreakerThreshold = 2
+	args.CircuitBreakerOpenDuration = 50 * time.Millisecond
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	numCalls := 0
+	isObserverDown := true
+
+	observerFacade.CallGetRestEndPointCalled = func(baseUrl string, path string, value interface{}) (int, error) {
+		numCalls++
+		if isObserverDown {
+			return 0, NewErrObserverUnreachable(errors.New("connection refused"))
+		}
+
+		return http.StatusOK, nil
+	}
+
+	_ = provider.getResource(context.Background(), "/test", &dummyResourceApiResponse{})
+	_ = provider.getResource(context.Background(), "/test", &dummyResourceApiResponse{})
+	require.Equal(t, 2, numCalls)
+
+	// Open: fail fast.
+	err = provider.getResource(context.Background(), "/test", &dummyResourceApiResponse{})
+	require.ErrorIs(t, err, errCircuitBreakerOpen)
+	require.True(t, IsObserverUnavailableError(err))
+	require.Equal(t, 2, numCalls)
+
+	// After the open duration, a trial call is let through.
+	time.Sleep(60 * time.Millisecond)
+	isObserverDown = false
+
+	err = provider.getResource(context.Background(), "/test", &dummyResourceApiResponse{})
+	require.Nil(t, err)
+	require.Equal(t, 3, numCalls)
+
+	// Closed again.
+	err = provider.getResource(context.Background(), "/test", &dummyResourceApiResponse{})
+	require.Nil(t, err)
+	require.Equal(t, 4, numCalls)
+}
+
+func TestNetworkProvider_GetResourceWithNotFound(t *testing.T) {
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+	args.ObserversPool = createObserversPool("http://a", "http://b")
+	args.NumObserverRetries = 2
+	args.CircuitBreakerThreshold = 1
+	args.CircuitBreakerOpenDuration = time.Minute
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	numCalls := 0
+
+	observerFacade.CallGetRestEndPointCalled = func(baseUrl string, path string, value interface{}) (int, error) {
+		numCalls++
+		return http.StatusNotFound, errors.New("not found")
+	}
+
+	// A live observer responding with "not found" is neither skipped, nor retried.
+	err = provider.getResource(context.Background(), "/test", &dummyResourceApiResponse{})
+	require.Equal(t, errors.New("not found"), err)
+	require.False(t, IsObserverUnavailableError(err))
+	require.Equal(t, 1, numCalls)
+
+	// The observer isn't blamed (it's still first in line), and the circuit breaker hasn't been opened.
+	require.Equal(t, []string{"http://a", "http://b"}, provider.observersPool.GetUrlsOrderedByHealth())
+	require.False(t, provider.circuitBreaker.isOpen)
+	require.Equal(t, uint32(0), provider.circuitBreaker.numConsecutiveFailures)
+
+	err = provider.getResource(context.Background(), "/test", &dummyResourceApiResponse{})
+	require.Equal(t, errors.New("not found"), err)
+	require.Equal(t, 2, numCalls)
+}
+
+func TestNetworkProvider_GetResourceWithCancelledContext(t *testing.T) {
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+	args.ObserversPool = createObserversPool("http://a", "http://b")
+	args.NumObserverRetries = 5
+	args.ObserverRetryBackoff = time.Millisecond
+	args.ObserverMaxRetryBackoff = time.Millisecond
+	args.CircuitBreakerThreshold = 1
+	args.CircuitBreakerOpenDuration = time.Minute
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	numCalls := 0
+
+	observerFacade.CallGetRestEndPointCalled = func(baseUrl string, path string, value interface{}) (int, error) {
+		numCalls++
+		return 0, NewErrObserverUnreachable(errors.New("request cancelled"))
+	}
+
+	// Neither retried, nor tried against the next observer.
+	err = provider.getResource(ctx, "/test", &dummyResourceApiResponse{})
+	require.ErrorIs(t, err, context.Canceled)
+	require.False(t, IsObserverUnavailableError(err))
+	require.Equal(t, 1, numCalls)
+
+	// The observer isn't blamed (it's still first in line), and the circuit breaker hasn't been opened.
+	require.Equal(t, []string{"http://a", "http://b"}, provider.observersPool.GetUrlsOrderedByHealth())
+
+	observerFacade.CallGetRestEndPointCalled = func(baseUrl string, path string, value interface{}) (int, error) {
+		numCalls++
+		return http.StatusOK, nil
+	}
+
+	err = provider.getResource(context.Background(), "/test", &dummyResourceApiResponse{})
+	require.Nil(t, err)
+	require.Equal(t, 2, numCalls)
+}
+
+func TestNetworkProvider_DoWithRetriesStopsOnDeadline(t *testing.T) {
+	args := createDefaultArgsNewNetworkProvider()
+	args.NumObserverRetries = 100
+	args.ObserverRetryBackoff = time.Hour
+	args.ObserverMaxRetryBackoff = time.Hour
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	numCalls := 0
+
+	err = provider.doWithRetries(ctx, func() error {
+		numCalls++
+		return newErrObserverUnavailable(errors.New("connection refused"))
+	})
+	require.True(t, IsObserverUnavailableError(err))
+	require.Equal(t, 1, numCalls)
+}
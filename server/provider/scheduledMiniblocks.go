@@ -1,18 +1,20 @@
 package provider
 
 import (
+	"context"
+
 	"github.com/multiversx/mx-chain-core-go/data/api"
 	dataBlock "github.com/multiversx/mx-chain-core-go/data/block"
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
 )
 
-func (provider *networkProvider) simplifyBlockWithScheduledTransactions(block *api.Block) error {
-	previousBlock, err := provider.doGetBlockByNonce(block.Nonce - 1)
+func (provider *networkProvider) simplifyBlockWithScheduledTransactions(ctx context.Context, block *api.Block) error {
+	previousBlock, err := provider.doGetBlockByNonce(ctx, block.Nonce-1)
 	if err != nil {
 		return err
 	}
 
-	nextBlock, err := provider.doGetBlockByNonce(block.Nonce + 1)
+	nextBlock, err := provider.doGetBlockByNonce(ctx, block.Nonce+1)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -89,7 +90,7 @@ func TestNetworkProviderSimplifyBlockWithScheduledTransactions(t *testing.T) {
 		return nil, errors.New("unexpected request")
 	}
 
-	err = provider.simplifyBlockWithScheduledTransactions(blocks[1])
+	err = provider.simplifyBlockWithScheduledTransactions(context.Background(), blocks[1])
 	require.Nil(t, err)
 
 	require.Len(t, blocks[1].MiniBlocks, 2)
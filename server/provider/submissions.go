@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+)
+
+// GetSubmittedTransaction gets a transaction tracked by the submissions tracker (a copy of it)
+func (provider *networkProvider) GetSubmittedTransaction(hash string) (*resources.SubmittedTransaction, bool) {
+	provider.submissionsMutex.RLock()
+	defer provider.submissionsMutex.RUnlock()
+
+	submission, ok := provider.submissions[hash]
+	if !ok {
+		return nil, false
+	}
+
+	submissionCopy := *submission
+	return &submissionCopy, true
+}
+
+// RefreshSubmittedTransactions checks whether the pending (tracked) transactions have landed in a final block or have been dropped from the pool.
+// Transactions that reached a terminal status are forgotten after a while.
+func (provider *networkProvider) RefreshSubmittedTransactions() error {
+	if provider.isOffline {
+		return errIsOffline
+	}
+
+	now := time.Now()
+	defer provider.evictStaleSubmissions(now)
+
+	pendingSubmissions := provider.getPendingSubmissions()
+	if len(pendingSubmissions) == 0 {
+		return nil
+	}
+
+	nodeStatus, err := provider.getPlainNodeStatus(provider.backgroundContext)
+	if err != nil {
+		return err
+	}
+
+	for _, submission := range pendingSubmissions {
+		updatedSubmission := provider.checkSubmission(provider.backgroundContext, submission, nodeStatus.HighestFinalNonce, now)
+		if updatedSubmission == nil {
+			continue
+		}
+
+		log.Debug("networkProvider.RefreshSubmittedTransactions(): status changed",
+			"hash", updatedSubmission.Hash,
+			"status", updatedSubmission.Status,
+			"executionStatus", updatedSubmission.ExecutionStatus,
+			"dropReason", updatedSubmission.DropReason,
+		)
+
+		provider.submissionsMutex.Lock()
+		provider.submissions[updatedSubmission.Hash] = updatedSubmission
+		provider.submissionsMutex.Unlock()
+	}
+
+	return nil
+}
+
+// StartSubmissionsTrackingLoop periodically refreshes the status of the tracked transactions (in the background), until the provider is closed.
+// A zero interval disables the tracking (the submitted transactions would then remain pending, thus the CLI does not allow it).
+func (provider *networkProvider) StartSubmissionsTrackingLoop(interval time.Duration) {
+	if interval == 0 {
+		log.Warn("networkProvider.StartSubmissionsTrackingLoop(): submissions tracking is disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				err := provider.RefreshSubmittedTransactions()
+				if err != nil {
+					log.Warn("networkProvider.StartSubmissionsTrackingLoop(): cannot refresh submitted transactions", "err", err)
+				}
+			case <-provider.closing:
+				return
+			}
+		}
+	}()
+}
+
+// isSubmissionTracked tells whether a transaction (given its hash) has already been broadcasted (it's tracked and not dropped)
+func (provider *networkProvider) isSubmissionTracked(hash string) bool {
+	provider.submissionsMutex.RLock()
+	defer provider.submissionsMutex.RUnlock()
+
+	submission, ok := provider.submissions[hash]
+	return ok && submission.Status != resources.SubmissionStatusDropped
+}
+
+// acquireSubmissionGuard makes sure that a transaction (given its hash) isn't submitted by multiple requests at the same time.
+// The returned function releases the guard. Meanwhile, other requests submitting the same transaction wait (or give up, once their context is done).
+func (provider *networkProvider) acquireSubmissionGuard(ctx context.Context, hash string) (func(), error) {
+	for {
+		provider.submissionsMutex.Lock()
+		inFlight, ok := provider.submissionsInFlight[hash]
+		if !ok {
+			done := make(chan struct{})
+			provider.submissionsInFlight[hash] = done
+			provider.submissionsMutex.Unlock()
+
+			release := func() {
+				provider.submissionsMutex.Lock()
+				delete(provider.submissionsInFlight, hash)
+				provider.submissionsMutex.Unlock()
+				close(done)
+			}
+
+			return release, nil
+		}
+		provider.submissionsMutex.Unlock()
+
+		select {
+		case <-inFlight:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (provider *networkProvider) trackSubmission(hash string, tx *data.Transaction) {
+	now := time.Now()
+
+	provider.submissionsMutex.Lock()
+	defer provider.submissionsMutex.Unlock()
+
+	provider.submissions[hash] = &resources.SubmittedTransaction{
+		Hash:              hash,
+		Sender:            tx.Sender,
+		Nonce:             tx.Nonce,
+		SignedTransaction: tx,
+		Status:            resources.SubmissionStatusPending,
+		SubmittedAt:       now,
+		UpdatedAt:         now,
+	}
+}
+
+func (provider *networkProvider) getPendingSubmissions() []*resources.SubmittedTransaction {
+	provider.submissionsMutex.RLock()
+	defer provider.submissionsMutex.RUnlock()
+
+	pendingSubmissions := make([]*resources.SubmittedTransaction, 0, len(provider.submissions))
+	for _, submission := range provider.submissions {
+		if submission.Status == resources.SubmissionStatusPending {
+			pendingSubmissions = append(pendingSubmissions, submission)
+		}
+	}
+
+	return pendingSubmissions
+}
+
+// checkSubmission returns an updated copy of the submission, or nil if its status hasn't changed
+func (provider *networkProvider) checkSubmission(ctx context.Context, submission *resources.SubmittedTransaction, highestFinalNonce uint64, now time.Time) *resources.SubmittedTransaction {
+	// The account is fetched before the transaction (on purpose), so that a transaction executed in-between isn't mistaken for a dropped one.
+	account, err := provider.GetAccount(ctx, submission.Sender)
+	if err != nil {
+		log.Debug("networkProvider.checkSubmission(): cannot get sender", "hash", submission.Hash, "err", err)
+		return nil
+	}
+
+	tx, _, err := provider.observerFacade.GetTransactionByHashAndSenderAddress(ctx, submission.Hash, submission.Sender, false)
+	if err == nil {
+		isInFinalBlock := tx.BlockNonce > 0 && tx.BlockNonce <= highestFinalNonce
+		if !isInFinalBlock {
+			return nil
+		}
+
+		updatedSubmission := *submission
+		updatedSubmission.Status = resources.SubmissionStatusLanded
+		updatedSubmission.ExecutionStatus = string(tx.Status)
+		updatedSubmission.BlockNonce = tx.BlockNonce
+		updatedSubmission.BlockHash = tx.BlockHash
+		updatedSubmission.SignedTransaction = nil
+		updatedSubmission.UpdatedAt = now
+		return &updatedSubmission
+	}
+
+	isNonceConsumed := account.Account.Nonce > submission.Nonce
+	isExpired := now.Sub(submission.SubmittedAt) > submittedTransactionsDropTimeout
+
+	dropReason := ""
+	if isNonceConsumed {
+		dropReason = "nonce consumed by another transaction"
+	} else if isExpired {
+		// The transaction isn't found (by hash), after a while: it's considered dropped only if it's missing from the pool, as well.
+		isInPool, err := provider.isTransactionInPoolOfSender(ctx, submission.Sender, submission.Hash)
+		if err != nil {
+			log.Debug("networkProvider.checkSubmission(): cannot check pool", "hash", submission.Hash, "err", err)
+			return nil
+		}
+		if isInPool {
+			return nil
+		}
+
+		dropReason = "not found in pool"
+	} else {
+		return nil
+	}
+
+	updatedSubmission := *submission
+	updatedSubmission.Status = resources.SubmissionStatusDropped
+	updatedSubmission.DropReason = dropReason
+	updatedSubmission.SignedTransaction = nil
+	updatedSubmission.UpdatedAt = now
+	return &updatedSubmission
+}
+
+func (provider *networkProvider) evictStaleSubmissions(now time.Time) {
+	provider.submissionsMutex.Lock()
+	defer provider.submissionsMutex.Unlock()
+
+	for hash, submission := range provider.submissions {
+		isTerminal := submission.Status != resources.SubmissionStatusPending
+		isStale := now.Sub(submission.UpdatedAt) > submittedTransactionsRetention
+
+		if isTerminal && isStale {
+			delete(provider.submissions, hash)
+		}
+	}
+}
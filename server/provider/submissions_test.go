@@ -0,0 +1,310 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkProvider_SendTransactionIsIdempotent(t *testing.T) {
+	t.Parallel()
+
+	observerFacade := testscommon.NewObserverFacadeMock()
+	observerFacade.MockComputedTransactionHash = "aaaa"
+
+	numSent := 0
+	observerFacade.SendTransactionCalled = func(tx *data.Transaction) (int, string, error) {
+		numSent++
+		return 200, "aaaa", nil
+	}
+
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	tx := &data.Transaction{
+		Sender:    testscommon.TestAddressAlice,
+		Receiver:  testscommon.TestAddressBob,
+		Nonce:     42,
+		Signature: "aabb",
+	}
+
+	hash, err := provider.SendTransaction(context.Background(), tx)
+	require.Nil(t, err)
+	require.Equal(t, "aaaa", hash)
+
+	hash, err = provider.SendTransaction(context.Background(), tx)
+	require.Nil(t, err)
+	require.Equal(t, "aaaa", hash)
+	require.Equal(t, 1, numSent)
+
+	submission, ok := provider.GetSubmittedTransaction("aaaa")
+	require.True(t, ok)
+	require.Equal(t, resources.SubmissionStatusPending, submission.Status)
+	require.Equal(t, uint64(42), submission.Nonce)
+	require.Equal(t, tx, submission.SignedTransaction)
+
+	// Once dropped, the transaction can be broadcasted again
+	provider.submissions["aaaa"].Status = resources.SubmissionStatusDropped
+
+	hash, err = provider.SendTransaction(context.Background(), tx)
+	require.Nil(t, err)
+	require.Equal(t, "aaaa", hash)
+	require.Equal(t, 2, numSent)
+
+	submission, _ = provider.GetSubmittedTransaction("aaaa")
+	require.Equal(t, resources.SubmissionStatusPending, submission.Status)
+}
+
+func TestNetworkProvider_SendTransactionConcurrently(t *testing.T) {
+	t.Parallel()
+
+	observerFacade := testscommon.NewObserverFacadeMock()
+	observerFacade.MockComputedTransactionHash = "aaaa"
+
+	var numSent atomic.Int32
+	observerFacade.SendTransactionCalled = func(tx *data.Transaction) (int, string, error) {
+		numSent.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return 200, "aaaa", nil
+	}
+
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	tx := &data.Transaction{
+		Sender:    testscommon.TestAddressAlice,
+		Receiver:  testscommon.TestAddressBob,
+		Nonce:     42,
+		Signature: "aabb",
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			hash, err := provider.SendTransaction(context.Background(), tx)
+			require.Nil(t, err)
+			require.Equal(t, "aaaa", hash)
+		}()
+	}
+
+	wg.Wait()
+	require.Equal(t, int32(1), numSent.Load())
+	require.Len(t, provider.submissionsInFlight, 0)
+}
+
+func TestNetworkProvider_AcquireSubmissionGuard(t *testing.T) {
+	t.Parallel()
+
+	provider, err := NewNetworkProvider(createDefaultArgsNewNetworkProvider())
+	require.Nil(t, err)
+
+	release, err := provider.acquireSubmissionGuard(context.Background(), "aaaa")
+	require.Nil(t, err)
+
+	// Other transactions aren't blocked
+	releaseOther, err := provider.acquireSubmissionGuard(context.Background(), "bbbb")
+	require.Nil(t, err)
+	releaseOther()
+
+	// The same transaction is blocked, until released (or until the context is done)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = provider.acquireSubmissionGuard(ctx, "aaaa")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+
+	release, err = provider.acquireSubmissionGuard(context.Background(), "aaaa")
+	require.Nil(t, err)
+	release()
+}
+
+func TestNetworkProvider_RefreshSubmittedTransactions(t *testing.T) {
+	t.Parallel()
+
+	createProvider := func(accountNonce uint64, highestFinalNonce uint64) (*networkProvider, map[string]*transaction.ApiTransactionResult, map[string]bool) {
+		observerFacade := testscommon.NewObserverFacadeMock()
+		observerFacade.MockTransactionsByHash = make(map[string]*transaction.ApiTransactionResult)
+		transactionsInPool := make(map[string]bool)
+		observerFacade.CallGetRestEndPointCalled = func(baseUrl string, path string, value interface{}) (int, error) {
+			if path == "/node/status" {
+				value.(*resources.NodeStatusApiResponse).Data.Status = resources.NodeStatus{
+					HighestFinalNonce: highestFinalNonce,
+				}
+				return 200, nil
+			}
+
+			if strings.HasPrefix(path, "/address/") {
+				value.(*resources.AccountApiResponse).Data.Account = resources.Account{
+					Address: testscommon.TestAddressAlice,
+					Nonce:   accountNonce,
+				}
+				return 200, nil
+			}
+
+			if strings.HasPrefix(path, "/transaction/pool?by-sender=") {
+				require.True(t, strings.HasSuffix(path, "&fields=hash"))
+
+				response := value.(*resources.TransactionsPoolForSenderApiResponse)
+				for hash := range transactionsInPool {
+					response.Data.TxPool.Transactions = append(response.Data.TxPool.Transactions, data.WrappedTransaction{
+						TxFields: map[string]interface{}{"hash": hash},
+					})
+				}
+				return 200, nil
+			}
+
+			return 500, errors.New("unexpected request")
+		}
+
+		args := createDefaultArgsNewNetworkProvider()
+		args.ObserverFacade = observerFacade
+
+		provider, err := NewNetworkProvider(args)
+		require.Nil(t, err)
+
+		return provider, observerFacade.MockTransactionsByHash, transactionsInPool
+	}
+
+	tx := &data.Transaction{
+		Sender: testscommon.TestAddressAlice,
+		Nonce:  42,
+	}
+
+	t.Run("landed in final block", func(t *testing.T) {
+		t.Parallel()
+
+		provider, transactionsByHash, _ := createProvider(43, 1000)
+		provider.trackSubmission("aaaa", tx)
+
+		transactionsByHash["aaaa"] = &transaction.ApiTransactionResult{
+			Status:     transaction.TxStatusSuccess,
+			BlockNonce: 1001,
+			BlockHash:  "bbbb",
+		}
+
+		// Not final yet
+		err := provider.RefreshSubmittedTransactions()
+		require.Nil(t, err)
+		submission, _ := provider.GetSubmittedTransaction("aaaa")
+		require.Equal(t, resources.SubmissionStatusPending, submission.Status)
+
+		transactionsByHash["aaaa"].BlockNonce = 1000
+
+		err = provider.RefreshSubmittedTransactions()
+		require.Nil(t, err)
+		submission, _ = provider.GetSubmittedTransaction("aaaa")
+		require.Equal(t, resources.SubmissionStatusLanded, submission.Status)
+		require.Equal(t, "success", submission.ExecutionStatus)
+		require.Equal(t, uint64(1000), submission.BlockNonce)
+		require.Equal(t, "bbbb", submission.BlockHash)
+		require.Nil(t, submission.SignedTransaction)
+	})
+
+	t.Run("still in pool", func(t *testing.T) {
+		t.Parallel()
+
+		provider, transactionsByHash, _ := createProvider(42, 1000)
+		provider.trackSubmission("aaaa", tx)
+
+		transactionsByHash["aaaa"] = &transaction.ApiTransactionResult{
+			Status: transaction.TxStatusPending,
+		}
+
+		err := provider.RefreshSubmittedTransactions()
+		require.Nil(t, err)
+		submission, _ := provider.GetSubmittedTransaction("aaaa")
+		require.Equal(t, resources.SubmissionStatusPending, submission.Status)
+		require.NotNil(t, submission.SignedTransaction)
+	})
+
+	t.Run("dropped, nonce consumed", func(t *testing.T) {
+		t.Parallel()
+
+		provider, _, _ := createProvider(43, 1000)
+		provider.trackSubmission("aaaa", tx)
+
+		err := provider.RefreshSubmittedTransactions()
+		require.Nil(t, err)
+		submission, _ := provider.GetSubmittedTransaction("aaaa")
+		require.Equal(t, resources.SubmissionStatusDropped, submission.Status)
+		require.Equal(t, "nonce consumed by another transaction", submission.DropReason)
+		require.Nil(t, submission.SignedTransaction)
+	})
+
+	t.Run("dropped, not found for too long", func(t *testing.T) {
+		t.Parallel()
+
+		provider, _, transactionsInPool := createProvider(42, 1000)
+		provider.trackSubmission("aaaa", tx)
+
+		// Not found, but not expired yet
+		err := provider.RefreshSubmittedTransactions()
+		require.Nil(t, err)
+		submission, _ := provider.GetSubmittedTransaction("aaaa")
+		require.Equal(t, resources.SubmissionStatusPending, submission.Status)
+
+		provider.submissions["aaaa"].SubmittedAt = time.Now().Add(-submittedTransactionsDropTimeout - time.Minute)
+
+		// Not found (by hash) for too long, but still in the pool
+		transactionsInPool["aaaa"] = true
+
+		err = provider.RefreshSubmittedTransactions()
+		require.Nil(t, err)
+		submission, _ = provider.GetSubmittedTransaction("aaaa")
+		require.Equal(t, resources.SubmissionStatusPending, submission.Status)
+
+		delete(transactionsInPool, "aaaa")
+
+		err = provider.RefreshSubmittedTransactions()
+		require.Nil(t, err)
+		submission, _ = provider.GetSubmittedTransaction("aaaa")
+		require.Equal(t, resources.SubmissionStatusDropped, submission.Status)
+		require.Equal(t, "not found in pool", submission.DropReason)
+	})
+
+	t.Run("stale submissions are evicted", func(t *testing.T) {
+		t.Parallel()
+
+		provider, _, _ := createProvider(43, 1000)
+		provider.trackSubmission("aaaa", tx)
+		provider.trackSubmission("bbbb", tx)
+
+		provider.submissions["aaaa"].Status = resources.SubmissionStatusLanded
+		provider.submissions["aaaa"].UpdatedAt = time.Now().Add(-submittedTransactionsRetention - time.Minute)
+		provider.submissions["bbbb"].UpdatedAt = time.Now().Add(-submittedTransactionsRetention - time.Minute)
+
+		err := provider.RefreshSubmittedTransactions()
+		require.Nil(t, err)
+
+		_, ok := provider.GetSubmittedTransaction("aaaa")
+		require.False(t, ok)
+
+		// Pending ones are never evicted (though, in this case, "bbbb" got dropped meanwhile)
+		submission, ok := provider.GetSubmittedTransaction("bbbb")
+		require.True(t, ok)
+		require.Equal(t, resources.SubmissionStatusDropped, submission.Status)
+	})
+}
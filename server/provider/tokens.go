@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+)
+
+const (
+	esdtFunctionGetTokenProperties      = "getTokenProperties"
+	vmQueryReturnCodeOk                 = "ok"
+	numFixedFieldsOfTokenProperties     = 5
+	separatorOfTokenPropertiesKeyValues = "-"
+)
+
+// erd1qqqqqqqqqqqqqqqpqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqzllls8a5w6u
+var esdtSystemContractPubKey, _ = hex.DecodeString("000000000000000000010000000000000000000000000000000000000002ffff")
+
+// GetTokenProperties gets the properties of an ESDT token, by querying the ESDT system contract.
+// The ESDT system contract lives in the metachain, thus the observer must be able to run queries against it.
+func (provider *networkProvider) GetTokenProperties(ctx context.Context, tokenIdentifier string) (*resources.TokenProperties, error) {
+	if provider.isOffline {
+		return nil, errIsOffline
+	}
+
+	query := &data.VmValueRequest{
+		Address:  provider.ConvertPubKeyToAddress(esdtSystemContractPubKey),
+		FuncName: esdtFunctionGetTokenProperties,
+		Args:     []string{hex.EncodeToString([]byte(tokenIdentifier))},
+	}
+
+	output, err := provider.QueryContract(ctx, query, resources.AccountQueryOptions{})
+	if err != nil {
+		return nil, newErrCannotGetTokenProperties(tokenIdentifier, err)
+	}
+	if output.ReturnCode != vmQueryReturnCodeOk {
+		return nil, newErrCannotGetTokenProperties(tokenIdentifier, fmt.Errorf("%s: %s", output.ReturnCode, output.ReturnMessage))
+	}
+
+	return provider.parseTokenProperties(tokenIdentifier, output.ReturnData)
+}
+
+// parseTokenProperties parses the output of "getTokenProperties": name, type, owner, minted & burnt supply, then a list of "key-value" items
+// (e.g. "NumDecimals-18", "CanMint-true").
+func (provider *networkProvider) parseTokenProperties(tokenIdentifier string, returnData [][]byte) (*resources.TokenProperties, error) {
+	if len(returnData) < numFixedFieldsOfTokenProperties {
+		return nil, newErrCannotGetTokenProperties(tokenIdentifier, errors.New("unexpected output"))
+	}
+
+	properties := make(map[string]string)
+
+	for _, item := range returnData[numFixedFieldsOfTokenProperties:] {
+		parts := strings.SplitN(string(item), separatorOfTokenPropertiesKeyValues, 2)
+		if len(parts) != 2 {
+			// We do not report such an error (not important)
+			continue
+		}
+
+		properties[parts[0]] = parts[1]
+	}
+
+	return &resources.TokenProperties{
+		Identifier: tokenIdentifier,
+		Name:       string(returnData[0]),
+		Type:       string(returnData[1]),
+		Owner:      provider.ConvertPubKeyToAddress(returnData[2]),
+		Minted:     string(returnData[3]),
+		Burnt:      string(returnData[4]),
+		Properties: properties,
+	}, nil
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkProvider_GetTokenProperties(t *testing.T) {
+	t.Parallel()
+
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	t.Run("with success", func(t *testing.T) {
+		observerFacade.CallPostRestEndPointCalled = func(baseUrl string, path string, payload interface{}, response interface{}) (int, error) {
+			require.Equal(t, "/vm-values/query", path)
+			require.Equal(t, &data.VmValueRequest{
+				Address:  "erd1qqqqqqqqqqqqqqqpqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqzllls8a5w6u",
+				FuncName: "getTokenProperties",
+				Args:     []string{hex.EncodeToString([]byte("ROSETTA-3a2edf"))},
+			}, payload)
+
+			response.(*resources.VMQueryApiResponse).Data.Data = resources.VMQueryOutput{
+				ReturnCode: "ok",
+				ReturnData: [][]byte{
+					[]byte("ROSETTA"),
+					[]byte("FungibleESDT"),
+					testscommon.TestPubKeyAlice,
+					[]byte("1000000"),
+					[]byte("0"),
+					[]byte("NumDecimals-2"),
+					[]byte("IsPaused-false"),
+					[]byte("CanMint-true"),
+					[]byte("malformed"),
+				},
+			}
+			return 200, nil
+		}
+
+		properties, err := provider.GetTokenProperties(context.Background(), "ROSETTA-3a2edf")
+		require.Nil(t, err)
+		require.Equal(t, &resources.TokenProperties{
+			Identifier: "ROSETTA-3a2edf",
+			Name:       "ROSETTA",
+			Type:       "FungibleESDT",
+			Owner:      testscommon.TestAddressAlice,
+			Minted:     "1000000",
+			Burnt:      "0",
+			Properties: map[string]string{
+				"NumDecimals": "2",
+				"IsPaused":    "false",
+				"CanMint":     "true",
+			},
+		}, properties)
+	})
+
+	t.Run("with unknown token", func(t *testing.T) {
+		observerFacade.CallPostRestEndPointCalled = func(baseUrl string, path string, payload interface{}, response interface{}) (int, error) {
+			response.(*resources.VMQueryApiResponse).Data.Data = resources.VMQueryOutput{
+				ReturnCode:    "user error",
+				ReturnMessage: "no ticker with given name",
+			}
+			return 200, nil
+		}
+
+		properties, err := provider.GetTokenProperties(context.Background(), "FOO-abcdef")
+		require.ErrorIs(t, err, errCannotGetTokenProperties)
+		require.ErrorContains(t, err, "no ticker with given name")
+		require.Nil(t, properties)
+	})
+
+	t.Run("with unexpected output", func(t *testing.T) {
+		observerFacade.CallPostRestEndPointCalled = func(baseUrl string, path string, payload interface{}, response interface{}) (int, error) {
+			response.(*resources.VMQueryApiResponse).Data.Data = resources.VMQueryOutput{
+				ReturnCode: "ok",
+				ReturnData: [][]byte{[]byte("ROSETTA")},
+			}
+			return 200, nil
+		}
+
+		properties, err := provider.GetTokenProperties(context.Background(), "ROSETTA-3a2edf")
+		require.ErrorIs(t, err, errCannotGetTokenProperties)
+		require.Nil(t, properties)
+	})
+}
@@ -1,7 +1,11 @@
 package provider
 
 import (
+	"context"
+
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
 )
 
 // IsRelayedTxV3 checks whether the provided (API) transaction is relayed (V3).
@@ -19,3 +23,51 @@ func IsRelayedTxV3(tx *transaction.ApiTransactionResult) bool {
 
 	return true
 }
+
+// EstimateTransactionGas asks the observer to estimate the gas units consumed by the provided (unsigned) transaction
+func (provider *networkProvider) EstimateTransactionGas(ctx context.Context, tx *data.Transaction) (uint64, error) {
+	response := &resources.TransactionCostApiResponse{}
+
+	err := provider.postResource(ctx, urlPathComputeTransactionCost, tx, response)
+	if err != nil {
+		return 0, newErrCannotEstimateTransactionGas(err)
+	}
+
+	// When the simulated execution fails, the observer still responds with success, but sets a "return message".
+	if len(response.Data.ReturnMessage) > 0 {
+		return 0, newErrCannotEstimateTransactionGas(NewErrSimulatedExecutionFailed(response.Data.ReturnMessage))
+	}
+
+	log.Trace("networkProvider.EstimateTransactionGas()",
+		"sender", tx.Sender,
+		"receiver", tx.Receiver,
+		"gasUnits", response.Data.GasUnits,
+	)
+
+	return response.Data.GasUnits, nil
+}
+
+// SimulateTransaction asks the observer to simulate (dry-run) the execution of the provided (signed) transaction
+func (provider *networkProvider) SimulateTransaction(ctx context.Context, tx *data.Transaction) (*resources.TransactionSimulationResults, error) {
+	if provider.isOffline {
+		return nil, errIsOffline
+	}
+
+	response := &resources.TransactionSimulationApiResponse{}
+
+	err := provider.postResource(ctx, urlPathSimulateTransaction, tx, response)
+	if err != nil {
+		return nil, newErrCannotSimulateTransaction(err)
+	}
+
+	results := &response.Data.Result
+
+	log.Debug("networkProvider.SimulateTransaction()",
+		"sender", tx.Sender,
+		"nonce", tx.Nonce,
+		"status", results.Status,
+		"failReason", results.FailReason,
+	)
+
+	return results, nil
+}
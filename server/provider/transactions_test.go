@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkProvider_EstimateTransactionGas(t *testing.T) {
+	t.Parallel()
+
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+	require.NotNil(t, provider)
+
+	tx := &data.Transaction{
+		Sender:   testscommon.TestAddressAlice,
+		Receiver: testscommon.TestAddressOfContract,
+		Data:     []byte("add@01"),
+	}
+
+	t.Run("with success", func(t *testing.T) {
+		observerFacade.CallPostRestEndPointCalled = func(baseUrl string, path string, payload interface{}, response interface{}) (int, error) {
+			require.Equal(t, "/transaction/cost", path)
+			require.Equal(t, tx, payload)
+
+			response.(*resources.TransactionCostApiResponse).Data = resources.TransactionCost{
+				GasUnits: 1234567,
+			}
+
+			return 200, nil
+		}
+
+		gasUnits, err := provider.EstimateTransactionGas(context.Background(), tx)
+		require.Nil(t, err)
+		require.Equal(t, uint64(1234567), gasUnits)
+	})
+
+	t.Run("with failed execution", func(t *testing.T) {
+		observerFacade.CallPostRestEndPointCalled = func(baseUrl string, path string, payload interface{}, response interface{}) (int, error) {
+			response.(*resources.TransactionCostApiResponse).Data = resources.TransactionCost{
+				ReturnMessage: "invalid function (not found)",
+			}
+
+			return 200, nil
+		}
+
+		gasUnits, err := provider.EstimateTransactionGas(context.Background(), tx)
+		require.ErrorIs(t, err, errCannotEstimateTransactionGas)
+		require.ErrorContains(t, err, "invalid function (not found)")
+		require.True(t, IsSimulatedExecutionFailedError(err))
+		require.Equal(t, uint64(0), gasUnits)
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		observerFacade.CallPostRestEndPointCalled = func(baseUrl string, path string, payload interface{}, response interface{}) (int, error) {
+			return 500, errors.New("arbitrary error")
+		}
+
+		gasUnits, err := provider.EstimateTransactionGas(context.Background(), tx)
+		require.ErrorIs(t, err, errCannotEstimateTransactionGas)
+		require.ErrorContains(t, err, "arbitrary error")
+		require.False(t, IsSimulatedExecutionFailedError(err))
+		require.Equal(t, uint64(0), gasUnits)
+	})
+}
+
+func TestNetworkProvider_SimulateTransaction(t *testing.T) {
+	t.Parallel()
+
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+	require.NotNil(t, provider)
+
+	tx := &data.Transaction{
+		Sender:    testscommon.TestAddressAlice,
+		Receiver:  testscommon.TestAddressOfContract,
+		Data:      []byte("add@01"),
+		GasLimit:  5000000,
+		Signature: "aabb",
+	}
+
+	t.Run("with success", func(t *testing.T) {
+		observerFacade.CallPostRestEndPointCalled = func(baseUrl string, path string, payload interface{}, response interface{}) (int, error) {
+			require.Equal(t, "/transaction/simulate", path)
+			require.Equal(t, tx, payload)
+
+			response.(*resources.TransactionSimulationApiResponse).Data.Result = resources.TransactionSimulationResults{
+				TransactionSimulationResults: data.TransactionSimulationResults{
+					Status:     "fail",
+					FailReason: "out of gas",
+				},
+				VMOutput: &resources.TransactionSimulationVMOutput{
+					GasRemaining: 0,
+				},
+			}
+
+			return 200, nil
+		}
+
+		results, err := provider.SimulateTransaction(context.Background(), tx)
+		require.Nil(t, err)
+		require.Equal(t, "fail", string(results.Status))
+		require.Equal(t, "out of gas", results.FailReason)
+		require.NotNil(t, results.VMOutput)
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		observerFacade.CallPostRestEndPointCalled = func(baseUrl string, path string, payload interface{}, response interface{}) (int, error) {
+			return 500, errors.New("arbitrary error")
+		}
+
+		results, err := provider.SimulateTransaction(context.Background(), tx)
+		require.ErrorIs(t, err, errCannotSimulateTransaction)
+		require.ErrorContains(t, err, "arbitrary error")
+		require.Nil(t, results)
+	})
+}
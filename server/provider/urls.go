@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/multiversx/mx-chain-rosetta/server/resources"
 )
@@ -13,10 +14,17 @@ var (
 	urlPathGetNodeStatus                        = "/node/status"
 	urlPathGetEpochStartInfo                    = "/node/epoch-start/%d"
 	urlPathGetGenesisBalances                   = "/network/genesis-balances"
+	urlPathGetNetworkConfig                     = "/network/config"
 	urlPathGetAccount                           = "/address/%s"
 	urlPathGetAccountNativeBalance              = "/address/%s"
 	urlPathGetAccountFungibleTokenBalance       = "/address/%s/esdt/%s"
 	urlPathGetAccountNonFungibleTokenBalance    = "/address/%s/nft/%s/nonce/%d"
+	urlPathComputeTransactionCost               = "/transaction/cost"
+	urlPathSimulateTransaction                  = "/transaction/simulate"
+	urlPathGetTransactionsPool                  = "/transaction/pool"
+	urlPathQueryVM                              = "/vm-values/query"
+	urlParameterTransactionsPoolSender          = "by-sender"
+	urlParameterTransactionsPoolFields          = "fields"
 	urlParameterAccountQueryOptionsOnFinalBlock = "onFinalBlock"
 	urlParameterAccountQueryOptionsBlockNonce   = "blockNonce"
 	urlParameterAccountQueryOptionsBlockHash    = "blockHash"
@@ -43,6 +51,26 @@ func buildUrlGetAccountNonFungibleTokenBalance(address string, tokenIdentifier s
 	return buildUrlWithAccountQueryOptions(fmt.Sprintf(urlPathGetAccountNonFungibleTokenBalance, address, tokenIdentifier, nonce), options)
 }
 
+func buildUrlGetTransactionsPool(fields []string) string {
+	return buildUrlWithQueryParameter(urlPathGetTransactionsPool, urlParameterTransactionsPoolFields, strings.Join(fields, ","))
+}
+
+func buildUrlGetTransactionsPoolForSender(sender string, fields []string) string {
+	u := url.URL{
+		Path: urlPathGetTransactionsPool,
+	}
+
+	query := u.Query()
+	query.Set(urlParameterTransactionsPoolSender, sender)
+	query.Set(urlParameterTransactionsPoolFields, strings.Join(fields, ","))
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+func buildUrlQueryContract(options resources.AccountQueryOptions) string {
+	return buildUrlWithAccountQueryOptions(urlPathQueryVM, options)
+}
+
 func buildUrlWithAccountQueryOptions(path string, options resources.AccountQueryOptions) string {
 	if options.OnFinalBlock {
 		return buildUrlWithQueryParameter(path, urlParameterAccountQueryOptionsOnFinalBlock, "true")
@@ -66,3 +66,16 @@ func TestBuildUrlGetAccountNonFungibleTokenBalance(t *testing.T) {
 	url = buildUrlGetAccountNonFungibleTokenBalance(testscommon.TestAddressAlice, "ABC-abcdef", 10, resources.AccountQueryOptions{})
 	require.Equal(t, "/address/erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th/nft/ABC-abcdef/nonce/10", url)
 }
+
+func TestBuildUrlGetTransactionsPool(t *testing.T) {
+	url := buildUrlGetTransactionsPool([]string{"hash", "sender", "receiver"})
+	require.Equal(t, "/transaction/pool?fields=hash%2Csender%2Creceiver", url)
+}
+
+func TestBuildUrlGetTransactionsPoolForSender(t *testing.T) {
+	url := buildUrlGetTransactionsPoolForSender(testscommon.TestAddressAlice, []string{"nonce"})
+	require.Equal(t, "/transaction/pool?by-sender=erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th&fields=nonce", url)
+
+	url = buildUrlGetTransactionsPoolForSender(testscommon.TestAddressAlice, []string{"hash", "nonce"})
+	require.Equal(t, "/transaction/pool?by-sender=erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th&fields=hash%2Cnonce", url)
+}
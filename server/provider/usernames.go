@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+
+	"github.com/multiversx/mx-chain-core-go/hashing"
+	"github.com/multiversx/mx-chain-core-go/hashing/keccak"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+)
+
+const (
+	pubKeyLength                 = 32
+	shardSelectorLength          = 2
+	dnsFunctionResolve           = "resolve"
+	dnsDeployerPubKeyFillByte    = 1
+	dnsDeployNonce               = 0
+	contractAddressPaddingLength = 8
+	contractAddressHashStart     = 10
+	contractAddressHashEnd       = 30
+)
+
+var contractAddressVMType = []byte{0x05, 0x00}
+
+// ResolveUsername resolves a username (herotag, e.g. "alice.elrond") to a bech32 address, by querying the DNS contract responsible for it.
+// The observer must be able to run queries against that DNS contract (DNS contracts are spread across shards).
+func (provider *networkProvider) ResolveUsername(ctx context.Context, username string) (string, error) {
+	if provider.isOffline {
+		return "", errIsOffline
+	}
+
+	dnsAddress := provider.ConvertPubKeyToAddress(computeDnsPubKeyForUsername(username))
+
+	query := &data.VmValueRequest{
+		Address:  dnsAddress,
+		FuncName: dnsFunctionResolve,
+		Args:     []string{hex.EncodeToString([]byte(username))},
+	}
+
+	output, err := provider.QueryContract(ctx, query, resources.AccountQueryOptions{})
+	if err != nil {
+		return "", newErrCannotResolveUsername(username, err)
+	}
+
+	returnData := output.ReturnData
+	if len(returnData) == 0 || len(returnData[0]) == 0 {
+		return "", newErrUsernameNotFound(username)
+	}
+
+	address := provider.ConvertPubKeyToAddress(returnData[0])
+	if len(address) == 0 {
+		return "", newErrCannotResolveUsername(username, errUsernameNotFound)
+	}
+
+	log.Debug("networkProvider.ResolveUsername()", "username", username, "address", address, "dns", dnsAddress)
+
+	return address, nil
+}
+
+// computeDnsPubKeyForUsername computes the public key of the DNS contract responsible for a username.
+// There are 256 DNS contracts: the last byte of the username's hash selects one of them (referred to as the "DNS shard").
+func computeDnsPubKeyForUsername(username string) []byte {
+	hasher := keccak.NewKeccak()
+	usernameHash := hasher.Compute(username)
+	dnsShard := usernameHash[len(usernameHash)-1]
+
+	return computeDnsPubKeyForShard(hasher, dnsShard)
+}
+
+// computeDnsPubKeyForShard computes the public key of a DNS contract. Each DNS contract is deployed (with nonce 0) by its own system account:
+// 30 bytes of 0x01, followed by the DNS shard (on 2 bytes).
+func computeDnsPubKeyForShard(hasher hashing.Hasher, dnsShard byte) []byte {
+	deployerPubKey := make([]byte, pubKeyLength)
+	for i := 0; i < pubKeyLength-shardSelectorLength; i++ {
+		deployerPubKey[i] = dnsDeployerPubKeyFillByte
+	}
+
+	deployerPubKey[pubKeyLength-1] = dnsShard
+
+	return computeContractPubKey(hasher, deployerPubKey, dnsDeployNonce)
+}
+
+// computeContractPubKey computes the public key of a contract, given its deployer and the deployment nonce (same as the protocol does).
+func computeContractPubKey(hasher hashing.Hasher, deployerPubKey []byte, nonce uint64) []byte {
+	nonceBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(nonceBytes, nonce)
+
+	hash := hasher.Compute(string(append(append([]byte{}, deployerPubKey...), nonceBytes...)))
+	shardSelector := deployerPubKey[len(deployerPubKey)-shardSelectorLength:]
+
+	pubKey := make([]byte, contractAddressPaddingLength, pubKeyLength)
+	pubKey = append(pubKey, contractAddressVMType...)
+	pubKey = append(pubKey, hash[contractAddressHashStart:contractAddressHashEnd]...)
+	pubKey = append(pubKey, shardSelector...)
+
+	return pubKey
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/hashing/keccak"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeDnsPubKey(t *testing.T) {
+	t.Parallel()
+
+	converter := testscommon.RealWorldBech32PubkeyConverter
+
+	// Known DNS contracts, see https://github.com/multiversx/mx-sdk-py (dns)
+	address, err := converter.Encode(computeDnsPubKeyForShard(keccak.NewKeccak(), 0))
+	require.Nil(t, err)
+	require.Equal(t, "erd1qqqqqqqqqqqqqpgqnhvsujzd95jz6fyv3ldmynlf97tscs9nqqqq49en6w", address)
+
+	address, err = converter.Encode(computeDnsPubKeyForShard(keccak.NewKeccak(), 1))
+	require.Nil(t, err)
+	require.Equal(t, "erd1qqqqqqqqqqqqqpgqysmcsfkqed279x6jvs694th4e4v50p4pqqqsxwywm0", address)
+
+	address, err = converter.Encode(computeDnsPubKeyForShard(keccak.NewKeccak(), 2))
+	require.Nil(t, err)
+	require.Equal(t, "erd1qqqqqqqqqqqqqpgqnk5fq8sgg4vc63ffzf7qez550xe2l5jgqqpqe53dcq", address)
+
+	// keccak("alice.elrond") ends in 133 (0x85)
+	address, err = converter.Encode(computeDnsPubKeyForUsername("alice.elrond"))
+	require.Nil(t, err)
+	require.Equal(t, "erd1qqqqqqqqqqqqqpgqf97pgqdy0tstwauxu09kszz020hp5kgqqzzsscqtww", address)
+}
+
+func TestNetworkProvider_ResolveUsername(t *testing.T) {
+	t.Parallel()
+
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	t.Run("with success", func(t *testing.T) {
+		observerFacade.CallPostRestEndPointCalled = func(baseUrl string, path string, payload interface{}, response interface{}) (int, error) {
+			require.Equal(t, "/vm-values/query", path)
+			require.Equal(t, &data.VmValueRequest{
+				Address:  "erd1qqqqqqqqqqqqqpgqf97pgqdy0tstwauxu09kszz020hp5kgqqzzsscqtww",
+				FuncName: "resolve",
+				Args:     []string{hex.EncodeToString([]byte("alice.elrond"))},
+			}, payload)
+
+			response.(*resources.VMQueryApiResponse).Data.Data.ReturnData = [][]byte{testscommon.TestPubKeyAlice}
+			return 200, nil
+		}
+
+		address, err := provider.ResolveUsername(context.Background(), "alice.elrond")
+		require.Nil(t, err)
+		require.Equal(t, testscommon.TestAddressAlice, address)
+	})
+
+	t.Run("with unknown username", func(t *testing.T) {
+		observerFacade.CallPostRestEndPointCalled = func(baseUrl string, path string, payload interface{}, response interface{}) (int, error) {
+			response.(*resources.VMQueryApiResponse).Data.Data.ReturnData = [][]byte{{}}
+			return 200, nil
+		}
+
+		address, err := provider.ResolveUsername(context.Background(), "nobody.elrond")
+		require.ErrorIs(t, err, errUsernameNotFound)
+		require.Empty(t, address)
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		observerFacade.CallPostRestEndPointCalled = func(baseUrl string, path string, payload interface{}, response interface{}) (int, error) {
+			return 500, errors.New("arbitrary error")
+		}
+
+		address, err := provider.ResolveUsername(context.Background(), "alice.elrond")
+		require.ErrorIs(t, err, errCannotResolveUsername)
+		require.ErrorContains(t, err, "arbitrary error")
+		require.Empty(t, address)
+	})
+}
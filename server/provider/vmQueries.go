@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+)
+
+// QueryContract runs a read-only query against a contract. The query is executed on the latest state, unless a block is specified by the options.
+// The observer must be able to run queries against the contract (i.e. the contract must be located in the observed shard).
+func (provider *networkProvider) QueryContract(ctx context.Context, query *data.VmValueRequest, options resources.AccountQueryOptions) (*resources.VMQueryOutput, error) {
+	if provider.isOffline {
+		return nil, errIsOffline
+	}
+
+	url := buildUrlQueryContract(options)
+	response := &resources.VMQueryApiResponse{}
+
+	err := provider.postResourceWithOptions(ctx, url, options, query, response)
+	if err != nil {
+		return nil, newErrCannotQueryContract(query.Address, query.FuncName, err)
+	}
+
+	log.Trace("networkProvider.QueryContract()", "contract", query.Address, "function", query.FuncName, "returnCode", response.Data.Data.ReturnCode)
+
+	return &response.Data.Data, nil
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkProvider_QueryContract(t *testing.T) {
+	t.Parallel()
+
+	observerFacade := testscommon.NewObserverFacadeMock()
+	args := createDefaultArgsNewNetworkProvider()
+	args.ObserverFacade = observerFacade
+
+	provider, err := NewNetworkProvider(args)
+	require.Nil(t, err)
+
+	query := &data.VmValueRequest{
+		Address:  testscommon.TestAddressOfContract,
+		FuncName: "getSum",
+		Args:     []string{"01"},
+	}
+
+	t.Run("on latest state", func(t *testing.T) {
+		observerFacade.CallPostRestEndPointCalled = func(baseUrl string, path string, payload interface{}, response interface{}) (int, error) {
+			require.Equal(t, "/vm-values/query", path)
+			require.Equal(t, query, payload)
+
+			response.(*resources.VMQueryApiResponse).Data.Data = resources.VMQueryOutput{
+				ReturnData: [][]byte{{0x2a}},
+				ReturnCode: "ok",
+			}
+			return 200, nil
+		}
+
+		output, err := provider.QueryContract(context.Background(), query, resources.AccountQueryOptions{})
+		require.Nil(t, err)
+		require.Equal(t, [][]byte{{0x2a}}, output.ReturnData)
+		require.Equal(t, "ok", output.ReturnCode)
+	})
+
+	t.Run("at a given block", func(t *testing.T) {
+		observerFacade.CallPostRestEndPointCalled = func(baseUrl string, path string, payload interface{}, response interface{}) (int, error) {
+			require.Equal(t, "/vm-values/query?blockNonce=42", path)
+
+			response.(*resources.VMQueryApiResponse).Data.Data = resources.VMQueryOutput{
+				ReturnCode:    "user error",
+				ReturnMessage: "not enough funds",
+			}
+			return 200, nil
+		}
+
+		output, err := provider.QueryContract(context.Background(), query, resources.NewAccountQueryOptionsWithBlockNonce(42))
+		require.Nil(t, err)
+		require.Equal(t, "user error", output.ReturnCode)
+		require.Equal(t, "not enough funds", output.ReturnMessage)
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		observerFacade.CallPostRestEndPointCalled = func(baseUrl string, path string, payload interface{}, response interface{}) (int, error) {
+			return 500, errors.New("arbitrary error")
+		}
+
+		output, err := provider.QueryContract(context.Background(), query, resources.AccountQueryOptions{})
+		require.ErrorIs(t, err, errCannotQueryContract)
+		require.ErrorContains(t, err, "arbitrary error")
+		require.Nil(t, output)
+	})
+}
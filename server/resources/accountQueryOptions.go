@@ -18,6 +18,11 @@ func NewAccountQueryOptionsOnFinalBlock() AccountQueryOptions {
 	}
 }
 
+// NewAccountQueryOptionsOnLatestBlock creates an AccountQueryOptions (for the latest block, final or not)
+func NewAccountQueryOptionsOnLatestBlock() AccountQueryOptions {
+	return AccountQueryOptions{}
+}
+
 // NewAccountQueryOptionsWithBlockNonce creates an AccountQueryOptions (for a given block nonce)
 func NewAccountQueryOptionsWithBlockNonce(blockNonce uint64) AccountQueryOptions {
 	return AccountQueryOptions{
@@ -47,3 +47,21 @@ type AccountBalanceOnBlock struct {
 	Nonce            core.OptionalUint64
 	BlockCoordinates BlockCoordinates
 }
+
+// VMQueryApiResponse is an API resource
+type VMQueryApiResponse struct {
+	resourceApiResponse
+	Data VMQueryApiResponsePayload `json:"data"`
+}
+
+// VMQueryApiResponsePayload is an API resource
+type VMQueryApiResponsePayload struct {
+	Data VMQueryOutput `json:"data"`
+}
+
+// VMQueryOutput is an API resource (holds only the fields handled by Rosetta)
+type VMQueryOutput struct {
+	ReturnData    [][]byte `json:"returnData"`
+	ReturnCode    string   `json:"returnCode"`
+	ReturnMessage string   `json:"returnMessage"`
+}
@@ -1,5 +1,7 @@
 package resources
 
+import "encoding/json"
+
 // NetworkConfig is a resource
 type NetworkConfig struct {
 	BlockchainName           string
@@ -12,6 +14,40 @@ type NetworkConfig struct {
 	GasLimitCustomTransfer   uint64
 	ExtraGasLimitGuardedTx   uint64
 	ExtraGasLimitRelayedTxV3 uint64
+	GasLimitEstimationMargin uint64
+}
+
+// NetworkConfigOverrides is an internal resource, flagging the network parameters explicitly provided by the operator
+// (which take precedence over the ones fetched from the observer)
+type NetworkConfigOverrides struct {
+	MinGasPrice              bool
+	MinGasLimit              bool
+	GasPerDataByte           bool
+	GasPriceModifier         bool
+	ExtraGasLimitGuardedTx   bool
+	ExtraGasLimitRelayedTxV3 bool
+}
+
+// NetworkConfigApiResponse is an API resource
+type NetworkConfigApiResponse struct {
+	resourceApiResponse
+	Data NetworkConfigApiResponsePayload `json:"data"`
+}
+
+// NetworkConfigApiResponsePayload is an API resource
+type NetworkConfigApiResponsePayload struct {
+	Config ObserverNetworkConfig `json:"config"`
+}
+
+// ObserverNetworkConfig is an API resource
+type ObserverNetworkConfig struct {
+	ChainID                  string      `json:"erd_chain_id"`
+	MinGasPrice              uint64      `json:"erd_min_gas_price"`
+	MinGasLimit              uint64      `json:"erd_min_gas_limit"`
+	GasPerDataByte           uint64      `json:"erd_gas_per_data_byte"`
+	GasPriceModifier         json.Number `json:"erd_gas_price_modifier"`
+	ExtraGasLimitGuardedTx   uint64      `json:"erd_extra_gas_limit_guarded_tx"`
+	ExtraGasLimitRelayedTxV3 uint64      `json:"erd_extra_gas_limit_relayed_tx"`
 }
 
 // NodeStatusApiResponse is an API resource
@@ -33,8 +69,12 @@ type NodeStatus struct {
 	IsSyncing            int    `json:"erd_is_syncing"`
 	CurrentEpoch         uint32 `json:"erd_epoch_number"`
 	HighestNonce         uint64 `json:"erd_nonce"`
+	ProbableHighestNonce uint64 `json:"erd_probable_highest_nonce"`
 	HighestFinalNonce    uint64 `json:"erd_highest_final_nonce"`
 	LastExecutedNonce    uint64 `json:"erd_last_executed_nonce"`
+	CurrentRound         uint64 `json:"erd_current_round"`
+	SynchronizedRound    uint64 `json:"erd_synchronized_round"`
+	RoundDuration        uint64 `json:"erd_round_duration"` // in milliseconds
 }
 
 // EpochStartApiResponse is an API resource
@@ -61,4 +101,17 @@ type AggregatedNodeStatus struct {
 	Synced                         bool
 	LatestBlock                    BlockSummary
 	OldestBlockWithHistoricalState BlockSummary
+	Sync                           NodeSyncStatus
+}
+
+// NodeSyncStatus is an aggregated resource, describing the synchronization progress of the observer
+type NodeSyncStatus struct {
+	CurrentEpoch         uint32
+	CurrentNonce         uint64
+	ProbableHighestNonce uint64
+	HighestFinalNonce    uint64
+	LastExecutedNonce    uint64
+	SynchronizedRound    uint64
+	NetworkRound         uint64
+	LagInMilliseconds    uint64
 }
@@ -0,0 +1,37 @@
+package resources
+
+import "github.com/multiversx/mx-chain-proxy-go/data"
+
+// TransactionsPoolForSenderApiResponse is an API resource
+type TransactionsPoolForSenderApiResponse struct {
+	resourceApiResponse
+	Data TransactionsPoolForSenderApiResponsePayload `json:"data"`
+}
+
+// TransactionsPoolForSenderApiResponsePayload is an API resource
+type TransactionsPoolForSenderApiResponsePayload struct {
+	TxPool TransactionsPoolForSender `json:"txPool"`
+}
+
+// TransactionsPoolForSender is an API resource
+type TransactionsPoolForSender struct {
+	Transactions []data.WrappedTransaction `json:"transactions"`
+}
+
+// TransactionsPoolApiResponse is an API resource
+type TransactionsPoolApiResponse struct {
+	resourceApiResponse
+	Data TransactionsPoolApiResponsePayload `json:"data"`
+}
+
+// TransactionsPoolApiResponsePayload is an API resource
+type TransactionsPoolApiResponsePayload struct {
+	TxPool data.TransactionsPool `json:"txPool"`
+}
+
+// TransactionInPool is an internal resource (a summary of a transaction in the pool)
+type TransactionInPool struct {
+	Hash     string
+	Sender   string
+	Receiver string
+}
@@ -19,6 +19,7 @@ type BlockSummary struct {
 	PreviousBlockHash string
 	Timestamp         int64
 	TimestampMs       int64
+	Epoch             uint32
 }
 
 // Currency is an internal resource
@@ -0,0 +1,12 @@
+package resources
+
+// TokenProperties is an internal resource, describing an ESDT token (as held by the ESDT system contract)
+type TokenProperties struct {
+	Identifier string
+	Name       string
+	Type       string
+	Owner      string
+	Minted     string
+	Burnt      string
+	Properties map[string]string
+}
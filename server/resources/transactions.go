@@ -0,0 +1,70 @@
+package resources
+
+import (
+	"time"
+
+	"github.com/multiversx/mx-chain-proxy-go/data"
+)
+
+// TransactionCostApiResponse is an API resource
+type TransactionCostApiResponse struct {
+	resourceApiResponse
+	Data TransactionCost `json:"data"`
+}
+
+// TransactionCost is an API resource
+type TransactionCost struct {
+	GasUnits      uint64 `json:"txGasUnits"`
+	ReturnMessage string `json:"returnMessage"`
+}
+
+// TransactionSimulationApiResponse is an API resource
+type TransactionSimulationApiResponse struct {
+	resourceApiResponse
+	Data TransactionSimulationApiResponsePayload `json:"data"`
+}
+
+// TransactionSimulationApiResponsePayload is an API resource
+type TransactionSimulationApiResponsePayload struct {
+	Result TransactionSimulationResults `json:"result"`
+}
+
+// TransactionSimulationResults is an API resource
+type TransactionSimulationResults struct {
+	data.TransactionSimulationResults
+	VMOutput *TransactionSimulationVMOutput `json:"vmOutput,omitempty"`
+}
+
+// TransactionSimulationVMOutput is an API resource (a subset of the VM output, as returned by the observer)
+type TransactionSimulationVMOutput struct {
+	ReturnCode    string `json:"returnCode"`
+	ReturnMessage string `json:"returnMessage"`
+	GasRemaining  uint64 `json:"gasRemaining"`
+}
+
+// SubmissionStatus is the status of a broadcasted transaction, as seen by the submissions tracker
+type SubmissionStatus string
+
+const (
+	// SubmissionStatusPending means the transaction is in the pool (or in a block that isn't final yet)
+	SubmissionStatusPending SubmissionStatus = "pending"
+	// SubmissionStatusLanded means the transaction is included in a final block
+	SubmissionStatusLanded SubmissionStatus = "landed"
+	// SubmissionStatusDropped means the transaction has been dropped from the pool (e.g. its nonce has been consumed by another transaction)
+	SubmissionStatusDropped SubmissionStatus = "dropped"
+)
+
+// SubmittedTransaction is an internal resource (a transaction tracked by the submissions tracker)
+type SubmittedTransaction struct {
+	Hash              string
+	Sender            string
+	Nonce             uint64
+	SignedTransaction *data.Transaction
+	Status            SubmissionStatus
+	ExecutionStatus   string
+	BlockNonce        uint64
+	BlockHash         string
+	DropReason        string
+	SubmittedAt       time.Time
+	UpdatedAt         time.Time
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var usernamePattern = regexp.MustCompile(`^[a-z0-9]+\.[a-z0-9]+$`)
+
+// resolveAddress normalizes an account identifier to a (canonical) bech32 address. Accepted forms:
+//   - bech32 address, e.g. "erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th"
+//   - hex-encoded public key, e.g. "0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1" (optionally prefixed by "0x")
+//   - username (herotag), e.g. "alice.elrond" (requires a connection to the network)
+func (extension *networkProviderExtension) resolveAddress(ctx context.Context, identifier string) (string, error) {
+	identifier = strings.TrimSpace(identifier)
+	if len(identifier) == 0 {
+		return "", errEmptyAccountIdentifier
+	}
+
+	pubKey, err := extension.provider.ConvertAddressToPubKey(identifier)
+	if err == nil {
+		return extension.provider.ConvertPubKeyToAddress(pubKey), nil
+	}
+
+	pubKey, ok := decodeHexPubKey(identifier)
+	if ok {
+		return extension.provider.ConvertPubKeyToAddress(pubKey), nil
+	}
+
+	username := strings.ToLower(identifier)
+	if usernamePattern.MatchString(username) {
+		if extension.provider.IsOffline() {
+			return "", fmt.Errorf("%w: usernames cannot be resolved in offline mode", errCannotResolveAccountIdentifier)
+		}
+
+		address, err := extension.provider.ResolveUsername(ctx, username)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", errCannotResolveAccountIdentifier, err)
+		}
+
+		return address, nil
+	}
+
+	return "", fmt.Errorf("%w: %s", errCannotResolveAccountIdentifier, identifier)
+}
+
+func decodeHexPubKey(identifier string) ([]byte, bool) {
+	identifier = strings.TrimPrefix(strings.ToLower(identifier), "0x")
+	if len(identifier) != hex.EncodedLen(pubKeyLength) {
+		return nil, false
+	}
+
+	pubKey, err := hex.DecodeString(identifier)
+	if err != nil {
+		return nil, false
+	}
+
+	return pubKey, true
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkProviderExtension_ResolveAddress(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockUsernames["alice.elrond"] = testscommon.TestAddressAlice
+	extension := newNetworkProviderExtension(networkProvider)
+
+	t.Run("with bech32 address", func(t *testing.T) {
+		address, err := extension.resolveAddress(context.Background(), testscommon.TestAddressAlice)
+		require.Nil(t, err)
+		require.Equal(t, testscommon.TestAddressAlice, address)
+
+		address, err = extension.resolveAddress(context.Background(), " "+testscommon.TestAddressAlice+" ")
+		require.Nil(t, err)
+		require.Equal(t, testscommon.TestAddressAlice, address)
+	})
+
+	t.Run("with hex-encoded public key", func(t *testing.T) {
+		address, err := extension.resolveAddress(context.Background(), hex.EncodeToString(testscommon.TestPubKeyAlice))
+		require.Nil(t, err)
+		require.Equal(t, testscommon.TestAddressAlice, address)
+
+		address, err = extension.resolveAddress(context.Background(), "0x"+strings.ToUpper(hex.EncodeToString(testscommon.TestPubKeyAlice)))
+		require.Nil(t, err)
+		require.Equal(t, testscommon.TestAddressAlice, address)
+	})
+
+	t.Run("with username", func(t *testing.T) {
+		address, err := extension.resolveAddress(context.Background(), "alice.elrond")
+		require.Nil(t, err)
+		require.Equal(t, testscommon.TestAddressAlice, address)
+
+		address, err = extension.resolveAddress(context.Background(), "bob.elrond")
+		require.ErrorIs(t, err, errCannotResolveAccountIdentifier)
+		require.Empty(t, address)
+	})
+
+	t.Run("with username, in offline mode", func(t *testing.T) {
+		offlineNetworkProvider := testscommon.NewNetworkProviderMock()
+		offlineNetworkProvider.MockIsOffline = true
+		offlineNetworkProvider.MockUsernames["alice.elrond"] = testscommon.TestAddressAlice
+
+		address, err := newNetworkProviderExtension(offlineNetworkProvider).resolveAddress(context.Background(), "alice.elrond")
+		require.ErrorIs(t, err, errCannotResolveAccountIdentifier)
+		require.ErrorContains(t, err, "offline mode")
+		require.Empty(t, address)
+	})
+
+	t.Run("with bad identifiers", func(t *testing.T) {
+		_, err := extension.resolveAddress(context.Background(), "")
+		require.ErrorIs(t, err, errEmptyAccountIdentifier)
+
+		_, err = extension.resolveAddress(context.Background(), "alice")
+		require.ErrorIs(t, err, errCannotResolveAccountIdentifier)
+
+		_, err = extension.resolveAddress(context.Background(), "0139472eff")
+		require.ErrorIs(t, err, errCannotResolveAccountIdentifier)
+
+		_, err = extension.resolveAddress(context.Background(), "erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6tx")
+		require.ErrorIs(t, err, errCannotResolveAccountIdentifier)
+	})
+}
+
+func TestConstructionService_ConstructionPreprocessResolvesAccountIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockUsernames["bob.elrond"] = testscommon.TestAddressBob
+	service := NewConstructionService(networkProvider)
+
+	response, errTyped := service.ConstructionPreprocess(context.Background(),
+		&types.ConstructionPreprocessRequest{
+			Metadata: objectsMap{
+				"sender":         hex.EncodeToString(testscommon.TestPubKeyAlice),
+				"receiver":       "bob.elrond",
+				"amount":         "1234",
+				"currencySymbol": "XeGLD",
+			},
+		},
+	)
+	require.Nil(t, errTyped)
+	require.Equal(t, testscommon.TestAddressAlice, response.Options["sender"])
+	require.Equal(t, testscommon.TestAddressBob, response.Options["receiver"])
+
+	response, errTyped = service.ConstructionPreprocess(context.Background(),
+		&types.ConstructionPreprocessRequest{
+			Metadata: objectsMap{
+				"sender":         testscommon.TestAddressAlice,
+				"receiver":       "carol.elrond",
+				"amount":         "1234",
+				"currencySymbol": "XeGLD",
+			},
+		},
+	)
+	require.Nil(t, response)
+	require.Equal(t, int32(ErrInvalidAccountAddress), errTyped.Code)
+}
@@ -25,11 +25,11 @@ func NewAccountService(provider NetworkProvider) server.AccountAPIServicer {
 }
 
 // AccountBalance implements the /account/balance endpoint.
-func (service *accountService) AccountBalance(_ context.Context, request *types.AccountBalanceRequest) (*types.AccountBalanceResponse, *types.Error) {
+func (service *accountService) AccountBalance(ctx context.Context, request *types.AccountBalanceRequest) (*types.AccountBalanceResponse, *types.Error) {
 	stopWatch := core.NewStopWatch()
 	stopWatch.Start("account")
 
-	response, err := service.doGetAccountBalance(request)
+	response, err := service.doGetAccountBalance(ctx, request)
 	if err != nil {
 		return nil, err
 	}
@@ -48,17 +48,21 @@ func (service *accountService) AccountBalance(_ context.Context, request *types.
 	return response, nil
 }
 
-func (service *accountService) doGetAccountBalance(request *types.AccountBalanceRequest) (*types.AccountBalanceResponse, *types.Error) {
+func (service *accountService) doGetAccountBalance(ctx context.Context, request *types.AccountBalanceRequest) (*types.AccountBalanceResponse, *types.Error) {
 	options, err := blockIdentifierToAccountQueryOptions(request.BlockIdentifier)
 	if err != nil {
 		return nil, service.errFactory.newErrWithOriginal(ErrUnableToGetAccount, err)
 	}
 
-	address := request.AccountIdentifier.Address
-	if address == "" {
+	if request.AccountIdentifier == nil || request.AccountIdentifier.Address == "" {
 		return nil, service.errFactory.newErr(ErrInvalidAccountAddress)
 	}
 
+	address, err := service.extension.resolveAddress(ctx, request.AccountIdentifier.Address)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrInvalidAccountAddress, err)
+	}
+
 	// The specification states:
 	// > If the currencies field is populated, only balances for the specified currencies will be returned.
 	// > If not populated, all available balances will be returned.
@@ -78,14 +82,17 @@ func (service *accountService) doGetAccountBalance(request *types.AccountBalance
 		return nil, service.errFactory.newErr(ErrNotImplemented)
 	}
 
-	accountBalanceOnBlock, err := service.provider.GetAccountBalance(address, currencySymbol, options)
+	accountBalanceOnBlock, err := service.provider.GetAccountBalance(ctx, address, currencySymbol, options)
 	if err != nil {
 		return nil, service.errFactory.newErrWithOriginal(ErrUnableToGetAccount, err)
 	}
 
 	blockIdentifier := accountBlockCoordinatesToIdentifier(accountBalanceOnBlock.BlockCoordinates)
 	amount := service.extension.valueToAmount(accountBalanceOnBlock.Balance, currencySymbol)
-	metadata := objectsMap{}
+	metadata := objectsMap{
+		// The canonical form of the account identifier (bech32), useful when the request holds a public key or a username.
+		"address": address,
+	}
 
 	// Currently, "nonce" is present only for native currency requests (for simplicity).
 	if accountBalanceOnBlock.Nonce.HasValue {
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"encoding/hex"
 	"testing"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
@@ -26,7 +27,7 @@ func TestAccountService_AccountBalance(t *testing.T) {
 
 	t.Run("with no specified currency, when account does not exist", func(t *testing.T) {
 		request := &types.AccountBalanceRequest{
-			AccountIdentifier: &types.AccountIdentifier{Address: "alice"},
+			AccountIdentifier: &types.AccountIdentifier{Address: testscommon.TestAddressAlice},
 		}
 
 		response, err := service.AccountBalance(context.Background(), request)
@@ -36,10 +37,10 @@ func TestAccountService_AccountBalance(t *testing.T) {
 
 	t.Run("with no specified currency, when account exists", func(t *testing.T) {
 		request := &types.AccountBalanceRequest{
-			AccountIdentifier: &types.AccountIdentifier{Address: "alice"},
+			AccountIdentifier: &types.AccountIdentifier{Address: testscommon.TestAddressAlice},
 		}
 
-		networkProvider.MockAccountsNativeBalances["alice"] = &resources.AccountBalanceOnBlock{
+		networkProvider.MockAccountsNativeBalances[testscommon.TestAddressAlice] = &resources.AccountBalanceOnBlock{
 			Nonce:   core.OptionalUint64{Value: 7, HasValue: true},
 			Balance: "100",
 		}
@@ -54,9 +55,50 @@ func TestAccountService_AccountBalance(t *testing.T) {
 		require.Equal(t, "abba", response.BlockIdentifier.Hash)
 	})
 
+	t.Run("with account identifier given as public key or username", func(t *testing.T) {
+		networkProvider.MockAccountsNativeBalances[testscommon.TestAddressAlice] = &resources.AccountBalanceOnBlock{
+			Nonce:   core.OptionalUint64{Value: 7, HasValue: true},
+			Balance: "100",
+		}
+		networkProvider.MockUsernames["alice.elrond"] = testscommon.TestAddressAlice
+
+		identifiers := []string{
+			testscommon.TestAddressAlice,
+			hex.EncodeToString(testscommon.TestPubKeyAlice),
+			"0x" + hex.EncodeToString(testscommon.TestPubKeyAlice),
+			"alice.elrond",
+			"Alice.Elrond",
+		}
+
+		for _, identifier := range identifiers {
+			request := &types.AccountBalanceRequest{
+				AccountIdentifier: &types.AccountIdentifier{Address: identifier},
+			}
+
+			response, err := service.AccountBalance(context.Background(), request)
+			require.Nil(t, err)
+			require.Equal(t, "100", response.Balances[0].Value)
+			require.Equal(t, testscommon.TestAddressAlice, response.Metadata["address"])
+		}
+	})
+
+	t.Run("with invalid account identifier", func(t *testing.T) {
+		identifiers := []string{"alice", "bob.elrond", "0139472eff"}
+
+		for _, identifier := range identifiers {
+			request := &types.AccountBalanceRequest{
+				AccountIdentifier: &types.AccountIdentifier{Address: identifier},
+			}
+
+			response, err := service.AccountBalance(context.Background(), request)
+			require.Nil(t, response)
+			require.Equal(t, ErrInvalidAccountAddress, errCode(err.Code))
+		}
+	})
+
 	t.Run("with native currency (specified)", func(t *testing.T) {
 		request := &types.AccountBalanceRequest{
-			AccountIdentifier: &types.AccountIdentifier{Address: "alice"},
+			AccountIdentifier: &types.AccountIdentifier{Address: testscommon.TestAddressAlice},
 			Currencies: []*types.Currency{
 				{
 					Symbol:   "XeGLD",
@@ -65,7 +107,7 @@ func TestAccountService_AccountBalance(t *testing.T) {
 			},
 		}
 
-		networkProvider.MockAccountsNativeBalances["alice"] = &resources.AccountBalanceOnBlock{
+		networkProvider.MockAccountsNativeBalances[testscommon.TestAddressAlice] = &resources.AccountBalanceOnBlock{
 			Nonce:   core.OptionalUint64{Value: 7, HasValue: true},
 			Balance: "1000",
 		}
@@ -81,7 +123,7 @@ func TestAccountService_AccountBalance(t *testing.T) {
 
 	t.Run("with one custom currency (fungible, specified)", func(t *testing.T) {
 		request := &types.AccountBalanceRequest{
-			AccountIdentifier: &types.AccountIdentifier{Address: "alice"},
+			AccountIdentifier: &types.AccountIdentifier{Address: testscommon.TestAddressAlice},
 			Currencies: []*types.Currency{
 				{
 					Symbol:   "FOO-abcdef",
@@ -90,7 +132,7 @@ func TestAccountService_AccountBalance(t *testing.T) {
 			},
 		}
 
-		networkProvider.MockAccountsCustomBalances["alice_FOO-abcdef"] = &resources.AccountBalanceOnBlock{
+		networkProvider.MockAccountsCustomBalances[testscommon.TestAddressAlice+"_FOO-abcdef"] = &resources.AccountBalanceOnBlock{
 			Balance: "500",
 		}
 		networkProvider.MockNextAccountBlockCoordinates.Nonce = 42
@@ -104,7 +146,7 @@ func TestAccountService_AccountBalance(t *testing.T) {
 
 	t.Run("with one custom currency (non-fungible, specified)", func(t *testing.T) {
 		request := &types.AccountBalanceRequest{
-			AccountIdentifier: &types.AccountIdentifier{Address: "alice"},
+			AccountIdentifier: &types.AccountIdentifier{Address: testscommon.TestAddressAlice},
 			Currencies: []*types.Currency{
 				{
 					Symbol: "FOO-abcdef-0a",
@@ -112,7 +154,7 @@ func TestAccountService_AccountBalance(t *testing.T) {
 			},
 		}
 
-		networkProvider.MockAccountsCustomBalances["alice_FOO-abcdef-0a"] = &resources.AccountBalanceOnBlock{
+		networkProvider.MockAccountsCustomBalances[testscommon.TestAddressAlice+"_FOO-abcdef-0a"] = &resources.AccountBalanceOnBlock{
 			Balance: "1",
 		}
 		networkProvider.MockNextAccountBlockCoordinates.Nonce = 42
@@ -126,7 +168,7 @@ func TestAccountService_AccountBalance(t *testing.T) {
 
 	t.Run("with more than 1 (custom or not) currencies", func(t *testing.T) {
 		request := &types.AccountBalanceRequest{
-			AccountIdentifier: &types.AccountIdentifier{Address: "alice"},
+			AccountIdentifier: &types.AccountIdentifier{Address: testscommon.TestAddressAlice},
 			Currencies: []*types.Currency{
 				{
 					Symbol:   "FOO-abcdef",
@@ -139,10 +181,10 @@ func TestAccountService_AccountBalance(t *testing.T) {
 			},
 		}
 
-		networkProvider.MockAccountsCustomBalances["alice_FOO-abcdef"] = &resources.AccountBalanceOnBlock{
+		networkProvider.MockAccountsCustomBalances[testscommon.TestAddressAlice+"_FOO-abcdef"] = &resources.AccountBalanceOnBlock{
 			Balance: "500",
 		}
-		networkProvider.MockAccountsCustomBalances["alice_BAR-abcdef"] = &resources.AccountBalanceOnBlock{
+		networkProvider.MockAccountsCustomBalances[testscommon.TestAddressAlice+"_BAR-abcdef"] = &resources.AccountBalanceOnBlock{
 			Balance: "700",
 		}
 		networkProvider.MockNextAccountBlockCoordinates.Nonce = 42
@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+// ArgsReconcileBalances holds the arguments of a balances reconciliation
+type ArgsReconcileBalances struct {
+	FromBlock uint64
+	ToBlock   uint64
+	Addresses []string
+	// Symbols of the currencies to reconcile (if empty, the native currency and all the custom currencies are reconciled)
+	Currencies []string
+}
+
+// BalanceMismatch describes a difference between the balance computed from the operations (of /block) and the one reported by /account/balance
+type BalanceMismatch struct {
+	Address         string                 `json:"address"`
+	Currency        string                 `json:"currency"`
+	Block           *types.BlockIdentifier `json:"block"`
+	PreviousBalance string                 `json:"previousBalance"`
+	ExpectedBalance string                 `json:"expectedBalance"`
+	ActualBalance   string                 `json:"actualBalance"`
+	// The transactions (of the block) whose operations touched the balance
+	Transactions []string `json:"transactions"`
+}
+
+// ReconciliationReport holds the outcome of a balances reconciliation
+type ReconciliationReport struct {
+	NumBlocks  int                `json:"numBlocks"`
+	NumChecks  int                `json:"numChecks"`
+	Mismatches []*BalanceMismatch `json:"mismatches"`
+}
+
+type balanceKey struct {
+	address  string
+	currency string
+}
+
+type balanceChange struct {
+	delta        *big.Int
+	transactions []string
+}
+
+type balancesReconciler struct {
+	provider           NetworkProvider
+	blockService       server.BlockAPIServicer
+	accountService     server.AccountAPIServicer
+	successfulStatuses map[string]bool
+}
+
+// NewBalancesReconciler will create a new instance of balancesReconciler,
+// which checks the operations returned by /block against the balances returned by /account/balance (both services are used in process).
+func NewBalancesReconciler(provider NetworkProvider) BalancesReconciler {
+	successfulStatuses := make(map[string]bool)
+	for _, status := range supportedOperationStatuses {
+		successfulStatuses[status.Status] = status.Successful
+	}
+
+	return &balancesReconciler{
+		provider:           provider,
+		blockService:       NewBlockService(provider),
+		accountService:     NewAccountService(provider),
+		successfulStatuses: successfulStatuses,
+	}
+}
+
+// Reconcile walks the blocks in the given range (inclusive) and accumulates the operations of the given addresses, per currency.
+// At each block which touches an (address, currency) pair, the accumulated balance is compared to the actual one (at that block).
+// After a mismatch, the actual balance is considered going forward (so that a single faulty transaction isn't reported over and over again).
+func (reconciler *balancesReconciler) Reconcile(ctx context.Context, args ArgsReconcileBalances) (*ReconciliationReport, error) {
+	if args.FromBlock > args.ToBlock {
+		return nil, fmt.Errorf("%w: from = %d, to = %d", errBadBlocksRange, args.FromBlock, args.ToBlock)
+	}
+
+	// The balances before the genesis block cannot be fetched.
+	genesisNonce := reconciler.provider.GetGenesisBlockSummary().Nonce
+	if args.FromBlock <= genesisNonce {
+		return nil, fmt.Errorf("%w: the genesis block (%d) cannot be reconciled", errBadBlocksRange, genesisNonce)
+	}
+
+	if len(args.Addresses) == 0 {
+		return nil, errNoAddressesToReconcile
+	}
+
+	addresses := make(map[string]struct{})
+	for _, address := range args.Addresses {
+		addresses[address] = struct{}{}
+	}
+
+	currencies := make(map[string]struct{})
+	for _, symbol := range reconciler.decideCurrencies(args.Currencies) {
+		currencies[symbol] = struct{}{}
+	}
+
+	report := &ReconciliationReport{
+		Mismatches: make([]*BalanceMismatch, 0),
+	}
+
+	knownBalances := make(map[balanceKey]*big.Int)
+
+	for nonce := args.FromBlock; nonce <= args.ToBlock; nonce++ {
+		err := ctx.Err()
+		if err != nil {
+			return report, err
+		}
+
+		block, changes, err := reconciler.getBalanceChangesInBlock(ctx, nonce, addresses, currencies)
+		if err != nil {
+			return report, err
+		}
+
+		for _, key := range sortBalanceKeys(changes) {
+			change := changes[key]
+
+			previousBalance, ok := knownBalances[key]
+			if !ok {
+				previousBalance, err = reconciler.getBalance(ctx, key, nonce-1)
+				if err != nil {
+					return report, err
+				}
+			}
+
+			actualBalance, err := reconciler.getBalance(ctx, key, nonce)
+			if err != nil {
+				return report, err
+			}
+
+			expectedBalance := new(big.Int).Add(previousBalance, change.delta)
+			report.NumChecks++
+
+			if expectedBalance.Cmp(actualBalance) != 0 {
+				mismatch := &BalanceMismatch{
+					Address:         key.address,
+					Currency:        key.currency,
+					Block:           block,
+					PreviousBalance: previousBalance.String(),
+					ExpectedBalance: expectedBalance.String(),
+					ActualBalance:   actualBalance.String(),
+					Transactions:    change.transactions,
+				}
+
+				log.Warn("balancesReconciler.Reconcile(): balance mismatch",
+					"address", mismatch.Address,
+					"currency", mismatch.Currency,
+					"block", nonce,
+					"expected", mismatch.ExpectedBalance,
+					"actual", mismatch.ActualBalance,
+					"transactions", mismatch.Transactions,
+				)
+
+				report.Mismatches = append(report.Mismatches, mismatch)
+			}
+
+			knownBalances[key] = actualBalance
+		}
+
+		report.NumBlocks++
+
+		if report.NumBlocks%numBlocksBetweenReconciliationProgressLogs == 0 {
+			log.Info("balancesReconciler.Reconcile()", "block", nonce, "numChecks", report.NumChecks, "numMismatches", len(report.Mismatches))
+		}
+	}
+
+	return report, nil
+}
+
+func (reconciler *balancesReconciler) decideCurrencies(symbols []string) []string {
+	if len(symbols) > 0 {
+		return symbols
+	}
+
+	symbols = []string{reconciler.provider.GetNativeCurrency().Symbol}
+	for _, currency := range reconciler.provider.GetCustomCurrencies() {
+		symbols = append(symbols, currency.Symbol)
+	}
+
+	return symbols
+}
+
+// getBalanceChangesInBlock accumulates the (successful) operations of a block, for the tracked addresses and currencies
+func (reconciler *balancesReconciler) getBalanceChangesInBlock(
+	ctx context.Context,
+	nonce uint64,
+	addresses map[string]struct{},
+	currencies map[string]struct{},
+) (*types.BlockIdentifier, map[balanceKey]*balanceChange, error) {
+	index := int64(nonce)
+
+	response, rosettaErr := reconciler.blockService.Block(ctx, &types.BlockRequest{
+		BlockIdentifier: &types.PartialBlockIdentifier{Index: &index},
+	})
+	if rosettaErr != nil {
+		return nil, nil, newErrReconciliationStep("block", nonce, rosettaErr)
+	}
+
+	changes := make(map[balanceKey]*balanceChange)
+
+	for _, tx := range response.Block.Transactions {
+		for _, operation := range tx.Operations {
+			if operation.Account == nil || operation.Amount == nil || operation.Amount.Currency == nil {
+				continue
+			}
+			if operation.Status == nil || !reconciler.successfulStatuses[*operation.Status] {
+				continue
+			}
+
+			key := balanceKey{address: operation.Account.Address, currency: operation.Amount.Currency.Symbol}
+
+			_, isTrackedAddress := addresses[key.address]
+			_, isTrackedCurrency := currencies[key.currency]
+			if !isTrackedAddress || !isTrackedCurrency {
+				continue
+			}
+
+			value, ok := new(big.Int).SetString(operation.Amount.Value, 10)
+			if !ok {
+				return nil, nil, fmt.Errorf("%w: %s (transaction %s)", errCannotParseAmount, operation.Amount.Value, tx.TransactionIdentifier.Hash)
+			}
+
+			change, ok := changes[key]
+			if !ok {
+				change = &balanceChange{delta: big.NewInt(0), transactions: make([]string, 0)}
+				changes[key] = change
+			}
+
+			change.delta.Add(change.delta, value)
+
+			hash := tx.TransactionIdentifier.Hash
+			if len(change.transactions) == 0 || change.transactions[len(change.transactions)-1] != hash {
+				change.transactions = append(change.transactions, hash)
+			}
+		}
+	}
+
+	return response.Block.BlockIdentifier, changes, nil
+}
+
+func (reconciler *balancesReconciler) getBalance(ctx context.Context, key balanceKey, nonce uint64) (*big.Int, error) {
+	index := int64(nonce)
+
+	response, rosettaErr := reconciler.accountService.AccountBalance(ctx, &types.AccountBalanceRequest{
+		AccountIdentifier: addressToAccountIdentifier(key.address),
+		BlockIdentifier:   &types.PartialBlockIdentifier{Index: &index},
+		Currencies:        []*types.Currency{{Symbol: key.currency}},
+	})
+	if rosettaErr != nil {
+		return nil, newErrReconciliationStep("account balance", nonce, rosettaErr)
+	}
+
+	if response.BlockIdentifier.Index != index {
+		return nil, fmt.Errorf("%w: requested = %d, actual = %d", errUnexpectedBalanceBlock, index, response.BlockIdentifier.Index)
+	}
+
+	value, ok := new(big.Int).SetString(response.Balances[0].Value, 10)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errCannotParseAmount, response.Balances[0].Value)
+	}
+
+	return value, nil
+}
+
+func sortBalanceKeys(changes map[balanceKey]*balanceChange) []balanceKey {
+	keys := make([]balanceKey, 0, len(changes))
+	for key := range changes {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].address != keys[j].address {
+			return keys[i].address < keys[j].address
+		}
+
+		return keys[i].currency < keys[j].currency
+	})
+
+	return keys
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+// networkProviderWithHistoricalBalances overrides GetAccountBalance, so that (native) balances are looked up by block nonce
+type networkProviderWithHistoricalBalances struct {
+	NetworkProvider
+	balances map[string]string
+}
+
+func (provider *networkProviderWithHistoricalBalances) setBalance(address string, nonce uint64, balance string) {
+	provider.balances[fmt.Sprintf("%s_%d", address, nonce)] = balance
+}
+
+func (provider *networkProviderWithHistoricalBalances) GetAccountBalance(address string, _ string, options resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error) {
+	nonce := options.BlockNonce.Value
+
+	balance, ok := provider.balances[fmt.Sprintf("%s_%d", address, nonce)]
+	if !ok {
+		return nil, fmt.Errorf("balance of %s not found at block %d", address, nonce)
+	}
+
+	return &resources.AccountBalanceOnBlock{
+		Balance:          balance,
+		BlockCoordinates: resources.BlockCoordinates{Nonce: nonce, Hash: fmt.Sprintf("%04d", nonce)},
+	}, nil
+}
+
+func createProviderForReconciliation() *networkProviderWithHistoricalBalances {
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockNumShards = 1
+	setupBlocksForSearch(networkProvider.MockBlocksByNonce, networkProvider.MockNodeStatus)
+
+	provider := &networkProviderWithHistoricalBalances{
+		NetworkProvider: networkProvider,
+		balances:        make(map[string]string),
+	}
+
+	// Block 1: Alice sends 1 to Bob (fee = 50000000000000, paid by Alice).
+	// Block 3: Bob sends 1 to Alice (fee = 50000000000000, paid by Bob).
+	provider.setBalance(testscommon.TestAddressAlice, 0, "1000000000000000")
+	provider.setBalance(testscommon.TestAddressAlice, 1, "949999999999999")
+	provider.setBalance(testscommon.TestAddressAlice, 2, "949999999999999")
+	provider.setBalance(testscommon.TestAddressAlice, 3, "950000000000000")
+	provider.setBalance(testscommon.TestAddressBob, 0, "100000000000000")
+	provider.setBalance(testscommon.TestAddressBob, 1, "100000000000001")
+	provider.setBalance(testscommon.TestAddressBob, 2, "100000000000001")
+	provider.setBalance(testscommon.TestAddressBob, 3, "50000000000000")
+
+	return provider
+}
+
+func TestBalancesReconciler_Reconcile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("without mismatches", func(t *testing.T) {
+		t.Parallel()
+
+		provider := createProviderForReconciliation()
+		reconciler := NewBalancesReconciler(provider)
+
+		report, err := reconciler.Reconcile(context.Background(), ArgsReconcileBalances{
+			FromBlock: 1,
+			ToBlock:   3,
+			Addresses: []string{testscommon.TestAddressAlice, testscommon.TestAddressBob},
+		})
+		require.Nil(t, err)
+		require.Equal(t, 3, report.NumBlocks)
+		require.Equal(t, 4, report.NumChecks)
+		require.Len(t, report.Mismatches, 0)
+	})
+
+	t.Run("with mismatches", func(t *testing.T) {
+		t.Parallel()
+
+		provider := createProviderForReconciliation()
+		provider.setBalance(testscommon.TestAddressBob, 3, "50000000000001")
+		reconciler := NewBalancesReconciler(provider)
+
+		report, err := reconciler.Reconcile(context.Background(), ArgsReconcileBalances{
+			FromBlock: 1,
+			ToBlock:   3,
+			Addresses: []string{testscommon.TestAddressAlice, testscommon.TestAddressBob},
+		})
+		require.Nil(t, err)
+		require.Equal(t, 4, report.NumChecks)
+		require.Equal(t, []*BalanceMismatch{
+			{
+				Address:         testscommon.TestAddressBob,
+				Currency:        "XeGLD",
+				Block:           &types.BlockIdentifier{Index: 3, Hash: "0003"},
+				PreviousBalance: "100000000000001",
+				ExpectedBalance: "50000000000000",
+				ActualBalance:   "50000000000001",
+				Transactions:    []string{"bbbb"},
+			},
+		}, report.Mismatches)
+	})
+
+	t.Run("with a single address, starting later", func(t *testing.T) {
+		t.Parallel()
+
+		provider := createProviderForReconciliation()
+		reconciler := NewBalancesReconciler(provider)
+
+		report, err := reconciler.Reconcile(context.Background(), ArgsReconcileBalances{
+			FromBlock:  2,
+			ToBlock:    3,
+			Addresses:  []string{testscommon.TestAddressAlice},
+			Currencies: []string{"XeGLD"},
+		})
+		require.Nil(t, err)
+		require.Equal(t, 2, report.NumBlocks)
+		require.Equal(t, 1, report.NumChecks)
+		require.Len(t, report.Mismatches, 0)
+	})
+
+	t.Run("with untracked currency", func(t *testing.T) {
+		t.Parallel()
+
+		provider := createProviderForReconciliation()
+		reconciler := NewBalancesReconciler(provider)
+
+		report, err := reconciler.Reconcile(context.Background(), ArgsReconcileBalances{
+			FromBlock:  1,
+			ToBlock:    3,
+			Addresses:  []string{testscommon.TestAddressBob},
+			Currencies: []string{"ROSETTA-3a2edf"},
+		})
+		require.Nil(t, err)
+		require.Equal(t, 0, report.NumChecks)
+	})
+
+	t.Run("with missing balance", func(t *testing.T) {
+		t.Parallel()
+
+		provider := createProviderForReconciliation()
+		delete(provider.balances, fmt.Sprintf("%s_%d", testscommon.TestAddressAlice, 3))
+		reconciler := NewBalancesReconciler(provider)
+
+		report, err := reconciler.Reconcile(context.Background(), ArgsReconcileBalances{
+			FromBlock: 1,
+			ToBlock:   3,
+			Addresses: []string{testscommon.TestAddressAlice},
+		})
+		require.ErrorIs(t, err, errReconciliationStepFailed)
+		require.Equal(t, 2, report.NumBlocks)
+	})
+
+	t.Run("with bad arguments", func(t *testing.T) {
+		t.Parallel()
+
+		provider := createProviderForReconciliation()
+		reconciler := NewBalancesReconciler(provider)
+
+		_, err := reconciler.Reconcile(context.Background(), ArgsReconcileBalances{FromBlock: 3, ToBlock: 1, Addresses: []string{testscommon.TestAddressAlice}})
+		require.ErrorIs(t, err, errBadBlocksRange)
+
+		_, err = reconciler.Reconcile(context.Background(), ArgsReconcileBalances{FromBlock: 0, ToBlock: 1, Addresses: []string{testscommon.TestAddressAlice}})
+		require.ErrorIs(t, err, errBadBlocksRange)
+
+		_, err = reconciler.Reconcile(context.Background(), ArgsReconcileBalances{FromBlock: 1, ToBlock: 1})
+		require.ErrorIs(t, err, errNoAddressesToReconcile)
+	})
+
+	t.Run("with cancelled context", func(t *testing.T) {
+		t.Parallel()
+
+		provider := createProviderForReconciliation()
+		reconciler := NewBalancesReconciler(provider)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		report, err := reconciler.Reconcile(ctx, ArgsReconcileBalances{FromBlock: 1, ToBlock: 3, Addresses: []string{testscommon.TestAddressAlice}})
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, 0, report.NumBlocks)
+	})
+}
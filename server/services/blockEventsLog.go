@@ -0,0 +1,131 @@
+package services
+
+import (
+	"sync"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+)
+
+// blockEventsLog holds (in memory) the block events reported so far, each with a sequence number.
+// It also holds the chain of reported blocks (its most recent part), so that reorganizations can be detected and reported.
+type blockEventsLog struct {
+	mutex                sync.RWMutex
+	events               []*types.BlockEvent
+	nextSequence         int64
+	reportedBlocks       []resources.BlockSummary
+	maxNumEvents         int
+	maxNumReportedBlocks int
+}
+
+func newBlockEventsLog(firstSequence int64, maxNumEvents int, maxNumReportedBlocks int) *blockEventsLog {
+	return &blockEventsLog{
+		events:               make([]*types.BlockEvent, 0),
+		nextSequence:         firstSequence,
+		reportedBlocks:       make([]resources.BlockSummary, 0),
+		maxNumEvents:         maxNumEvents,
+		maxNumReportedBlocks: maxNumReportedBlocks,
+	}
+}
+
+// addBlock records a "block_added" event, and the block becomes the tip of the reported chain
+func (eventsLog *blockEventsLog) addBlock(block resources.BlockSummary) {
+	eventsLog.mutex.Lock()
+	defer eventsLog.mutex.Unlock()
+
+	eventsLog.appendEvent(block, types.ADDED)
+	eventsLog.reportedBlocks = append(eventsLog.reportedBlocks, block)
+
+	if len(eventsLog.reportedBlocks) > eventsLog.maxNumReportedBlocks {
+		eventsLog.reportedBlocks = eventsLog.reportedBlocks[len(eventsLog.reportedBlocks)-eventsLog.maxNumReportedBlocks:]
+	}
+}
+
+// removeTipBlock records a "block_removed" event for the tip of the reported chain, which is then dropped
+func (eventsLog *blockEventsLog) removeTipBlock() {
+	eventsLog.mutex.Lock()
+	defer eventsLog.mutex.Unlock()
+
+	numReportedBlocks := len(eventsLog.reportedBlocks)
+	if numReportedBlocks == 0 {
+		return
+	}
+
+	tip := eventsLog.reportedBlocks[numReportedBlocks-1]
+	eventsLog.appendEvent(tip, types.REMOVED)
+	eventsLog.reportedBlocks = eventsLog.reportedBlocks[:numReportedBlocks-1]
+}
+
+func (eventsLog *blockEventsLog) appendEvent(block resources.BlockSummary, eventType types.BlockEventType) {
+	eventsLog.events = append(eventsLog.events, &types.BlockEvent{
+		Sequence:        eventsLog.nextSequence,
+		BlockIdentifier: blockSummaryToIdentifier(&block),
+		Type:            eventType,
+	})
+
+	eventsLog.nextSequence++
+
+	if len(eventsLog.events) > eventsLog.maxNumEvents {
+		eventsLog.events = eventsLog.events[len(eventsLog.events)-eventsLog.maxNumEvents:]
+	}
+}
+
+// getTipBlock gets the most recent block of the reported chain (if any)
+func (eventsLog *blockEventsLog) getTipBlock() (resources.BlockSummary, bool) {
+	eventsLog.mutex.RLock()
+	defer eventsLog.mutex.RUnlock()
+
+	numReportedBlocks := len(eventsLog.reportedBlocks)
+	if numReportedBlocks == 0 {
+		return resources.BlockSummary{}, false
+	}
+
+	return eventsLog.reportedBlocks[numReportedBlocks-1], true
+}
+
+// getEvents gets (at most "limit") events, starting with the one having the sequence "offset", along with the range of the sequences retained so far.
+// If the requested events aren't retained anymore, the events are returned starting with the oldest retained one (the caller decides whether that's acceptable).
+// If no event has been recorded yet, "ok" is false.
+func (eventsLog *blockEventsLog) getEvents(offset int64, limit int64) (events []*types.BlockEvent, oldestSequence int64, maxSequence int64, ok bool) {
+	eventsLog.mutex.RLock()
+	defer eventsLog.mutex.RUnlock()
+
+	return eventsLog.doGetEvents(offset, limit)
+}
+
+// getLatestEvents gets the most recent (at most "limit") events, along with the range of the sequences retained so far.
+// If no event has been recorded yet, "ok" is false.
+func (eventsLog *blockEventsLog) getLatestEvents(limit int64) (events []*types.BlockEvent, oldestSequence int64, maxSequence int64, ok bool) {
+	eventsLog.mutex.RLock()
+	defer eventsLog.mutex.RUnlock()
+
+	return eventsLog.doGetEvents(eventsLog.nextSequence-limit, limit)
+}
+
+func (eventsLog *blockEventsLog) doGetEvents(offset int64, limit int64) (events []*types.BlockEvent, oldestSequence int64, maxSequence int64, ok bool) {
+	if len(eventsLog.events) == 0 {
+		return nil, 0, 0, false
+	}
+
+	oldestSequence = eventsLog.events[0].Sequence
+	maxSequence = eventsLog.nextSequence - 1
+
+	if offset < oldestSequence {
+		offset = oldestSequence
+	}
+	if offset > maxSequence {
+		return []*types.BlockEvent{}, oldestSequence, maxSequence, true
+	}
+
+	start := offset - oldestSequence
+	end := start + limit
+	if end > int64(len(eventsLog.events)) {
+		end = int64(len(eventsLog.events))
+	}
+
+	// Events are immutable, thus it's safe to share them (the slice itself is copied).
+	events = make([]*types.BlockEvent, end-start)
+	copy(events, eventsLog.events[start:end])
+
+	return events, oldestSequence, maxSequence, true
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockEventsLog_AddAndRemoveBlocks(t *testing.T) {
+	t.Parallel()
+
+	eventsLog := newBlockEventsLog(0, 100, 100)
+
+	_, _, _, ok := eventsLog.getEvents(0, 10)
+	require.False(t, ok)
+
+	_, _, _, ok = eventsLog.getLatestEvents(10)
+	require.False(t, ok)
+
+	_, ok = eventsLog.getTipBlock()
+	require.False(t, ok)
+
+	eventsLog.addBlock(resources.BlockSummary{Nonce: 1, Hash: "aa"})
+	eventsLog.addBlock(resources.BlockSummary{Nonce: 2, Hash: "bb"})
+	eventsLog.removeTipBlock()
+	eventsLog.addBlock(resources.BlockSummary{Nonce: 2, Hash: "cc"})
+
+	tip, ok := eventsLog.getTipBlock()
+	require.True(t, ok)
+	require.Equal(t, "cc", tip.Hash)
+
+	events, oldestSequence, maxSequence, ok := eventsLog.getEvents(0, 10)
+	require.True(t, ok)
+	require.Equal(t, int64(0), oldestSequence)
+	require.Equal(t, int64(3), maxSequence)
+	require.Equal(t, []*types.BlockEvent{
+		{Sequence: 0, BlockIdentifier: &types.BlockIdentifier{Index: 1, Hash: "aa"}, Type: types.ADDED},
+		{Sequence: 1, BlockIdentifier: &types.BlockIdentifier{Index: 2, Hash: "bb"}, Type: types.ADDED},
+		{Sequence: 2, BlockIdentifier: &types.BlockIdentifier{Index: 2, Hash: "bb"}, Type: types.REMOVED},
+		{Sequence: 3, BlockIdentifier: &types.BlockIdentifier{Index: 2, Hash: "cc"}, Type: types.ADDED},
+	}, events)
+
+	events, _, _, _ = eventsLog.getEvents(1, 2)
+	require.Len(t, events, 2)
+	require.Equal(t, int64(1), events[0].Sequence)
+	require.Equal(t, int64(2), events[1].Sequence)
+
+	events, _, maxSequence, ok = eventsLog.getLatestEvents(2)
+	require.True(t, ok)
+	require.Equal(t, int64(3), maxSequence)
+	require.Len(t, events, 2)
+	require.Equal(t, int64(2), events[0].Sequence)
+	require.Equal(t, int64(3), events[1].Sequence)
+
+	events, _, _, _ = eventsLog.getLatestEvents(10)
+	require.Len(t, events, 4)
+
+	events, _, maxSequence, ok = eventsLog.getEvents(4, 10)
+	require.True(t, ok)
+	require.Equal(t, int64(3), maxSequence)
+	require.Len(t, events, 0)
+}
+
+func TestBlockEventsLog_RetentionLimits(t *testing.T) {
+	t.Parallel()
+
+	eventsLog := newBlockEventsLog(100, 3, 2)
+
+	eventsLog.addBlock(resources.BlockSummary{Nonce: 1, Hash: "aa"})
+	eventsLog.addBlock(resources.BlockSummary{Nonce: 2, Hash: "bb"})
+	eventsLog.addBlock(resources.BlockSummary{Nonce: 3, Hash: "cc"})
+	eventsLog.addBlock(resources.BlockSummary{Nonce: 4, Hash: "dd"})
+	eventsLog.addBlock(resources.BlockSummary{Nonce: 5, Hash: "ee"})
+
+	// Events that aren't retained anymore are skipped.
+	events, oldestSequence, maxSequence, ok := eventsLog.getEvents(0, 10)
+	require.True(t, ok)
+	require.Equal(t, int64(102), oldestSequence)
+	require.Equal(t, int64(104), maxSequence)
+	require.Len(t, events, 3)
+	require.Equal(t, int64(102), events[0].Sequence)
+	require.Equal(t, int64(104), events[2].Sequence)
+
+	// Only the most recent part of the reported chain is retained.
+	eventsLog.removeTipBlock()
+	eventsLog.removeTipBlock()
+	eventsLog.removeTipBlock()
+
+	_, ok = eventsLog.getTipBlock()
+	require.False(t, ok)
+
+	events, _, maxSequence, _ = eventsLog.getEvents(0, 10)
+	require.Equal(t, int64(106), maxSequence)
+	require.Equal(t, types.REMOVED, events[2].Type)
+	require.Equal(t, "dd", events[2].BlockIdentifier.Hash)
+}
@@ -36,13 +36,13 @@ func NewBlockService(provider NetworkProvider) server.BlockAPIServicer {
 
 // Block implements the /block endpoint.
 func (service *blockService) Block(
-	_ context.Context,
+	ctx context.Context,
 	request *types.BlockRequest,
 ) (*types.BlockResponse, *types.Error) {
 	stopWatch := core.NewStopWatch()
 	stopWatch.Start("block")
 
-	response, err := service.doGetBlock(request)
+	response, err := service.doGetBlock(ctx, request)
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +62,7 @@ func (service *blockService) Block(
 	return response, nil
 }
 
-func (service *blockService) doGetBlock(request *types.BlockRequest) (*types.BlockResponse, *types.Error) {
+func (service *blockService) doGetBlock(ctx context.Context, request *types.BlockRequest) (*types.BlockResponse, *types.Error) {
 	genesisBlockIdentifier := service.extension.getGenesisBlockIdentifier()
 
 	index := request.BlockIdentifier.Index
@@ -75,24 +75,24 @@ func (service *blockService) doGetBlock(request *types.BlockRequest) (*types.Blo
 
 	isGenesis := hasGenesisIndex || hasGenesisHash
 	if isGenesis {
-		return service.getGenesisBlock()
+		return service.getGenesisBlock(ctx)
 	}
 
 	if hasIndex {
 		log.Trace("blockService.Block()", "index", *index)
-		return service.getBlockByNonce(*index)
+		return service.getBlockByNonce(ctx, *index)
 	}
 
 	if hasHash {
 		log.Trace("blockService.Block()", "hash", *hash)
-		return service.getBlockByHash(*hash)
+		return service.getBlockByHash(ctx, *hash)
 	}
 
 	return nil, service.errFactory.newErr(ErrMustQueryByIndexOrByHash)
 }
 
 // getGenesisBlock returns or lazily fetches the genesis block (using "double-checked locking" pattern)
-func (service *blockService) getGenesisBlock() (*types.BlockResponse, *types.Error) {
+func (service *blockService) getGenesisBlock(ctx context.Context) (*types.BlockResponse, *types.Error) {
 	log.Debug("blockService.getGenesisBlock()")
 
 	service.genesisBlockMutex.RLock()
@@ -110,7 +110,7 @@ func (service *blockService) getGenesisBlock() (*types.BlockResponse, *types.Err
 		return service.genesisBlock, nil
 	}
 
-	fetchedBlock, err := service.doGetGenesisBlock()
+	fetchedBlock, err := service.doGetGenesisBlock(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -119,11 +119,11 @@ func (service *blockService) getGenesisBlock() (*types.BlockResponse, *types.Err
 	return fetchedBlock, nil
 }
 
-func (service *blockService) doGetGenesisBlock() (*types.BlockResponse, *types.Error) {
+func (service *blockService) doGetGenesisBlock(ctx context.Context) (*types.BlockResponse, *types.Error) {
 	log.Debug("blockService.doGetGenesisBlock()")
 
 	genesisBlockIdentifier := service.extension.getGenesisBlockIdentifier()
-	genesisBalances, err := service.provider.GetGenesisBalances()
+	genesisBalances, err := service.provider.GetGenesisBalances(ctx)
 	if err != nil {
 		return nil, service.errFactory.newErrWithOriginal(ErrUnableToGetGenesisBlock, err)
 	}
@@ -171,8 +171,8 @@ func (service *blockService) createGenesisOperations(balances []*resources.Genes
 	return operations, nil
 }
 
-func (service *blockService) getBlockByNonce(nonce int64) (*types.BlockResponse, *types.Error) {
-	block, err := service.provider.GetBlockByNonce(uint64(nonce))
+func (service *blockService) getBlockByNonce(ctx context.Context, nonce int64) (*types.BlockResponse, *types.Error) {
+	block, err := service.provider.GetBlockByNonce(ctx, uint64(nonce))
 	if err != nil {
 		return nil, service.errFactory.newErrWithOriginal(ErrUnableToGetBlock, err)
 	}
@@ -185,8 +185,8 @@ func (service *blockService) getBlockByNonce(nonce int64) (*types.BlockResponse,
 	return rosettaBlock, nil
 }
 
-func (service *blockService) getBlockByHash(hash string) (*types.BlockResponse, *types.Error) {
-	block, err := service.provider.GetBlockByHash(hash)
+func (service *blockService) getBlockByHash(ctx context.Context, hash string) (*types.BlockResponse, *types.Error) {
+	block, err := service.provider.GetBlockByHash(ctx, hash)
 	if err != nil {
 		return nil, service.errFactory.newErrWithOriginal(ErrUnableToGetBlock, err)
 	}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+// callMethodHandler handles a method of the /call endpoint (it's a method expression, bound to the service when called)
+type callMethodHandler func(service *callService, ctx context.Context, parameters objectsMap) (*types.CallResponse, *types.Error)
+
+// callMethodsRegistry holds the handlers of the methods supported by the /call endpoint
+type callMethodsRegistry struct {
+	handlers map[string]callMethodHandler
+}
+
+func newCallMethodsRegistry() *callMethodsRegistry {
+	return &callMethodsRegistry{
+		handlers: make(map[string]callMethodHandler),
+	}
+}
+
+func (registry *callMethodsRegistry) register(method string, handler callMethodHandler) {
+	_, exists := registry.handlers[method]
+	if exists {
+		log.Warn("callMethodsRegistry.register(): method already registered, will be overwritten", "method", method)
+	}
+
+	registry.handlers[method] = handler
+}
+
+func (registry *callMethodsRegistry) getHandler(method string) (callMethodHandler, bool) {
+	handler, ok := registry.handlers[method]
+	return handler, ok
+}
+
+func (registry *callMethodsRegistry) getMethods() []string {
+	methods := make([]string, 0, len(registry.handlers))
+
+	for method := range registry.handlers {
+		methods = append(methods, method)
+	}
+
+	sort.Strings(methods)
+	return methods
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallMethodsRegistry(t *testing.T) {
+	t.Parallel()
+
+	registry := newCallMethodsRegistry()
+	registry.register("b", func(_ *callService, _ context.Context, parameters objectsMap) (*types.CallResponse, *types.Error) {
+		return &types.CallResponse{Result: objectsMap{"method": "b"}}, nil
+	})
+	registry.register("a", func(_ *callService, _ context.Context, parameters objectsMap) (*types.CallResponse, *types.Error) {
+		return &types.CallResponse{Result: objectsMap{"method": "a"}}, nil
+	})
+
+	require.Equal(t, []string{"a", "b"}, registry.getMethods())
+
+	handler, ok := registry.getHandler("a")
+	require.True(t, ok)
+	response, err := handler(nil, context.Background(), nil)
+	require.Nil(t, err)
+	require.Equal(t, "a", response.Result["method"])
+
+	handler, ok = registry.getHandler("c")
+	require.False(t, ok)
+	require.Nil(t, handler)
+}
+
+func TestSupportedCallMethods(t *testing.T) {
+	t.Parallel()
+
+	// Derived from the registry (sorted)
+	require.Equal(t, []string{
+		"computeShardOfAddress",
+		"getBlockByTimestamp",
+		"getSubmittedTransaction",
+		"getTokenProperties",
+		"vmQuery",
+	}, SupportedCallMethods)
+
+	for _, method := range SupportedCallMethods {
+		_, ok := callMethods.getHandler(method)
+		require.True(t, ok)
+	}
+}
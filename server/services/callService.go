@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+)
+
+const (
+	callMethodGetSubmittedTransaction = "getSubmittedTransaction"
+	callMethodVMQuery                 = "vmQuery"
+	callMethodGetTokenProperties      = "getTokenProperties"
+	callMethodComputeShardOfAddress   = "computeShardOfAddress"
+	callMethodGetBlockByTimestamp     = "getBlockByTimestamp"
+)
+
+var (
+	// callMethods holds the handlers of the methods supported by the /call endpoint
+	callMethods = createCallMethodsRegistry()
+
+	// SupportedCallMethods are the methods supported by the /call endpoint (as registered)
+	SupportedCallMethods = callMethods.getMethods()
+)
+
+func createCallMethodsRegistry() *callMethodsRegistry {
+	registry := newCallMethodsRegistry()
+	registry.register(callMethodGetSubmittedTransaction, (*callService).getSubmittedTransaction)
+	registry.register(callMethodVMQuery, (*callService).vmQuery)
+	registry.register(callMethodGetTokenProperties, (*callService).getTokenProperties)
+	registry.register(callMethodComputeShardOfAddress, (*callService).computeShardOfAddress)
+	registry.register(callMethodGetBlockByTimestamp, (*callService).getBlockByTimestamp)
+	return registry
+}
+
+type callService struct {
+	provider   NetworkProvider
+	extension  *networkProviderExtension
+	errFactory *errFactory
+	registry   *callMethodsRegistry
+}
+
+// NewCallService creates a new instance of callService
+func NewCallService(provider NetworkProvider) server.CallAPIServicer {
+	return &callService{
+		provider:   provider,
+		extension:  newNetworkProviderExtension(provider),
+		errFactory: newErrFactory(),
+		registry:   callMethods,
+	}
+}
+
+// Call implements the /call endpoint.
+func (service *callService) Call(
+	ctx context.Context,
+	request *types.CallRequest,
+) (*types.CallResponse, *types.Error) {
+	log.Debug("callService.Call()", "method", request.Method, "parameters", request.Parameters)
+
+	// In offline mode, no methods are advertised (see /network/options).
+	if service.provider.IsOffline() {
+		return nil, service.errFactory.newErr(ErrOfflineMode)
+	}
+
+	handler, ok := service.registry.getHandler(request.Method)
+	if !ok {
+		return nil, service.errFactory.newErr(ErrNotImplemented)
+	}
+
+	return handler(service, ctx, request.Parameters)
+}
+
+// getSubmittedTransaction returns the status of a transaction broadcasted through /construction/submit
+// (e.g. whether it has landed in a final block or has been dropped from the pool).
+func (service *callService) getSubmittedTransaction(_ context.Context, parameters objectsMap) (*types.CallResponse, *types.Error) {
+	hash, ok := parameters["hash"].(string)
+	if !ok || len(hash) == 0 {
+		return nil, service.errFactory.newErrWithOriginal(ErrInvalidInputParam, errors.New("missing parameter: hash"))
+	}
+
+	submission, ok := service.provider.GetSubmittedTransaction(hash)
+	if !ok {
+		return nil, service.errFactory.newErr(ErrTransactionIsNotTracked)
+	}
+
+	// Not idempotent: the status changes over time (and, eventually, the transaction is forgotten).
+	return &types.CallResponse{
+		Result:     submittedTransactionToCallResult(submission),
+		Idempotent: false,
+	}, nil
+}
+
+func submittedTransactionToCallResult(submission *resources.SubmittedTransaction) map[string]interface{} {
+	return map[string]interface{}{
+		"hash":            submission.Hash,
+		"sender":          submission.Sender,
+		"nonce":           submission.Nonce,
+		"status":          string(submission.Status),
+		"executionStatus": submission.ExecutionStatus,
+		"blockNonce":      submission.BlockNonce,
+		"blockHash":       submission.BlockHash,
+		"dropReason":      submission.DropReason,
+		"submittedAt":     submission.SubmittedAt.Unix(),
+		"updatedAt":       submission.UpdatedAt.Unix(),
+	}
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+// computeShardOfAddress returns the shard of an account (given as bech32 address, hex-encoded public key or username).
+func (service *callService) computeShardOfAddress(ctx context.Context, parameters objectsMap) (*types.CallResponse, *types.Error) {
+	identifier, ok := parameters["address"].(string)
+	if !ok || len(identifier) == 0 {
+		return nil, service.errFactory.newErrWithOriginal(ErrInvalidInputParam, errors.New("missing parameter: address"))
+	}
+
+	address, err := service.extension.resolveAddress(ctx, identifier)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrInvalidAccountAddress, err)
+	}
+
+	pubKey, err := service.provider.ConvertAddressToPubKey(address)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrInvalidAccountAddress, err)
+	}
+
+	isObserved, err := service.provider.IsAddressObserved(address)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrInvalidAccountAddress, err)
+	}
+
+	return &types.CallResponse{
+		Result: map[string]interface{}{
+			"address":    address,
+			"shard":      service.provider.ComputeShardIdOfPubKey(pubKey),
+			"isContract": !service.extension.isUserPubKey(pubKey),
+			"isObserved": isObserved,
+		},
+		Idempotent: true,
+	}, nil
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallService_ComputeShardOfAddress(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	service := NewCallService(networkProvider)
+
+	call := func(address string) (*types.CallResponse, *types.Error) {
+		return service.Call(context.Background(), &types.CallRequest{
+			Method:     "computeShardOfAddress",
+			Parameters: objectsMap{"address": address},
+		})
+	}
+
+	response, err := call(testscommon.TestAddressAlice)
+	require.Nil(t, err)
+	require.True(t, response.Idempotent)
+	require.Equal(t, map[string]interface{}{
+		"address":    testscommon.TestAddressAlice,
+		"shard":      uint32(1),
+		"isContract": false,
+		"isObserved": false,
+	}, response.Result)
+
+	response, err = call(hex.EncodeToString(testscommon.TestPubKeyBob))
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{
+		"address":    testscommon.TestAddressBob,
+		"shard":      uint32(0),
+		"isContract": false,
+		"isObserved": true,
+	}, response.Result)
+
+	response, err = call(testscommon.TestAddressOfContract)
+	require.Nil(t, err)
+	require.Equal(t, true, response.Result["isContract"])
+
+	response, err = call("alice")
+	require.Nil(t, response)
+	require.Equal(t, int32(ErrInvalidAccountAddress), err.Code)
+
+	response, err = call("")
+	require.Nil(t, response)
+	require.Equal(t, int32(ErrInvalidInputParam), err.Code)
+}
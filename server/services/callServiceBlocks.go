@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+)
+
+type getBlockByTimestampParameters struct {
+	Timestamp int64 `json:"timestamp"`
+}
+
+// getBlockByTimestamp looks up the latest block with a timestamp (in milliseconds) lower than or equal to the given one.
+// The lookup is a binary search over the blocks with historical state (each step fetches a block summary from the observer).
+func (service *callService) getBlockByTimestamp(ctx context.Context, parameters objectsMap) (*types.CallResponse, *types.Error) {
+	params := &getBlockByTimestampParameters{}
+	err := fromObjectsMap(parameters, params)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrInvalidInputParam, err)
+	}
+	if params.Timestamp <= 0 {
+		return nil, service.errFactory.newErrWithOriginal(ErrInvalidInputParam, errors.New("missing or bad parameter: timestamp"))
+	}
+
+	nodeStatus, err := service.provider.GetNodeStatus(ctx)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrUnableToGetNodeStatus, err)
+	}
+
+	oldest := &nodeStatus.OldestBlockWithHistoricalState
+	latest := &nodeStatus.LatestBlock
+
+	if params.Timestamp < getBlockSummaryTimestampInMS(oldest) {
+		return nil, service.errFactory.newErrWithOriginal(ErrInvalidInputParam, fmt.Errorf("timestamp precedes the oldest available block (%d)", oldest.Nonce))
+	}
+	if params.Timestamp >= getBlockSummaryTimestampInMS(latest) {
+		// Not idempotent: newer blocks might still come (with a timestamp lower than or equal to the given one).
+		return &types.CallResponse{
+			Result:     blockSummaryToCallResult(latest),
+			Idempotent: false,
+		}, nil
+	}
+
+	found, err := service.searchBlockByTimestamp(ctx, params.Timestamp, oldest, latest)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrUnableToGetBlock, err)
+	}
+
+	return &types.CallResponse{
+		Result:     blockSummaryToCallResult(found),
+		Idempotent: true,
+	}, nil
+}
+
+// searchBlockByTimestamp expects: timestamp(low) <= timestamp < timestamp(high).
+func (service *callService) searchBlockByTimestamp(ctx context.Context, timestamp int64, low *resources.BlockSummary, high *resources.BlockSummary) (*resources.BlockSummary, error) {
+	for high.Nonce-low.Nonce > 1 {
+		middleNonce := low.Nonce + (high.Nonce-low.Nonce)/2
+
+		middle, err := service.provider.GetBlockSummaryByNonce(ctx, middleNonce)
+		if err != nil {
+			return nil, err
+		}
+
+		if getBlockSummaryTimestampInMS(middle) <= timestamp {
+			low = middle
+		} else {
+			high = middle
+		}
+	}
+
+	return low, nil
+}
+
+func getBlockSummaryTimestampInMS(summary *resources.BlockSummary) int64 {
+	return getTimestampInMS(summary.Timestamp, summary.TimestampMs)
+}
+
+func blockSummaryToCallResult(summary *resources.BlockSummary) map[string]interface{} {
+	return map[string]interface{}{
+		"index":     summary.Nonce,
+		"hash":      summary.Hash,
+		"timestamp": getBlockSummaryTimestampInMS(summary),
+	}
+}
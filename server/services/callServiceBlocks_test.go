@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-core-go/data/api"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallService_GetBlockByTimestamp(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+
+	// Blocks 100 to 200, one every 6 seconds, with a gap (skipped rounds) after block 150.
+	timestampOfBlock := func(nonce uint64) int64 {
+		timestamp := int64(1700000000000 + (nonce-100)*6000)
+		if nonce > 150 {
+			timestamp += 60000
+		}
+
+		return timestamp
+	}
+
+	for nonce := uint64(100); nonce <= 200; nonce++ {
+		networkProvider.MockBlocksByNonce[nonce] = &api.Block{
+			Nonce:       nonce,
+			Hash:        fmt.Sprintf("%064x", nonce),
+			TimestampMs: timestampOfBlock(nonce),
+		}
+	}
+
+	summaryOf := func(nonce uint64) resources.BlockSummary {
+		return resources.BlockSummary{
+			Nonce:       nonce,
+			Hash:        fmt.Sprintf("%064x", nonce),
+			TimestampMs: timestampOfBlock(nonce),
+		}
+	}
+
+	networkProvider.MockNodeStatus.OldestBlockWithHistoricalState = summaryOf(100)
+	networkProvider.MockNodeStatus.LatestBlock = summaryOf(200)
+
+	service := NewCallService(networkProvider)
+
+	call := func(timestamp int64) (*types.CallResponse, *types.Error) {
+		return service.Call(context.Background(), &types.CallRequest{
+			Method:     "getBlockByTimestamp",
+			Parameters: objectsMap{"timestamp": timestamp},
+		})
+	}
+
+	t.Run("exact match", func(t *testing.T) {
+		response, err := call(timestampOfBlock(137))
+		require.Nil(t, err)
+		require.True(t, response.Idempotent)
+		require.Equal(t, map[string]interface{}{
+			"index":     uint64(137),
+			"hash":      fmt.Sprintf("%064x", 137),
+			"timestamp": timestampOfBlock(137),
+		}, response.Result)
+	})
+
+	t.Run("between blocks", func(t *testing.T) {
+		response, err := call(timestampOfBlock(137) + 3000)
+		require.Nil(t, err)
+		require.Equal(t, uint64(137), response.Result["index"])
+
+		// Within the gap
+		response, err = call(timestampOfBlock(150) + 30000)
+		require.Nil(t, err)
+		require.Equal(t, uint64(150), response.Result["index"])
+	})
+
+	t.Run("oldest block", func(t *testing.T) {
+		response, err := call(timestampOfBlock(100) + 1)
+		require.Nil(t, err)
+		require.Equal(t, uint64(100), response.Result["index"])
+	})
+
+	t.Run("after the latest block", func(t *testing.T) {
+		response, err := call(timestampOfBlock(200) + 1)
+		require.Nil(t, err)
+		require.False(t, response.Idempotent)
+		require.Equal(t, uint64(200), response.Result["index"])
+	})
+
+	t.Run("before the oldest block", func(t *testing.T) {
+		response, err := call(timestampOfBlock(100) - 1)
+		require.Nil(t, response)
+		require.Equal(t, int32(ErrInvalidInputParam), err.Code)
+	})
+
+	t.Run("with bad timestamp", func(t *testing.T) {
+		response, err := call(0)
+		require.Nil(t, response)
+		require.Equal(t, int32(ErrInvalidInputParam), err.Code)
+	})
+}
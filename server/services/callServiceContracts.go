@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+)
+
+type vmQueryParameters struct {
+	Contract   string   `json:"contract"`
+	Function   string   `json:"function"`
+	Arguments  []string `json:"arguments"`
+	Caller     string   `json:"caller"`
+	Value      string   `json:"value"`
+	BlockNonce *uint64  `json:"blockNonce"`
+	BlockHash  string   `json:"blockHash"`
+}
+
+func (parameters *vmQueryParameters) hasBlockCoordinates() bool {
+	return parameters.BlockNonce != nil || len(parameters.BlockHash) > 0
+}
+
+// vmQuery runs a read-only contract call (optionally, at a given block).
+// Arguments are expected to be hex-encoded; the return data is hex-encoded, as well.
+func (service *callService) vmQuery(ctx context.Context, parameters objectsMap) (*types.CallResponse, *types.Error) {
+	params := &vmQueryParameters{}
+	err := fromObjectsMap(parameters, params)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrInvalidInputParam, err)
+	}
+
+	query, options, err := service.prepareVMQuery(ctx, params)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrInvalidInputParam, err)
+	}
+
+	output, err := service.provider.QueryContract(ctx, query, options)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrUnableToQueryContract, err)
+	}
+
+	// Only idempotent when the query is bound to a specific block.
+	return &types.CallResponse{
+		Result:     vmQueryOutputToCallResult(output),
+		Idempotent: params.hasBlockCoordinates(),
+	}, nil
+}
+
+func (service *callService) prepareVMQuery(ctx context.Context, params *vmQueryParameters) (*data.VmValueRequest, resources.AccountQueryOptions, error) {
+	noOptions := resources.AccountQueryOptions{}
+
+	contract, err := service.extension.resolveAddress(ctx, params.Contract)
+	if err != nil {
+		return nil, noOptions, fmt.Errorf("bad contract: %w", err)
+	}
+	if !service.extension.isContractAddress(contract) {
+		return nil, noOptions, fmt.Errorf("%w: %s", errNotAContract, contract)
+	}
+
+	function := strings.TrimSpace(params.Function)
+	if len(function) == 0 {
+		return nil, noOptions, errors.New("missing parameter: function")
+	}
+
+	for _, argument := range params.Arguments {
+		_, err = hex.DecodeString(argument)
+		if err != nil {
+			return nil, noOptions, fmt.Errorf("bad argument (not hex-encoded): %s", argument)
+		}
+	}
+
+	caller := ""
+	if len(params.Caller) > 0 {
+		caller, err = service.extension.resolveAddress(ctx, params.Caller)
+		if err != nil {
+			return nil, noOptions, fmt.Errorf("bad caller: %w", err)
+		}
+	}
+
+	if len(params.Value) > 0 {
+		value, ok := big.NewInt(0).SetString(params.Value, 10)
+		if !ok || value.Sign() < 0 {
+			return nil, noOptions, fmt.Errorf("bad value: %s", params.Value)
+		}
+	}
+
+	options, err := params.toAccountQueryOptions()
+	if err != nil {
+		return nil, noOptions, err
+	}
+
+	return &data.VmValueRequest{
+		Address:    contract,
+		FuncName:   function,
+		CallerAddr: caller,
+		CallValue:  params.Value,
+		Args:       params.Arguments,
+	}, options, nil
+}
+
+func (parameters *vmQueryParameters) toAccountQueryOptions() (resources.AccountQueryOptions, error) {
+	hasBlockNonce := parameters.BlockNonce != nil
+	hasBlockHash := len(parameters.BlockHash) > 0
+
+	if hasBlockNonce && hasBlockHash {
+		return resources.AccountQueryOptions{}, errBadBlockCoordinates
+	}
+	if hasBlockNonce {
+		return resources.NewAccountQueryOptionsWithBlockNonce(*parameters.BlockNonce), nil
+	}
+	if hasBlockHash {
+		blockHash, err := hex.DecodeString(parameters.BlockHash)
+		if err != nil || len(blockHash) != len(emptyHash)/2 {
+			return resources.AccountQueryOptions{}, fmt.Errorf("%w: bad block hash", errBadBlockCoordinates)
+		}
+
+		return resources.NewAccountQueryOptionsWithBlockHash(blockHash), nil
+	}
+
+	return resources.AccountQueryOptions{}, nil
+}
+
+func vmQueryOutputToCallResult(output *resources.VMQueryOutput) map[string]interface{} {
+	returnData := make([]string, 0, len(output.ReturnData))
+
+	for _, item := range output.ReturnData {
+		returnData = append(returnData, hex.EncodeToString(item))
+	}
+
+	return map[string]interface{}{
+		"returnData":    returnData,
+		"returnCode":    output.ReturnCode,
+		"returnMessage": output.ReturnMessage,
+	}
+}
+
+// getTokenProperties returns the properties of an ESDT token (e.g. name, owner, supply, decimals, capabilities).
+func (service *callService) getTokenProperties(ctx context.Context, parameters objectsMap) (*types.CallResponse, *types.Error) {
+	token, ok := parameters["token"].(string)
+	token = strings.TrimSpace(token)
+	if !ok || len(token) == 0 {
+		return nil, service.errFactory.newErrWithOriginal(ErrInvalidInputParam, errors.New("missing parameter: token"))
+	}
+
+	properties, err := service.provider.GetTokenProperties(ctx, token)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrUnableToGetTokenProperties, err)
+	}
+
+	// Not idempotent: supply and capabilities of a token might change over time.
+	return &types.CallResponse{
+		Result:     tokenPropertiesToCallResult(properties),
+		Idempotent: false,
+	}, nil
+}
+
+func tokenPropertiesToCallResult(properties *resources.TokenProperties) map[string]interface{} {
+	return map[string]interface{}{
+		"identifier": properties.Identifier,
+		"name":       properties.Name,
+		"type":       properties.Type,
+		"owner":      properties.Owner,
+		"minted":     properties.Minted,
+		"burnt":      properties.Burnt,
+		"properties": properties.Properties,
+	}
+}
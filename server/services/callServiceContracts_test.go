@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallService_VMQuery(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	service := NewCallService(networkProvider)
+
+	var calledWithQuery *data.VmValueRequest
+	var calledWithOptions resources.AccountQueryOptions
+
+	networkProvider.QueryContractCalled = func(query *data.VmValueRequest, options resources.AccountQueryOptions) (*resources.VMQueryOutput, error) {
+		calledWithQuery = query
+		calledWithOptions = options
+
+		return &resources.VMQueryOutput{
+			ReturnData: [][]byte{{0x2a}, {}},
+			ReturnCode: "ok",
+		}, nil
+	}
+
+	call := func(parameters objectsMap) (*types.CallResponse, *types.Error) {
+		return service.Call(context.Background(), &types.CallRequest{
+			Method:     "vmQuery",
+			Parameters: parameters,
+		})
+	}
+
+	t.Run("on latest state", func(t *testing.T) {
+		response, err := call(objectsMap{
+			"contract":  testscommon.TestAddressOfContract,
+			"function":  "getSum",
+			"arguments": []interface{}{"01", "abba"},
+			"caller":    testscommon.TestAddressAlice,
+			"value":     "0",
+		})
+		require.Nil(t, err)
+		require.False(t, response.Idempotent)
+		require.Equal(t, map[string]interface{}{
+			"returnData":    []string{"2a", ""},
+			"returnCode":    "ok",
+			"returnMessage": "",
+		}, response.Result)
+		require.Equal(t, &data.VmValueRequest{
+			Address:    testscommon.TestAddressOfContract,
+			FuncName:   "getSum",
+			CallerAddr: testscommon.TestAddressAlice,
+			CallValue:  "0",
+			Args:       []string{"01", "abba"},
+		}, calledWithQuery)
+		require.Equal(t, resources.AccountQueryOptions{}, calledWithOptions)
+	})
+
+	t.Run("at a given block", func(t *testing.T) {
+		response, err := call(objectsMap{
+			"contract":   testscommon.TestAddressOfContract,
+			"function":   "getSum",
+			"blockNonce": 42,
+		})
+		require.Nil(t, err)
+		require.True(t, response.Idempotent)
+		require.Equal(t, resources.NewAccountQueryOptionsWithBlockNonce(42), calledWithOptions)
+
+		response, err = call(objectsMap{
+			"contract":  testscommon.TestAddressOfContract,
+			"function":  "getSum",
+			"blockHash": "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		})
+		require.Nil(t, err)
+		require.True(t, response.Idempotent)
+		require.Len(t, calledWithOptions.BlockHash, 32)
+	})
+
+	t.Run("with bad parameters", func(t *testing.T) {
+		badParameters := []objectsMap{
+			{"function": "getSum"},
+			{"contract": testscommon.TestAddressAlice, "function": "getSum"},
+			{"contract": testscommon.TestAddressOfContract},
+			{"contract": testscommon.TestAddressOfContract, "function": "getSum", "arguments": []interface{}{"xyz"}},
+			{"contract": testscommon.TestAddressOfContract, "function": "getSum", "caller": "alice"},
+			{"contract": testscommon.TestAddressOfContract, "function": "getSum", "value": "-1"},
+			{"contract": testscommon.TestAddressOfContract, "function": "getSum", "blockNonce": -1},
+			{"contract": testscommon.TestAddressOfContract, "function": "getSum", "blockHash": "abba"},
+			{"contract": testscommon.TestAddressOfContract, "function": "getSum", "blockNonce": 42, "blockHash": "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"},
+		}
+
+		for _, parameters := range badParameters {
+			response, err := call(parameters)
+			require.Nil(t, response)
+			require.Equal(t, int32(ErrInvalidInputParam), err.Code, parameters)
+		}
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		networkProvider.QueryContractCalled = func(query *data.VmValueRequest, options resources.AccountQueryOptions) (*resources.VMQueryOutput, error) {
+			return nil, errors.New("arbitrary error")
+		}
+
+		response, err := call(objectsMap{
+			"contract": testscommon.TestAddressOfContract,
+			"function": "getSum",
+		})
+		require.Nil(t, response)
+		require.Equal(t, int32(ErrUnableToQueryContract), err.Code)
+	})
+}
+
+func TestCallService_GetTokenProperties(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockTokensProperties["ROSETTA-3a2edf"] = &resources.TokenProperties{
+		Identifier: "ROSETTA-3a2edf",
+		Name:       "ROSETTA",
+		Type:       "FungibleESDT",
+		Owner:      testscommon.TestAddressAlice,
+		Minted:     "1000000",
+		Burnt:      "0",
+		Properties: map[string]string{"NumDecimals": "2"},
+	}
+
+	service := NewCallService(networkProvider)
+
+	response, err := service.Call(context.Background(), &types.CallRequest{
+		Method:     "getTokenProperties",
+		Parameters: objectsMap{"token": "ROSETTA-3a2edf"},
+	})
+	require.Nil(t, err)
+	require.False(t, response.Idempotent)
+	require.Equal(t, map[string]interface{}{
+		"identifier": "ROSETTA-3a2edf",
+		"name":       "ROSETTA",
+		"type":       "FungibleESDT",
+		"owner":      testscommon.TestAddressAlice,
+		"minted":     "1000000",
+		"burnt":      "0",
+		"properties": map[string]string{"NumDecimals": "2"},
+	}, response.Result)
+
+	response, err = service.Call(context.Background(), &types.CallRequest{
+		Method:     "getTokenProperties",
+		Parameters: objectsMap{"token": "FOO-abcdef"},
+	})
+	require.Nil(t, response)
+	require.Equal(t, int32(ErrUnableToGetTokenProperties), err.Code)
+
+	response, err = service.Call(context.Background(), &types.CallRequest{
+		Method:     "getTokenProperties",
+		Parameters: objectsMap{"token": " "},
+	})
+	require.Nil(t, response)
+	require.Equal(t, int32(ErrInvalidInputParam), err.Code)
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallService_GetSubmittedTransaction(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockSubmittedTransactions["aaaa"] = &resources.SubmittedTransaction{
+		Hash:            "aaaa",
+		Sender:          testscommon.TestAddressAlice,
+		Nonce:           42,
+		Status:          resources.SubmissionStatusLanded,
+		ExecutionStatus: "success",
+		BlockNonce:      1000,
+		BlockHash:       "bbbb",
+		SubmittedAt:     time.Unix(1700000000, 0),
+		UpdatedAt:       time.Unix(1700000012, 0),
+	}
+
+	service := NewCallService(networkProvider)
+
+	t.Run("with tracked transaction", func(t *testing.T) {
+		response, err := service.Call(context.Background(), &types.CallRequest{
+			Method:     "getSubmittedTransaction",
+			Parameters: map[string]interface{}{"hash": "aaaa"},
+		})
+		require.Nil(t, err)
+		require.False(t, response.Idempotent)
+		require.Equal(t, map[string]interface{}{
+			"hash":            "aaaa",
+			"sender":          testscommon.TestAddressAlice,
+			"nonce":           uint64(42),
+			"status":          "landed",
+			"executionStatus": "success",
+			"blockNonce":      uint64(1000),
+			"blockHash":       "bbbb",
+			"dropReason":      "",
+			"submittedAt":     int64(1700000000),
+			"updatedAt":       int64(1700000012),
+		}, response.Result)
+	})
+
+	t.Run("with unknown transaction", func(t *testing.T) {
+		response, err := service.Call(context.Background(), &types.CallRequest{
+			Method:     "getSubmittedTransaction",
+			Parameters: map[string]interface{}{"hash": "cccc"},
+		})
+		require.Nil(t, response)
+		require.Equal(t, int32(ErrTransactionIsNotTracked), err.Code)
+	})
+
+	t.Run("with missing hash", func(t *testing.T) {
+		response, err := service.Call(context.Background(), &types.CallRequest{
+			Method:     "getSubmittedTransaction",
+			Parameters: map[string]interface{}{},
+		})
+		require.Nil(t, response)
+		require.Equal(t, int32(ErrInvalidInputParam), err.Code)
+	})
+
+	t.Run("with unknown method", func(t *testing.T) {
+		response, err := service.Call(context.Background(), &types.CallRequest{
+			Method: "foobar",
+		})
+		require.Nil(t, response)
+		require.Equal(t, int32(ErrNotImplemented), err.Code)
+	})
+}
+
+func TestCallService_CallWhenOffline(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockIsOffline = true
+	service := NewCallService(networkProvider)
+
+	response, err := service.Call(context.Background(), &types.CallRequest{
+		Method:     "computeShardOfAddress",
+		Parameters: map[string]interface{}{"address": testscommon.TestAddressAlice},
+	})
+	require.Nil(t, response)
+	require.Equal(t, int32(ErrOfflineMode), err.Code)
+}
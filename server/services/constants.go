@@ -11,6 +11,8 @@ import (
 
 var (
 	transactionVersion                                    = 1
+	transactionVersionWithOptions                         = 2
+	transactionOptionSignedWithHash                       = uint32(1)
 	transactionProcessingTypeRelayedV1                    = "RelayedTx"
 	transactionProcessingTypeBuiltInFunctionCall          = "BuiltInFunctionCall"
 	transactionProcessingTypeMoveBalance                  = "MoveBalance"
@@ -19,6 +21,10 @@ var (
 	amountZero                                            = "0"
 	builtInFunctionClaimDeveloperRewards                  = core.BuiltInFunctionClaimDeveloperRewards
 	builtInFunctionESDTTransfer                           = core.BuiltInFunctionESDTTransfer
+	builtInFunctionESDTNFTTransfer                        = core.BuiltInFunctionESDTNFTTransfer
+	builtInFunctionMultiESDTNFTTransfer                   = core.BuiltInFunctionMultiESDTNFTTransfer
+	relayedTransactionV1Function                          = core.RelayedTransaction
+	relayedTransactionV2Function                          = core.RelayedTransactionV2
 	refundGasMessage                                      = "refundedGas"
 	argumentsSeparator                                    = "@"
 	sendingValueToNonPayableContractDataPrefix            = argumentsSeparator + hex.EncodeToString([]byte("sending value to non payable contract"))
@@ -26,8 +32,13 @@ var (
 	nodeVersionForOfflineRosetta                          = "N / A"
 	systemContractDeployAddress                           = "erd1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq6gq4hu"
 	nativeAsESDTIdentifier                                = "EGLD-000000"
+	pubKeyLength                                          = 32
+	syncStageSynced                                       = "synced"
+	syncStageSyncing                                      = "syncing"
 	durationAlarmThresholdBlockServiceGetBlock            = time.Duration(500) * time.Millisecond
 	durationAlarmThresholdAccountServiceGetAccountBalance = time.Duration(500) * time.Millisecond
+	durationNonceReservation                              = time.Duration(2) * time.Minute
+	durationMempoolSnapshotMaxAge                         = time.Duration(3) * time.Second
 )
 
 const (
@@ -71,3 +82,17 @@ const (
 	numTopicsOfEventClaimDeveloperRewards           = 2
 	numTopicsOfEventTransferValueOnlyAfterSirius    = 2
 )
+
+const (
+	maxNumBlockEventsRetained      = 100000
+	maxNumReportedBlocksRetained   = 1000
+	maxNumBlockEventsPerResponse   = 1000
+	maxNumBlocksAddedPerEventsPoll = 1000
+	maxNumSearchResultsPerResponse = 100
+	maxSearchOffset                = 10000
+	maxNumBlocksIndexedPerRound    = 100
+)
+
+const (
+	numBlocksBetweenReconciliationProgressLogs = 1000
+)
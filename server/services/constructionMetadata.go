@@ -19,6 +19,10 @@ type constructionMetadata struct {
 	Data           []byte `json:"data"`
 	ChainID        string `json:"chainID"`
 	Version        int    `json:"version"`
+	Options        uint32 `json:"options,omitempty"`
+
+	// NonceGaps is informative (not part of the transaction): the missing nonces of the sender, as seen in the pool.
+	NonceGaps []data.NonceGap `json:"nonceGaps,omitempty"`
 }
 
 func newConstructionMetadata(obj objectsMap) (*constructionMetadata, error) {
@@ -51,7 +55,20 @@ func (metadata *constructionMetadata) toTransaction() (*data.Transaction, error)
 		return nil, err
 	}
 
-	tx := &data.Transaction{
+	return metadata.toUncheckedTransaction(), nil
+}
+
+// toTransactionForGasEstimation creates an unsigned transaction, to be simulated by the observer (the gas limit is not known yet).
+// Apart from the gas limit and the gas price, it's identical to the transaction to be signed (so that the estimation holds).
+func (metadata *constructionMetadata) toTransactionForGasEstimation(gasPrice uint64) *data.Transaction {
+	tx := metadata.toUncheckedTransaction()
+	tx.GasLimit = 0
+	tx.GasPrice = gasPrice
+	return tx
+}
+
+func (metadata *constructionMetadata) toUncheckedTransaction() *data.Transaction {
+	return &data.Transaction{
 		Sender:   metadata.Sender,
 		Receiver: metadata.Receiver,
 		Nonce:    metadata.Nonce,
@@ -61,9 +78,8 @@ func (metadata *constructionMetadata) toTransaction() (*data.Transaction, error)
 		Data:     metadata.Data,
 		ChainID:  metadata.ChainID,
 		Version:  uint32(metadata.Version),
+		Options:  metadata.Options,
 	}
-
-	return tx, nil
 }
 
 func (metadata *constructionMetadata) validate() error {
@@ -79,9 +95,17 @@ func (metadata *constructionMetadata) validate() error {
 	if metadata.GasPrice == 0 {
 		return errors.New("missing metadata: 'gasPrice'")
 	}
-	if metadata.Version != 1 {
+	if metadata.Version != transactionVersion && metadata.Version != transactionVersionWithOptions {
 		return fmt.Errorf("bad metadata: unexpected 'version' %v", metadata.Version)
 	}
+	if metadata.Options&^transactionOptionSignedWithHash != 0 {
+		return fmt.Errorf("bad metadata: unexpected 'options' %v", metadata.Options)
+	}
+
+	err := validateTransactionVersionAndOptions(uint32(metadata.Version), metadata.Options)
+	if err != nil {
+		return err
+	}
 	if len(metadata.ChainID) == 0 {
 		return errors.New("missing metadata: 'chainID'")
 	}
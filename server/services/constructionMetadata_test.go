@@ -28,6 +28,36 @@ func TestConstructionMetadata_ToTransactionJson(t *testing.T) {
 	require.Equal(t, expectedJson, string(actualJson))
 }
 
+func TestConstructionMetadata_ToTransactionForGasEstimation(t *testing.T) {
+	t.Parallel()
+
+	metadata := &constructionMetadata{
+		Sender:         "alice",
+		Receiver:       "bob",
+		Nonce:          42,
+		Amount:         "1234",
+		CurrencySymbol: "XeGLD",
+		GasLimit:       80000,
+		GasPrice:       1000000000,
+		Data:           []byte("hello"),
+		ChainID:        "T",
+		Version:        2,
+		Options:        1,
+	}
+
+	tx, err := metadata.toTransaction()
+	require.Nil(t, err)
+
+	// Apart from the gas limit and the gas price, the transactions are identical
+	txForGasEstimation := metadata.toTransactionForGasEstimation(2000000000)
+	require.Equal(t, uint64(0), txForGasEstimation.GasLimit)
+	require.Equal(t, uint64(2000000000), txForGasEstimation.GasPrice)
+
+	txForGasEstimation.GasLimit = tx.GasLimit
+	txForGasEstimation.GasPrice = tx.GasPrice
+	require.Equal(t, tx, txForGasEstimation)
+}
+
 func TestConstructionMetadata_Validate(t *testing.T) {
 	t.Parallel()
 
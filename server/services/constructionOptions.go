@@ -12,6 +12,9 @@ type constructionOptions struct {
 	GasLimit       uint64 `json:"gasLimit"`
 	GasPrice       uint64 `json:"gasPrice"`
 	Data           []byte `json:"data"`
+	ReserveNonce   bool   `json:"reserveNonce,omitempty"`
+	SignWithHash   bool   `json:"signWithHash,omitempty"`
+	Sweep          bool   `json:"sweep,omitempty"`
 }
 
 func newConstructionOptions(obj objectsMap) (*constructionOptions, error) {
@@ -47,7 +50,10 @@ func (options *constructionOptions) validate(nativeCurrencySymbol string) error
 	if len(options.Receiver) == 0 {
 		return errors.New("missing option: 'receiver'")
 	}
-	if isZeroAmount(options.Amount) {
+	if options.Sweep && !isZeroAmount(options.Amount) {
+		return errors.New("option 'amount' must not be set when 'sweep' is set")
+	}
+	if !options.Sweep && isZeroAmount(options.Amount) {
 		return errors.New("missing option: 'amount'")
 	}
 	if len(options.CurrencySymbol) == 0 {
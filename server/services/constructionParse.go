@@ -0,0 +1,40 @@
+package services
+
+import (
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+)
+
+// createOperationsFromPreparedTx understands the intent of a (well-formed) transaction, before its execution.
+// It handles native transfers, contract calls, (single or multiple) ESDT transfers and relayed transactions (V1, V2 and V3).
+// Fees are not accounted for.
+func (service *constructionService) createOperationsFromPreparedTx(tx *data.Transaction) ([]*types.Operation, error) {
+	transfers, err := service.extension.parseTransfersOfTransaction(tx.Sender, tx.Receiver, tx.Value, tx.Data, false)
+	if err != nil {
+		return nil, err
+	}
+
+	operations := make([]*types.Operation, 0, len(transfers)*2)
+
+	for _, transfer := range transfers {
+		operations = append(operations, service.extension.transferToOperations(transfer)...)
+	}
+
+	indexOperations(operations)
+
+	return operations, nil
+}
+
+// getSignersOfPreparedTx returns the sender, the guardian (if any) and the relayer (if any, for relayed V3 transactions).
+func getSignersOfPreparedTx(tx *data.Transaction) []*types.AccountIdentifier {
+	signers := []*types.AccountIdentifier{addressToAccountIdentifier(tx.Sender)}
+
+	if len(tx.GuardianAddr) > 0 {
+		signers = append(signers, addressToAccountIdentifier(tx.GuardianAddr))
+	}
+	if len(tx.RelayerAddr) > 0 {
+		signers = append(signers, addressToAccountIdentifier(tx.RelayerAddr))
+	}
+
+	return signers
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstructionService_CreateOperationsFromWellFormedPreparedTx(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	extension := newNetworkProviderExtension(networkProvider)
+	service := NewConstructionService(networkProvider).(*constructionService)
+
+	bobPubKeyHex := hex.EncodeToString(testscommon.TestPubKeyBob)
+	carolPubKeyHex := hex.EncodeToString(testscommon.TestPubKeyCarol)
+
+	t.Run("contract call, with value", func(t *testing.T) {
+		operations, err := service.createOperationsFromPreparedTx(&data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressOfContract,
+			Value:    "1000",
+			Data:     []byte("deposit@01"),
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, []*types.Operation{
+			{
+				OperationIdentifier: indexToOperationIdentifier(0),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToNativeAmount("-1000"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(1),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressOfContract),
+				Amount:              extension.valueToNativeAmount("1000"),
+			},
+		}, operations)
+	})
+
+	t.Run("contract call, without value", func(t *testing.T) {
+		operations, err := service.createOperationsFromPreparedTx(&data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressOfContract,
+			Value:    "0",
+			Data:     []byte("claim"),
+		})
+
+		require.Nil(t, err)
+		require.Empty(t, operations)
+	})
+
+	t.Run("ESDTNFTTransfer", func(t *testing.T) {
+		operations, err := service.createOperationsFromPreparedTx(&data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressAlice,
+			Value:    "0",
+			Data:     []byte("ESDTNFTTransfer@4558414d504c452d616263646566@0a@01@" + bobPubKeyHex),
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, []*types.Operation{
+			{
+				OperationIdentifier: indexToOperationIdentifier(0),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToCustomAmount("-1", "EXAMPLE-abcdef-0a"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(1),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressBob),
+				Amount:              extension.valueToCustomAmount("1", "EXAMPLE-abcdef-0a"),
+			},
+		}, operations)
+	})
+
+	t.Run("MultiESDTNFTTransfer, with native currency", func(t *testing.T) {
+		operations, err := service.createOperationsFromPreparedTx(&data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressAlice,
+			Value:    "0",
+			Data:     []byte("MultiESDTNFTTransfer@" + carolPubKeyHex + "@02@544553542d616263646566@@64@45474c442d303030303030@@03e8@666f6f"),
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, []*types.Operation{
+			{
+				OperationIdentifier: indexToOperationIdentifier(0),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToCustomAmount("-100", "TEST-abcdef"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(1),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressCarol),
+				Amount:              extension.valueToCustomAmount("100", "TEST-abcdef"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(2),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToNativeAmount("-1000"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(3),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressCarol),
+				Amount:              extension.valueToNativeAmount("1000"),
+			},
+		}, operations)
+	})
+
+	t.Run("relayed V1", func(t *testing.T) {
+		innerTx, _ := json.Marshal(map[string]interface{}{
+			"value":    big.NewInt(5),
+			"sender":   testscommon.TestPubKeyBob,
+			"receiver": testscommon.TestPubKeyCarol,
+			"data":     []byte("ESDTTransfer@544553542d616263646566@07"),
+		})
+
+		operations, err := service.createOperationsFromPreparedTx(&data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressBob,
+			Value:    "0",
+			Data:     []byte("relayedTx@" + hex.EncodeToString(innerTx)),
+		})
+
+		// Inner transaction has value, but calls a built-in function
+		require.ErrorIs(t, err, errValueNotAllowedWithBuiltInFunction)
+		require.Nil(t, operations)
+
+		innerTx, _ = json.Marshal(map[string]interface{}{
+			"value":    big.NewInt(5),
+			"sender":   testscommon.TestPubKeyBob,
+			"receiver": testscommon.TestPubKeyCarol,
+		})
+
+		operations, err = service.createOperationsFromPreparedTx(&data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressBob,
+			Value:    "5",
+			Data:     []byte("relayedTx@" + hex.EncodeToString(innerTx)),
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, []*types.Operation{
+			{
+				OperationIdentifier: indexToOperationIdentifier(0),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToNativeAmount("-5"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(1),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressBob),
+				Amount:              extension.valueToNativeAmount("5"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(2),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressBob),
+				Amount:              extension.valueToNativeAmount("-5"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(3),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressCarol),
+				Amount:              extension.valueToNativeAmount("5"),
+			},
+		}, operations)
+	})
+
+	t.Run("relayed V2", func(t *testing.T) {
+		innerData := hex.EncodeToString([]byte("ESDTTransfer@544553542d616263646566@07"))
+
+		operations, err := service.createOperationsFromPreparedTx(&data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressBob,
+			Value:    "0",
+			Data:     []byte("relayedTxV2@" + carolPubKeyHex + "@2a@" + innerData + "@aabb"),
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, []*types.Operation{
+			{
+				OperationIdentifier: indexToOperationIdentifier(0),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressBob),
+				Amount:              extension.valueToCustomAmount("-7", "TEST-abcdef"),
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(1),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressCarol),
+				Amount:              extension.valueToCustomAmount("7", "TEST-abcdef"),
+			},
+		}, operations)
+	})
+
+	t.Run("nested relayed", func(t *testing.T) {
+		innerData := hex.EncodeToString([]byte("relayedTxV2@" + carolPubKeyHex + "@2a@@aabb"))
+
+		_, err := service.createOperationsFromPreparedTx(&data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressBob,
+			Value:    "0",
+			Data:     []byte("relayedTxV2@" + carolPubKeyHex + "@2a@" + innerData + "@aabb"),
+		})
+
+		require.ErrorIs(t, err, errNestedRelayedTransaction)
+	})
+
+	t.Run("with value, calling a built-in function", func(t *testing.T) {
+		_, err := service.createOperationsFromPreparedTx(&data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressBob,
+			Value:    "1",
+			Data:     []byte("ESDTTransfer@544553542d616263646566@07"),
+		})
+
+		require.ErrorIs(t, err, errValueNotAllowedWithBuiltInFunction)
+	})
+
+	t.Run("contract deployment", func(t *testing.T) {
+		_, err := service.createOperationsFromPreparedTx(&data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: systemContractDeployAddress,
+			Value:    "0",
+			Data:     []byte("0061736d@0500@0506"),
+		})
+
+		require.ErrorIs(t, err, errCannotParseContractDeployment)
+	})
+
+	t.Run("malformed data", func(t *testing.T) {
+		_, err := service.createOperationsFromPreparedTx(&data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressBob,
+			Value:    "0",
+			Data:     []byte("ESDTTransfer@544553542d616263646566"),
+		})
+		require.ErrorIs(t, err, errMalformedTransactionData)
+
+		_, err = service.createOperationsFromPreparedTx(&data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressBob,
+			Value:    "0",
+			Data:     []byte("ESDTTransfer@xyz@07"),
+		})
+		require.ErrorIs(t, err, errMalformedTransactionData)
+
+		// Not sent to self
+		_, err = service.createOperationsFromPreparedTx(&data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressBob,
+			Value:    "0",
+			Data:     []byte("MultiESDTNFTTransfer@" + carolPubKeyHex + "@01@544553542d616263646566@@64"),
+		})
+		require.ErrorIs(t, err, errMalformedTransactionData)
+
+		// Too few transfers (with respect to the declared number)
+		_, err = service.createOperationsFromPreparedTx(&data.Transaction{
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressAlice,
+			Value:    "0",
+			Data:     []byte("MultiESDTNFTTransfer@" + carolPubKeyHex + "@02@544553542d616263646566@@64"),
+		})
+		require.ErrorIs(t, err, errMalformedTransactionData)
+	})
+}
+
+func TestGetSignersOfPreparedTx(t *testing.T) {
+	t.Parallel()
+
+	signers := getSignersOfPreparedTx(&data.Transaction{
+		Sender: testscommon.TestAddressAlice,
+	})
+	require.Equal(t, []*types.AccountIdentifier{addressToAccountIdentifier(testscommon.TestAddressAlice)}, signers)
+
+	signers = getSignersOfPreparedTx(&data.Transaction{
+		Sender:       testscommon.TestAddressAlice,
+		GuardianAddr: testscommon.TestAddressBob,
+		RelayerAddr:  testscommon.TestAddressCarol,
+	})
+	require.Equal(t, []*types.AccountIdentifier{
+		addressToAccountIdentifier(testscommon.TestAddressAlice),
+		addressToAccountIdentifier(testscommon.TestAddressBob),
+		addressToAccountIdentifier(testscommon.TestAddressCarol),
+	}, signers)
+}
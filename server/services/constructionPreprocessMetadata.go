@@ -8,6 +8,9 @@ type constructionPreprocessMetadata struct {
 	GasLimit       uint64 `json:"gasLimit"`
 	GasPrice       uint64 `json:"gasPrice"`
 	Data           []byte `json:"data"`
+	ReserveNonce   bool   `json:"reserveNonce,omitempty"`
+	SignWithHash   bool   `json:"signWithHash,omitempty"`
+	Sweep          bool   `json:"sweep,omitempty"`
 }
 
 func newConstructionPreprocessMetadata(obj objectsMap) (*constructionPreprocessMetadata, error) {
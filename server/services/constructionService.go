@@ -6,17 +6,21 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/coinbase/rosetta-sdk-go/server"
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-core-go/hashing"
+	"github.com/multiversx/mx-chain-core-go/hashing/keccak"
 	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
 )
 
 type constructionService struct {
-	provider   NetworkProvider
-	extension  *networkProviderExtension
-	errFactory *errFactory
+	provider          NetworkProvider
+	extension         *networkProviderExtension
+	errFactory        *errFactory
+	nonceReservations *nonceReservations
+	txSigningHasher   hashing.Hasher
 }
 
 // NewConstructionService creates a new instance of an constructionService
@@ -24,15 +28,17 @@ func NewConstructionService(
 	networkProvider NetworkProvider,
 ) server.ConstructionAPIServicer {
 	return &constructionService{
-		provider:   networkProvider,
-		extension:  newNetworkProviderExtension(networkProvider),
-		errFactory: newErrFactory(),
+		provider:          networkProvider,
+		extension:         newNetworkProviderExtension(networkProvider),
+		errFactory:        newErrFactory(),
+		nonceReservations: newNonceReservations(durationNonceReservation),
+		txSigningHasher:   keccak.NewKeccak(),
 	}
 }
 
 // ConstructionPreprocess determines which metadata is needed for construction
 func (service *constructionService) ConstructionPreprocess(
-	_ context.Context,
+	ctx context.Context,
 	request *types.ConstructionPreprocessRequest,
 ) (*types.ConstructionPreprocessResponse, *types.Error) {
 	log.Debug("constructionService.ConstructionPreprocess()", "metadata", request.Metadata)
@@ -67,7 +73,21 @@ func (service *constructionService) ConstructionPreprocess(
 		responseOptions.Receiver = request.Operations[1].Account.Address
 	}
 
-	if len(requestMetadata.Amount) > 0 {
+	// Sender and receiver might be given as hex-encoded public keys or usernames, as well. Options hold their canonical form (bech32).
+	responseOptions.Sender, err = service.extension.resolveAddress(ctx, responseOptions.Sender)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrInvalidAccountAddress, err)
+	}
+
+	responseOptions.Receiver, err = service.extension.resolveAddress(ctx, responseOptions.Receiver)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrInvalidAccountAddress, err)
+	}
+
+	if requestMetadata.Sweep {
+		// The amount is decided by /construction/metadata (the whole balance, minus the fee, if applicable).
+		responseOptions.Sweep = true
+	} else if len(requestMetadata.Amount) > 0 {
 		responseOptions.Amount = requestMetadata.Amount
 	} else {
 		// Fallback: get "amount" from the first operation
@@ -96,6 +116,12 @@ func (service *constructionService) ConstructionPreprocess(
 	if len(requestMetadata.Data) > 0 {
 		responseOptions.Data = requestMetadata.Data
 	}
+	if requestMetadata.ReserveNonce {
+		responseOptions.ReserveNonce = true
+	}
+	if requestMetadata.SignWithHash {
+		responseOptions.SignWithHash = true
+	}
 
 	err = responseOptions.validate(
 		service.extension.getNativeCurrencySymbol(),
@@ -116,7 +142,7 @@ func (service *constructionService) ConstructionPreprocess(
 
 // ConstructionMetadata gets any information required to construct a transaction for a specific network (e.g. the account nonce)
 func (service *constructionService) ConstructionMetadata(
-	_ context.Context,
+	ctx context.Context,
 	request *types.ConstructionMetadataRequest,
 ) (*types.ConstructionMetadataResponse, *types.Error) {
 	log.Debug("constructionService.ConstructionMetadata()", "options", request.Options)
@@ -130,13 +156,44 @@ func (service *constructionService) ConstructionMetadata(
 		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
 	}
 
-	account, err := service.provider.GetAccount(requestOptions.Sender)
+	requestOptions.Sender, err = service.extension.resolveAddress(ctx, requestOptions.Sender)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrInvalidAccountAddress, err)
+	}
+
+	requestOptions.Receiver, err = service.extension.resolveAddress(ctx, requestOptions.Receiver)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrInvalidAccountAddress, err)
+	}
+
+	account, err := service.provider.GetAccount(ctx, requestOptions.Sender)
 	if err != nil {
 		return nil, service.errFactory.newErrWithOriginal(ErrUnableToGetAccount, err)
 	}
 
+	// The account nonce (at the final block) lags behind the transactions already executed in non-final blocks (which aren't in the pool anymore).
+	latestAccountNonce, err := service.provider.GetAccountNonceOnLatestBlock(ctx, requestOptions.Sender)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrUnableToGetAccount, err)
+	}
+
+	accountNonce := account.Account.Nonce
+	if latestAccountNonce > accountNonce {
+		accountNonce = latestAccountNonce
+	}
+
+	if requestOptions.Sweep {
+		errTyped := service.prepareSweep(ctx, requestOptions, account.Account.Nonce, accountNonce)
+		if errTyped != nil {
+			return nil, errTyped
+		}
+	}
+
+	nonce, nonceGaps := service.decideNonce(ctx, requestOptions, accountNonce)
+
 	metadata := &constructionMetadata{
-		Nonce:          account.Account.Nonce,
+		Nonce:          nonce,
+		NonceGaps:      nonceGaps,
 		Sender:         requestOptions.Sender,
 		Receiver:       requestOptions.Receiver,
 		CurrencySymbol: requestOptions.CurrencySymbol,
@@ -144,6 +201,11 @@ func (service *constructionService) ConstructionMetadata(
 		Version:        transactionVersion,
 	}
 
+	if requestOptions.SignWithHash {
+		metadata.Version = transactionVersionWithOptions
+		metadata.Options = transactionOptionSignedWithHash
+	}
+
 	if service.extension.isNativeCurrencySymbol(requestOptions.CurrencySymbol) {
 		metadata.Amount = requestOptions.Amount
 		metadata.Data = requestOptions.Data
@@ -152,7 +214,7 @@ func (service *constructionService) ConstructionMetadata(
 		metadata.Data = service.computeDataForCustomCurrencyTransfer(requestOptions.CurrencySymbol, requestOptions.Amount)
 	}
 
-	fee, gasLimit, gasPrice, errTyped := service.computeFeeComponents(requestOptions, metadata.Data)
+	fee, gasLimit, gasPrice, errTyped := service.estimateFeeComponents(ctx, requestOptions, metadata)
 	if errTyped != nil {
 		return nil, errTyped
 	}
@@ -160,6 +222,13 @@ func (service *constructionService) ConstructionMetadata(
 	metadata.GasLimit = gasLimit
 	metadata.GasPrice = gasPrice
 
+	if requestOptions.Sweep {
+		errTyped = service.completeSweep(ctx, requestOptions, metadata, fee)
+		if errTyped != nil {
+			return nil, errTyped
+		}
+	}
+
 	metadataAsObjectsMap, err := toObjectsMap(metadata)
 	if err != nil {
 		return nil, service.errFactory.newErrWithOriginal(ErrConstruction, err)
@@ -202,7 +271,7 @@ func (service *constructionService) ConstructionPayloads(
 			{
 				AccountIdentifier: addressToAccountIdentifier(metadata.Sender),
 				SignatureType:     types.Ed25519,
-				Bytes:             txJson,
+				Bytes:             computeSigningPayload(service.txSigningHasher, metadata.Options, txJson),
 			},
 		},
 	}, nil
@@ -219,13 +288,14 @@ func (service *constructionService) ConstructionParse(
 		return nil, service.errFactory.newErrWithOriginal(ErrMalformedValue, err)
 	}
 
+	err = validateTransactionVersionAndOptions(tx.Version, tx.Options)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrMalformedValue, err)
+	}
+
 	var signers []*types.AccountIdentifier
 	if request.Signed {
-		signers = []*types.AccountIdentifier{
-			{
-				Address: tx.Sender,
-			},
-		}
+		signers = getSignersOfPreparedTx(tx)
 	}
 
 	operations, err := service.createOperationsFromPreparedTx(tx)
@@ -239,76 +309,6 @@ func (service *constructionService) ConstructionParse(
 	}, nil
 }
 
-func (service *constructionService) createOperationsFromPreparedTx(tx *data.Transaction) ([]*types.Operation, error) {
-	var operations []*types.Operation
-
-	isCustomCurrencyTransfer := isCustomCurrencyTransfer(string(tx.Data))
-
-	if isCustomCurrencyTransfer {
-		tokenIdentifier, amount, err := parseCustomCurrencyTransfer(string(tx.Data))
-		if err != nil {
-			return nil, err
-		}
-
-		operations = []*types.Operation{
-			{
-				Type:    opCustomTransfer,
-				Account: addressToAccountIdentifier(tx.Sender),
-				Amount:  service.extension.valueToCustomAmount("-"+amount, tokenIdentifier),
-			},
-			{
-				Type:    opCustomTransfer,
-				Account: addressToAccountIdentifier(tx.Receiver),
-				Amount:  service.extension.valueToCustomAmount(amount, tokenIdentifier),
-			},
-		}
-	} else {
-		// Native currency transfer
-		operations = []*types.Operation{
-			{
-				Type:    opTransfer,
-				Account: addressToAccountIdentifier(tx.Sender),
-				Amount:  service.extension.valueToNativeAmount("-" + tx.Value),
-			},
-			{
-				Type:    opTransfer,
-				Account: addressToAccountIdentifier(tx.Receiver),
-				Amount:  service.extension.valueToNativeAmount(tx.Value),
-			},
-		}
-	}
-
-	indexOperations(operations)
-
-	return operations, nil
-}
-
-func isCustomCurrencyTransfer(txData string) bool {
-	return strings.HasPrefix(txData, builtInFunctionESDTTransfer)
-}
-
-// parseCustomCurrencyTransfer parses a single ESDT transfer.
-// Other kinds of ESDT transfers are not supported, for now.
-func parseCustomCurrencyTransfer(txData string) (string, string, error) {
-	parts := strings.Split(txData, "@")
-
-	if len(parts) != 3 {
-		return "", "", errors.New("cannot parse data of custom currency transfer")
-	}
-
-	tokenIdentifierBytes, err := hex.DecodeString(parts[1])
-	if err != nil {
-		return "", "", errors.New("cannot decode custom token identifier")
-	}
-
-	amount, err := hexToAmount(parts[2])
-	if err != nil {
-		return "", "", errors.New("cannot decode custom token amount")
-	}
-
-	return string(tokenIdentifierBytes), amount, nil
-}
-
 func getTxFromRequest(txString string) (*data.Transaction, error) {
 	txBytes := []byte(txString)
 
@@ -335,7 +335,14 @@ func (service *constructionService) ConstructionCombine(
 		return nil, service.errFactory.newErr(ErrInvalidInputParam)
 	}
 
-	tx.Signature = hex.EncodeToString(request.Signatures[0].Bytes)
+	signature := request.Signatures[0]
+
+	err = service.verifySignatureOfPreparedTx(tx, signature)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrInvalidSignature, err)
+	}
+
+	tx.Signature = hex.EncodeToString(signature.Bytes)
 
 	signedTxBytes, err := json.Marshal(tx)
 	if err != nil {
@@ -347,6 +354,30 @@ func (service *constructionService) ConstructionCombine(
 	}, nil
 }
 
+// verifySignatureOfPreparedTx verifies the signature of the sender, without relying on the network (works in offline mode, as well).
+func (service *constructionService) verifySignatureOfPreparedTx(tx *data.Transaction, signature *types.Signature) error {
+	senderPubKey, err := service.provider.ConvertAddressToPubKey(tx.Sender)
+	if err != nil {
+		return fmt.Errorf("%w: cannot decode sender: %v", errInvalidSignature, err)
+	}
+
+	err = checkDeclaredSignerOfSignature(signature, tx.Sender, senderPubKey)
+	if err != nil {
+		return err
+	}
+
+	unsignedTx := *tx
+	unsignedTx.Signature = ""
+
+	unsignedTxJson, err := json.Marshal(&unsignedTx)
+	if err != nil {
+		return err
+	}
+
+	signingPayload := computeSigningPayload(service.txSigningHasher, tx.Options, unsignedTxJson)
+	return verifyTransactionSignature(senderPubKey, signingPayload, signature.Bytes)
+}
+
 // ConstructionDerive returns a bech32 address from public key bytes
 func (service *constructionService) ConstructionDerive(
 	_ context.Context,
@@ -375,6 +406,12 @@ func (service *constructionService) ConstructionHash(
 		return nil, service.errFactory.newErrWithOriginal(ErrMalformedValue, err)
 	}
 
+	// The hash covers the version and the options, as well (thus, it's consistent for transactions signed with hash).
+	err = validateTransactionVersionAndOptions(tx.Version, tx.Options)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrMalformedValue, err)
+	}
+
 	txHash, err := service.provider.ComputeTransactionHash(tx)
 	if err != nil {
 		return nil, service.errFactory.newErrWithOriginal(ErrMalformedValue, err)
@@ -389,7 +426,7 @@ func (service *constructionService) ConstructionHash(
 
 // ConstructionSubmit will submit transaction and return hash
 func (service *constructionService) ConstructionSubmit(
-	_ context.Context,
+	ctx context.Context,
 	request *types.ConstructionSubmitRequest,
 ) (*types.TransactionIdentifierResponse, *types.Error) {
 	log.Debug("constructionService.ConstructionSubmit()", "transaction", request.SignedTransaction)
@@ -403,7 +440,24 @@ func (service *constructionService) ConstructionSubmit(
 		return nil, service.errFactory.newErrWithOriginal(ErrMalformedValue, err)
 	}
 
-	txHash, err := service.provider.SendTransaction(tx)
+	// Resubmitting an already broadcasted transaction returns its hash (without simulating it again, since its nonce might be already consumed).
+	knownHash, ok := service.getHashOfTrackedSubmission(tx)
+	if ok {
+		return &types.TransactionIdentifierResponse{
+			TransactionIdentifier: &types.TransactionIdentifier{
+				Hash: knownHash,
+			},
+		}, nil
+	}
+
+	if service.provider.ShouldSimulateBeforeSubmit() {
+		errSimulation := service.simulateTransaction(ctx, tx)
+		if errSimulation != nil {
+			return nil, errSimulation
+		}
+	}
+
+	txHash, err := service.provider.SendTransaction(ctx, tx)
 	if err != nil {
 		return nil, service.errFactory.newErrWithOriginal(ErrUnableToSubmitTransaction, err)
 	}
@@ -414,3 +468,17 @@ func (service *constructionService) ConstructionSubmit(
 		},
 	}, nil
 }
+
+func (service *constructionService) getHashOfTrackedSubmission(tx *data.Transaction) (string, bool) {
+	hash, err := service.provider.ComputeTransactionHash(tx)
+	if err != nil {
+		return "", false
+	}
+
+	submission, ok := service.provider.GetSubmittedTransaction(hash)
+	if !ok || submission.Status == resources.SubmissionStatusDropped {
+		return "", false
+	}
+
+	return hash, true
+}
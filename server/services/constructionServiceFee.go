@@ -1,31 +1,95 @@
 package services
 
 import (
+	"context"
 	"math/big"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/provider"
 )
 
+// estimateFeeComponents estimates the fee, the gas limit and the gas price of the transaction described by the metadata.
+// When online, the estimation relies on the observer (simulation); otherwise, it falls back to the static model.
+// The static model is used when the gas limit is provided by the client, as well (there's nothing to estimate).
+func (service *constructionService) estimateFeeComponents(ctx context.Context, options *constructionOptions, metadata *constructionMetadata) (*big.Int, uint64, uint64, *types.Error) {
+	if service.provider.IsOffline() || options.GasLimit > 0 {
+		return service.computeFeeComponents(options, metadata.Data)
+	}
+
+	gasPrice := options.coalesceGasPrice(service.provider.GetNetworkConfig().MinGasPrice)
+	tx := metadata.toTransactionForGasEstimation(gasPrice)
+	return service.computeFeeComponentsBySimulation(ctx, options, tx)
+}
+
+// computeFeeComponents estimates the gas limit using a static model (given the network configuration).
 func (service *constructionService) computeFeeComponents(options *constructionOptions, computedData []byte) (*big.Int, uint64, uint64, *types.Error) {
 	networkConfig := service.provider.GetNetworkConfig()
-	minGasPrice := networkConfig.MinGasPrice
-	gasPriceModifier := networkConfig.GasPriceModifier
 
 	isForNativeCurrency := service.extension.isNativeCurrencySymbol(options.CurrencySymbol)
 	isForCustomCurrency := !isForNativeCurrency
 
-	movementGasLimit := networkConfig.MinGasLimit + networkConfig.GasPerDataByte*uint64(len(computedData))
+	movementGasLimit := service.computeMovementGasLimit(computedData)
 	executionGasLimit := uint64(0)
 	if isForCustomCurrency {
 		executionGasLimit = networkConfig.GasLimitCustomTransfer
 	}
 
 	estimatedGasLimit := movementGasLimit + executionGasLimit
+	return service.computeFeeComponentsGivenEstimation(options, movementGasLimit, executionGasLimit, estimatedGasLimit)
+}
+
+// computeFeeComponentsBySimulation estimates the gas limit by asking the observer to simulate the transaction.
+// A configurable safety margin is added on top of the suggested gas limit (but not on top of the suggested fee).
+// If the simulation cannot be performed (e.g. the observer is unavailable), it falls back to the static model,
+// unless the receiver is a contract (the static model cannot account for the execution). If the simulated execution fails, so would the transaction.
+func (service *constructionService) computeFeeComponentsBySimulation(ctx context.Context, options *constructionOptions, tx *data.Transaction) (*big.Int, uint64, uint64, *types.Error) {
+	networkConfig := service.provider.GetNetworkConfig()
+
+	simulatedGasLimit, err := service.provider.EstimateTransactionGas(ctx, tx)
+	if err != nil {
+		if provider.IsSimulatedExecutionFailedError(err) {
+			return nil, 0, 0, service.errFactory.newErrWithOriginal(ErrTransactionSimulationFailed, err)
+		}
+		if service.extension.isContractAddress(tx.Receiver) {
+			return nil, 0, 0, service.errFactory.newErrWithOriginal(ErrUnableToEstimateGasLimit, err)
+		}
+
+		log.Warn("constructionService.computeFeeComponentsBySimulation(): cannot simulate, falling back to the static model", "err", err)
+		return service.computeFeeComponents(options, tx.Data)
+	}
+
+	movementGasLimit := service.computeMovementGasLimit(tx.Data)
+	executionGasLimit := uint64(0)
+	if simulatedGasLimit > movementGasLimit {
+		executionGasLimit = simulatedGasLimit - movementGasLimit
+	}
+
+	estimatedGasLimit := movementGasLimit + executionGasLimit
+	estimatedGasLimitWithMargin := estimatedGasLimit + estimatedGasLimit*networkConfig.GasLimitEstimationMargin/100
+
+	return service.computeFeeComponentsGivenEstimation(options, movementGasLimit, executionGasLimit, estimatedGasLimitWithMargin)
+}
+
+func (service *constructionService) computeMovementGasLimit(computedData []byte) uint64 {
+	networkConfig := service.provider.GetNetworkConfig()
+	return networkConfig.MinGasLimit + networkConfig.GasPerDataByte*uint64(len(computedData))
+}
+
+func (service *constructionService) computeFeeComponentsGivenEstimation(
+	options *constructionOptions,
+	movementGasLimit uint64,
+	executionGasLimit uint64,
+	suggestedGasLimit uint64,
+) (*big.Int, uint64, uint64, *types.Error) {
+	networkConfig := service.provider.GetNetworkConfig()
+	minGasPrice := networkConfig.MinGasPrice
+	gasPriceModifier := networkConfig.GasPriceModifier
 
-	gasLimit := options.coalesceGasLimit(estimatedGasLimit)
+	gasLimit := options.coalesceGasLimit(suggestedGasLimit)
 	gasPrice := options.coalesceGasPrice(minGasPrice)
 
-	if gasLimit < estimatedGasLimit {
+	if gasLimit < movementGasLimit+executionGasLimit {
 		return nil, 0, 0, service.errFactory.newErr(ErrInsufficientGasLimit)
 	}
 	if gasPrice < minGasPrice {
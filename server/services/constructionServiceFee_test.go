@@ -1,9 +1,13 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"math/big"
 	"testing"
 
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/provider"
 	"github.com/multiversx/mx-chain-rosetta/testscommon"
 	"github.com/stretchr/testify/require"
 )
@@ -149,3 +153,132 @@ func TestComputeFee(t *testing.T) {
 	require.Equal(t, big.NewInt(70000000000000), computeFee(70000, 0, 1000000000, 0.01))
 	require.Equal(t, big.NewInt(60000000000000), computeFee(50000, 1000000, 1000000000, 0.01))
 }
+
+func TestConstructionService_ComputeFeeComponentsBySimulation(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockNetworkConfig.GasPriceModifier = 0.01
+	networkProvider.MockNetworkConfig.GasLimitEstimationMargin = 10
+	service := NewConstructionService(networkProvider).(*constructionService)
+
+	t.Run("contract call (without explicit gas limit)", func(t *testing.T) {
+		networkProvider.EstimateTransactionGasCalled = func(tx *data.Transaction) (uint64, error) {
+			return 5000000, nil
+		}
+
+		fee, gasLimit, gasPrice, err := service.computeFeeComponentsBySimulation(context.Background(), &constructionOptions{
+			GasPrice:       1000000000,
+			CurrencySymbol: "XeGLD",
+		}, &data.Transaction{Data: []byte("add@01")})
+
+		require.Nil(t, err)
+		// movement = 50000 + 6 * 1500 = 59000, execution = 5000000 - 59000 = 4941000
+		require.Equal(t, "108410000000000", fee.String())
+		require.Equal(t, uint64(5500000), gasLimit)
+		require.Equal(t, uint64(1000000000), gasPrice)
+	})
+
+	t.Run("contract call (with explicit gas limit, but insufficient)", func(t *testing.T) {
+		networkProvider.EstimateTransactionGasCalled = func(tx *data.Transaction) (uint64, error) {
+			return 5000000, nil
+		}
+
+		fee, gasLimit, gasPrice, err := service.computeFeeComponentsBySimulation(context.Background(), &constructionOptions{
+			GasLimit:       4000000,
+			GasPrice:       1000000000,
+			CurrencySymbol: "XeGLD",
+		}, &data.Transaction{Data: []byte("add@01")})
+
+		require.Equal(t, int32(ErrInsufficientGasLimit), err.Code)
+		require.Nil(t, fee)
+		require.Equal(t, uint64(0), gasLimit)
+		require.Equal(t, uint64(0), gasPrice)
+	})
+
+	t.Run("with failed (simulated) execution", func(t *testing.T) {
+		networkProvider.EstimateTransactionGasCalled = func(tx *data.Transaction) (uint64, error) {
+			return 0, provider.NewErrSimulatedExecutionFailed("function not found")
+		}
+
+		fee, _, _, err := service.computeFeeComponentsBySimulation(context.Background(), &constructionOptions{
+			GasPrice:       1000000000,
+			CurrencySymbol: "XeGLD",
+		}, &data.Transaction{Receiver: testscommon.TestAddressOfContract, Data: []byte("foobar")})
+
+		require.Equal(t, int32(ErrTransactionSimulationFailed), err.Code)
+		require.False(t, err.Retriable)
+		require.Nil(t, fee)
+	})
+
+	t.Run("with simulation error, for contract call", func(t *testing.T) {
+		networkProvider.EstimateTransactionGasCalled = func(tx *data.Transaction) (uint64, error) {
+			return 0, errors.New("arbitrary error")
+		}
+
+		fee, _, _, err := service.computeFeeComponentsBySimulation(context.Background(), &constructionOptions{
+			GasPrice:       1000000000,
+			CurrencySymbol: "XeGLD",
+		}, &data.Transaction{Receiver: testscommon.TestAddressOfContract, Data: []byte("foobar")})
+
+		require.Equal(t, int32(ErrUnableToEstimateGasLimit), err.Code)
+		require.True(t, err.Retriable)
+		require.Nil(t, fee)
+	})
+
+	t.Run("with simulation error, for transfer (falls back to the static model)", func(t *testing.T) {
+		networkProvider.EstimateTransactionGasCalled = func(tx *data.Transaction) (uint64, error) {
+			return 0, errors.New("arbitrary error")
+		}
+
+		fee, gasLimit, gasPrice, err := service.computeFeeComponentsBySimulation(context.Background(), &constructionOptions{
+			GasPrice:       1000000000,
+			CurrencySymbol: "XeGLD",
+		}, &data.Transaction{Receiver: testscommon.TestAddressBob, Data: []byte("hello")})
+
+		require.Nil(t, err)
+		// movement = 50000 + 5 * 1500 = 57500 (no margin)
+		require.Equal(t, "57500000000000", fee.String())
+		require.Equal(t, uint64(57500), gasLimit)
+		require.Equal(t, uint64(1000000000), gasPrice)
+	})
+}
+
+func TestConstructionService_EstimateFeeComponents(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	service := NewConstructionService(networkProvider).(*constructionService)
+
+	numSimulations := 0
+	networkProvider.EstimateTransactionGasCalled = func(tx *data.Transaction) (uint64, error) {
+		numSimulations++
+		return 5000000, nil
+	}
+
+	metadata := &constructionMetadata{
+		Sender:   testscommon.TestAddressAlice,
+		Receiver: testscommon.TestAddressOfContract,
+		Amount:   "0",
+		Data:     []byte("add@01"),
+		ChainID:  "T",
+		Version:  1,
+	}
+
+	// Without explicit gas limit
+	_, gasLimit, _, err := service.estimateFeeComponents(context.Background(), &constructionOptions{
+		CurrencySymbol: "XeGLD",
+	}, metadata)
+	require.Nil(t, err)
+	require.Equal(t, uint64(5000000), gasLimit)
+	require.Equal(t, 1, numSimulations)
+
+	// With explicit gas limit, the simulation is skipped
+	_, gasLimit, _, err = service.estimateFeeComponents(context.Background(), &constructionOptions{
+		GasLimit:       6000000,
+		CurrencySymbol: "XeGLD",
+	}, metadata)
+	require.Nil(t, err)
+	require.Equal(t, uint64(6000000), gasLimit)
+	require.Equal(t, 1, numSimulations)
+}
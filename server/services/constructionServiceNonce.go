@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+
+	"github.com/multiversx/mx-chain-proxy-go/data"
+)
+
+// decideNonce computes the next nonce of the sender, taking into account its transactions in the pool (and the nonces reserved by concurrent construction flows).
+// It also returns the nonce gaps (if any) of the sender, as seen in the pool.
+func (service *constructionService) decideNonce(ctx context.Context, options *constructionOptions, accountNonce uint64) (uint64, []data.NonceGap) {
+	poolNonces, err := service.provider.GetTransactionsPoolNoncesForSender(ctx, options.Sender)
+	if err != nil {
+		// Fallback: the pool is ignored (we only rely on the account nonce, at the final block).
+		log.Warn("constructionService.decideNonce(): cannot get pool nonces, will rely on the account nonce", "sender", options.Sender, "err", err)
+		poolNonces = nil
+	}
+
+	nonce := service.nonceReservations.decideNextNonce(options.Sender, accountNonce, poolNonces, options.ReserveNonce)
+	nonceGaps := computeNonceGaps(accountNonce, poolNonces)
+
+	log.Debug("constructionService.decideNonce()",
+		"sender", options.Sender,
+		"accountNonce", accountNonce,
+		"poolNonces", poolNonces,
+		"nonce", nonce,
+		"reserved", options.ReserveNonce,
+	)
+
+	return nonce, nonceGaps
+}
+
+// computeNonceGaps finds the missing nonces (between the account nonce and the highest nonce in the pool), given the sorted pool nonces
+func computeNonceGaps(accountNonce uint64, sortedPoolNonces []uint64) []data.NonceGap {
+	var nonceGaps []data.NonceGap
+
+	expectedNonce := accountNonce
+
+	for _, nonce := range sortedPoolNonces {
+		if nonce < expectedNonce {
+			// Duplicate or stale (already consumed) nonce.
+			continue
+		}
+
+		if nonce > expectedNonce {
+			nonceGaps = append(nonceGaps, data.NonceGap{
+				From: expectedNonce,
+				To:   nonce - 1,
+			})
+		}
+
+		expectedNonce = nonce + 1
+	}
+
+	return nonceGaps
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstructionService_ConstructionMetadataWithPoolNonces(t *testing.T) {
+	t.Parallel()
+
+	options := objectsMap{
+		"sender":         testscommon.TestAddressAlice,
+		"receiver":       testscommon.TestAddressBob,
+		"amount":         "1234",
+		"currencySymbol": "XeGLD",
+	}
+
+	optionsWithReservation := objectsMap{
+		"sender":         testscommon.TestAddressAlice,
+		"receiver":       testscommon.TestAddressBob,
+		"amount":         "1234",
+		"currencySymbol": "XeGLD",
+		"reserveNonce":   true,
+	}
+
+	getMetadata := func(t *testing.T, service *constructionService, options objectsMap) *constructionMetadata {
+		response, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: options,
+			},
+		)
+		require.Nil(t, errTyped)
+
+		metadata, err := newConstructionMetadata(response.Metadata)
+		require.Nil(t, err)
+		return metadata
+	}
+
+	t.Run("with transactions (and gaps) in pool", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockAccountsByAddress[testscommon.TestAddressAlice] = &resources.Account{
+			Address: testscommon.TestAddressAlice,
+			Nonce:   42,
+		}
+		networkProvider.MockPoolNoncesBySender[testscommon.TestAddressAlice] = []uint64{42, 43, 45, 48}
+
+		service := NewConstructionService(networkProvider).(*constructionService)
+
+		metadata := getMetadata(t, service, options)
+		require.Equal(t, uint64(44), metadata.Nonce)
+		require.Equal(t, []data.NonceGap{{From: 44, To: 44}, {From: 46, To: 47}}, metadata.NonceGaps)
+	})
+
+	t.Run("with reservations", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockAccountsByAddress[testscommon.TestAddressAlice] = &resources.Account{
+			Address: testscommon.TestAddressAlice,
+			Nonce:   42,
+		}
+		networkProvider.MockPoolNoncesBySender[testscommon.TestAddressAlice] = []uint64{42}
+
+		service := NewConstructionService(networkProvider).(*constructionService)
+
+		require.Equal(t, uint64(43), getMetadata(t, service, optionsWithReservation).Nonce)
+		require.Equal(t, uint64(44), getMetadata(t, service, optionsWithReservation).Nonce)
+		require.Equal(t, uint64(45), getMetadata(t, service, options).Nonce)
+		require.Equal(t, uint64(45), getMetadata(t, service, options).Nonce)
+	})
+
+	t.Run("with transactions executed in non-final blocks (empty pool)", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockAccountsByAddress[testscommon.TestAddressAlice] = &resources.Account{
+			Address: testscommon.TestAddressAlice,
+			Nonce:   42,
+		}
+		networkProvider.MockLatestNoncesByAddress[testscommon.TestAddressAlice] = 44
+
+		service := NewConstructionService(networkProvider).(*constructionService)
+
+		metadata := getMetadata(t, service, options)
+		require.Equal(t, uint64(44), metadata.Nonce)
+		require.Nil(t, metadata.NonceGaps)
+	})
+
+	t.Run("with transactions executed in non-final blocks, and more in pool", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockAccountsByAddress[testscommon.TestAddressAlice] = &resources.Account{
+			Address: testscommon.TestAddressAlice,
+			Nonce:   42,
+		}
+		networkProvider.MockLatestNoncesByAddress[testscommon.TestAddressAlice] = 44
+		networkProvider.MockPoolNoncesBySender[testscommon.TestAddressAlice] = []uint64{44, 45}
+
+		service := NewConstructionService(networkProvider).(*constructionService)
+
+		metadata := getMetadata(t, service, options)
+		require.Equal(t, uint64(46), metadata.Nonce)
+		require.Nil(t, metadata.NonceGaps)
+	})
+}
+
+func TestComputeNonceGaps(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, computeNonceGaps(42, nil))
+	require.Nil(t, computeNonceGaps(42, []uint64{42, 43, 44}))
+	require.Nil(t, computeNonceGaps(42, []uint64{40, 41, 42}))
+	require.Equal(t, []data.NonceGap{{From: 42, To: 43}}, computeNonceGaps(42, []uint64{44}))
+	require.Equal(t, []data.NonceGap{{From: 43, To: 43}, {From: 45, To: 49}}, computeNonceGaps(42, []uint64{42, 44, 44, 50}))
+}
+
+func TestConstructionService_DecideNonceWhenPoolIsUnavailable(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	service := NewConstructionService(networkProvider).(*constructionService)
+
+	networkProvider.MockNextError = errors.New("arbitrary error")
+
+	nonce, nonceGaps := service.decideNonce(context.Background(), &constructionOptions{Sender: testscommon.TestAddressAlice}, 42)
+	require.Equal(t, uint64(42), nonce)
+	require.Nil(t, nonceGaps)
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+)
+
+// simulateTransaction dry-runs the (signed) transaction against the observer.
+// If the simulation fails, an error holding the outcome (as details) is returned, so that the transaction isn't broadcasted.
+func (service *constructionService) simulateTransaction(ctx context.Context, tx *data.Transaction) *types.Error {
+	results, err := service.provider.SimulateTransaction(ctx, tx)
+	if err != nil {
+		return service.errFactory.newErrWithOriginal(ErrUnableToSimulateTransaction, err)
+	}
+
+	if isSimulationSuccessful(results) {
+		return nil
+	}
+
+	log.Debug("constructionService.simulateTransaction(): simulation failed",
+		"sender", tx.Sender,
+		"nonce", tx.Nonce,
+		"status", results.Status,
+		"failReason", results.FailReason,
+	)
+
+	errSimulation := service.errFactory.newErr(ErrTransactionSimulationFailed)
+	errSimulation.Details = simulationResultsToErrorDetails(tx, results)
+	return errSimulation
+}
+
+func isSimulationSuccessful(results *resources.TransactionSimulationResults) bool {
+	if len(results.FailReason) > 0 {
+		return false
+	}
+
+	return results.Status != transaction.TxStatusFail && results.Status != transaction.TxStatusInvalid
+}
+
+func simulationResultsToErrorDetails(tx *data.Transaction, results *resources.TransactionSimulationResults) map[string]interface{} {
+	details := map[string]interface{}{
+		"status":     string(results.Status),
+		"failReason": results.FailReason,
+	}
+
+	if results.VMOutput != nil {
+		if tx.GasLimit >= results.VMOutput.GasRemaining {
+			details["gasUsed"] = tx.GasLimit - results.VMOutput.GasRemaining
+		}
+
+		details["returnCode"] = results.VMOutput.ReturnCode
+		details["returnMessage"] = results.VMOutput.ReturnMessage
+	}
+
+	// Map iteration order is random, thus we sort the results by hash (for a deterministic output).
+	scrHashes := make([]string, 0, len(results.ScResults))
+	for hash := range results.ScResults {
+		scrHashes = append(scrHashes, hash)
+	}
+	sort.Strings(scrHashes)
+
+	scrs := make([]map[string]interface{}, 0, len(scrHashes))
+	for _, hash := range scrHashes {
+		scr := results.ScResults[hash]
+		if scr == nil {
+			continue
+		}
+
+		scrs = append(scrs, map[string]interface{}{
+			"hash":          hash,
+			"sender":        scr.SndAddr,
+			"receiver":      scr.RcvAddr,
+			"value":         scr.Value.String(),
+			"data":          scr.Data,
+			"returnMessage": scr.ReturnMessage,
+		})
+	}
+
+	details["smartContractResults"] = scrs
+	return details
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstructionService_ConstructionSubmitWithSimulation(t *testing.T) {
+	t.Parallel()
+
+	signedTx := `{"nonce":42,"value":"0","receiver":"erd1qqqqqqqqqqqqqpgqfejaxfh4ktp8mh8s77pl90dq0uzvh2vk396qlcwepw","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1000000000,"gasLimit":5000000,"data":"YWRkQDAx","signature":"aabb","chainID":"T","version":1}`
+
+	t.Run("with successful simulation", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockShouldSimulateBeforeSubmit = true
+
+		numSent := 0
+		networkProvider.SendTransactionCalled = func(tx *data.Transaction) (string, error) {
+			numSent++
+			return "aaaa", nil
+		}
+
+		service := NewConstructionService(networkProvider)
+
+		response, errTyped := service.ConstructionSubmit(context.Background(),
+			&types.ConstructionSubmitRequest{
+				SignedTransaction: signedTx,
+			},
+		)
+		require.Nil(t, errTyped)
+		require.Equal(t, "aaaa", response.TransactionIdentifier.Hash)
+		require.Equal(t, 1, numSent)
+	})
+
+	t.Run("with failed simulation", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockShouldSimulateBeforeSubmit = true
+
+		networkProvider.SimulateTransactionCalled = func(tx *data.Transaction) (*resources.TransactionSimulationResults, error) {
+			require.Equal(t, uint64(42), tx.Nonce)
+
+			results := &resources.TransactionSimulationResults{
+				VMOutput: &resources.TransactionSimulationVMOutput{
+					ReturnCode:    "user error",
+					ReturnMessage: "not enough tokens",
+					GasRemaining:  3000000,
+				},
+			}
+			results.Status = transaction.TxStatusFail
+			results.FailReason = "not enough tokens"
+			results.ScResults = map[string]*transaction.ApiSmartContractResult{
+				"bbbb": {
+					SndAddr:       testscommon.TestAddressOfContract,
+					RcvAddr:       testscommon.TestAddressAlice,
+					Value:         big.NewInt(0),
+					Data:          "@04@6e6f7420656e6f75676820746f6b656e73",
+					ReturnMessage: "not enough tokens",
+				},
+			}
+
+			return results, nil
+		}
+
+		networkProvider.SendTransactionCalled = func(tx *data.Transaction) (string, error) {
+			require.Fail(t, "transaction should not be broadcasted")
+			return "", nil
+		}
+
+		service := NewConstructionService(networkProvider)
+
+		response, errTyped := service.ConstructionSubmit(context.Background(),
+			&types.ConstructionSubmitRequest{
+				SignedTransaction: signedTx,
+			},
+		)
+		require.Nil(t, response)
+		require.Equal(t, int32(ErrTransactionSimulationFailed), errTyped.Code)
+		require.False(t, errTyped.Retriable)
+		require.Equal(t, "fail", errTyped.Details["status"])
+		require.Equal(t, "not enough tokens", errTyped.Details["failReason"])
+		require.Equal(t, uint64(2000000), errTyped.Details["gasUsed"])
+		require.Equal(t, "user error", errTyped.Details["returnCode"])
+
+		scrs := errTyped.Details["smartContractResults"].([]map[string]interface{})
+		require.Len(t, scrs, 1)
+		require.Equal(t, "bbbb", scrs[0]["hash"])
+		require.Equal(t, testscommon.TestAddressAlice, scrs[0]["receiver"])
+		require.Equal(t, "not enough tokens", scrs[0]["returnMessage"])
+	})
+
+	t.Run("with simulation error", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockShouldSimulateBeforeSubmit = true
+
+		networkProvider.SimulateTransactionCalled = func(tx *data.Transaction) (*resources.TransactionSimulationResults, error) {
+			return nil, errors.New("arbitrary error")
+		}
+
+		service := NewConstructionService(networkProvider)
+
+		response, errTyped := service.ConstructionSubmit(context.Background(),
+			&types.ConstructionSubmitRequest{
+				SignedTransaction: signedTx,
+			},
+		)
+		require.Nil(t, response)
+		require.Equal(t, int32(ErrUnableToSimulateTransaction), errTyped.Code)
+		require.True(t, errTyped.Retriable)
+	})
+
+	t.Run("with already submitted transaction", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockShouldSimulateBeforeSubmit = true
+		networkProvider.MockComputedTransactionHash = "aaaa"
+		networkProvider.MockSubmittedTransactions["aaaa"] = &resources.SubmittedTransaction{
+			Hash:   "aaaa",
+			Status: resources.SubmissionStatusLanded,
+		}
+
+		networkProvider.SimulateTransactionCalled = func(tx *data.Transaction) (*resources.TransactionSimulationResults, error) {
+			require.Fail(t, "transaction should not be simulated")
+			return nil, nil
+		}
+		networkProvider.SendTransactionCalled = func(tx *data.Transaction) (string, error) {
+			require.Fail(t, "transaction should not be sent")
+			return "", nil
+		}
+
+		service := NewConstructionService(networkProvider)
+
+		response, errTyped := service.ConstructionSubmit(context.Background(),
+			&types.ConstructionSubmitRequest{
+				SignedTransaction: signedTx,
+			},
+		)
+		require.Nil(t, errTyped)
+		require.Equal(t, "aaaa", response.TransactionIdentifier.Hash)
+	})
+
+	t.Run("with dropped transaction, submitted again", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockShouldSimulateBeforeSubmit = true
+		networkProvider.MockComputedTransactionHash = "aaaa"
+		networkProvider.MockSubmittedTransactions["aaaa"] = &resources.SubmittedTransaction{
+			Hash:   "aaaa",
+			Status: resources.SubmissionStatusDropped,
+		}
+
+		numSimulated := 0
+		networkProvider.SimulateTransactionCalled = func(tx *data.Transaction) (*resources.TransactionSimulationResults, error) {
+			numSimulated++
+			return &resources.TransactionSimulationResults{}, nil
+		}
+
+		service := NewConstructionService(networkProvider)
+
+		response, errTyped := service.ConstructionSubmit(context.Background(),
+			&types.ConstructionSubmitRequest{
+				SignedTransaction: signedTx,
+			},
+		)
+		require.Nil(t, errTyped)
+		require.Equal(t, "aaaa", response.TransactionIdentifier.Hash)
+		require.Equal(t, 1, numSimulated)
+	})
+
+	t.Run("with simulation disabled", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockShouldSimulateBeforeSubmit = false
+
+		networkProvider.SimulateTransactionCalled = func(tx *data.Transaction) (*resources.TransactionSimulationResults, error) {
+			require.Fail(t, "transaction should not be simulated")
+			return nil, nil
+		}
+
+		service := NewConstructionService(networkProvider)
+
+		response, errTyped := service.ConstructionSubmit(context.Background(),
+			&types.ConstructionSubmitRequest{
+				SignedTransaction: signedTx,
+			},
+		)
+		require.Nil(t, errTyped)
+		require.Equal(t, emptyHash, response.TransactionIdentifier.Hash)
+	})
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+)
+
+// prepareSweep decides the amount to be transferred in "sweep" mode, before estimating the fee:
+//   - for custom currencies, the whole token balance (at the latest final block)
+//   - for the native currency, the amount depends on the fee, thus it's decided afterwards (see completeSweep); meanwhile, zero is used
+//
+// Sweeping is refused while the sender has pending transactions (in the pool, or executed in blocks that aren't final yet), since their value and fees aren't reflected by the final balance.
+func (service *constructionService) prepareSweep(ctx context.Context, options *constructionOptions, finalAccountNonce uint64, accountNonce uint64) *types.Error {
+	if accountNonce > finalAccountNonce {
+		return service.errFactory.newErrWithOriginal(ErrSenderHasPendingTransactions,
+			fmt.Errorf("cannot sweep while the sender has transactions in non-final blocks, final nonce = %d, latest nonce = %d", finalAccountNonce, accountNonce),
+		)
+	}
+
+	poolNonces, err := service.provider.GetTransactionsPoolNoncesForSender(ctx, options.Sender)
+	if err != nil {
+		return service.errFactory.newErrWithOriginal(ErrUnableToGetMempool, err)
+	}
+
+	for _, nonce := range poolNonces {
+		// Stale (already consumed) nonces are ignored.
+		if nonce >= accountNonce {
+			return service.errFactory.newErrWithOriginal(ErrSenderHasPendingTransactions,
+				fmt.Errorf("cannot sweep while the sender has pending transactions, first nonce in pool = %d", nonce),
+			)
+		}
+	}
+
+	if service.extension.isNativeCurrencySymbol(options.CurrencySymbol) {
+		options.Amount = amountZero
+		return nil
+	}
+
+	tokenBalance, err := service.getFinalBalance(ctx, options.Sender, options.CurrencySymbol)
+	if err != nil {
+		return service.errFactory.newErrWithOriginal(ErrUnableToGetAccount, err)
+	}
+	if tokenBalance.Sign() <= 0 {
+		return service.errFactory.newErrWithOriginal(ErrInsufficientBalance,
+			fmt.Errorf("nothing to sweep, balance of %s is zero", options.CurrencySymbol),
+		)
+	}
+
+	options.Amount = tokenBalance.String()
+	return nil
+}
+
+// completeSweep checks that the native balance of the sender (at the latest final block) covers the (estimated) fee.
+// For the native currency, it sets the amount to be transferred: the whole balance, minus the maximum fee.
+// The maximum fee is "gasLimit * gasPrice", which is what the protocol requires the sender to hold (on top of the value), before execution.
+// Any unused gas is refunded to the sender afterwards; thus, if nothing is executed (plain transfer), the gas limit is set to the movement gas
+// (without the estimation margin, unless provided by the client), so that the fee is exactly "gasLimit * gasPrice" and nothing is left behind.
+func (service *constructionService) completeSweep(ctx context.Context, options *constructionOptions, metadata *constructionMetadata, fee *big.Int) *types.Error {
+	nativeBalance, err := service.getFinalBalance(ctx, options.Sender, service.extension.getNativeCurrencySymbol())
+	if err != nil {
+		return service.errFactory.newErrWithOriginal(ErrUnableToGetAccount, err)
+	}
+
+	if service.extension.isNativeCurrencySymbol(options.CurrencySymbol) && options.GasLimit == 0 {
+		movementGasLimit := service.computeMovementGasLimit(metadata.Data)
+		isPlainTransfer := fee.Cmp(multiplyUint64(movementGasLimit, metadata.GasPrice)) == 0
+		if isPlainTransfer {
+			metadata.GasLimit = movementGasLimit
+		}
+	}
+
+	maxFee := multiplyUint64(metadata.GasLimit, metadata.GasPrice)
+
+	if !service.extension.isNativeCurrencySymbol(options.CurrencySymbol) {
+		if nativeBalance.Cmp(maxFee) < 0 {
+			return service.errFactory.newErrWithOriginal(ErrInsufficientBalance,
+				fmt.Errorf("balance (%s) does not cover the fee (%s)", nativeBalance, maxFee),
+			)
+		}
+
+		return nil
+	}
+
+	amount := big.NewInt(0).Sub(nativeBalance, maxFee)
+	if amount.Sign() <= 0 {
+		return service.errFactory.newErrWithOriginal(ErrInsufficientBalance,
+			fmt.Errorf("balance (%s) does not cover the fee (%s)", nativeBalance, maxFee),
+		)
+	}
+
+	options.Amount = amount.String()
+	metadata.Amount = amount.String()
+	return nil
+}
+
+func (service *constructionService) getFinalBalance(ctx context.Context, address string, currencySymbol string) (*big.Int, error) {
+	balance, err := service.provider.GetAccountBalance(ctx, address, currencySymbol, resources.NewAccountQueryOptionsOnFinalBlock())
+	if err != nil {
+		return nil, err
+	}
+
+	balanceBig, ok := big.NewInt(0).SetString(balance.Balance, 10)
+	if !ok {
+		return nil, fmt.Errorf("cannot parse balance: %s", balance.Balance)
+	}
+
+	return balanceBig, nil
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstructionService_ConstructionPreprocessWithSweep(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	service := NewConstructionService(networkProvider)
+
+	response, errTyped := service.ConstructionPreprocess(context.Background(),
+		&types.ConstructionPreprocessRequest{
+			Metadata: objectsMap{
+				"sender":         testscommon.TestAddressAlice,
+				"receiver":       testscommon.TestAddressBob,
+				"currencySymbol": "XeGLD",
+				"sweep":          true,
+			},
+		},
+	)
+	require.Nil(t, errTyped)
+	require.Equal(t, true, response.Options["sweep"])
+	require.Equal(t, "", response.Options["amount"])
+}
+
+func TestConstructionService_ConstructionMetadataWithSweep(t *testing.T) {
+	t.Parallel()
+
+	createService := func(nativeBalance string, tokenBalance string, poolNonces []uint64, gasLimitEstimationMargin uint64) *constructionService {
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockAccountsByAddress[testscommon.TestAddressAlice] = &resources.Account{
+			Address: testscommon.TestAddressAlice,
+			Nonce:   42,
+		}
+		networkProvider.MockAccountsNativeBalances[testscommon.TestAddressAlice] = &resources.AccountBalanceOnBlock{
+			Balance: nativeBalance,
+		}
+		networkProvider.MockAccountsCustomBalances[testscommon.TestAddressAlice+"_TEST-abcdef"] = &resources.AccountBalanceOnBlock{
+			Balance: tokenBalance,
+		}
+
+		networkProvider.MockPoolNoncesBySender[testscommon.TestAddressAlice] = poolNonces
+		networkProvider.MockNetworkConfig.GasLimitEstimationMargin = gasLimitEstimationMargin
+
+		return NewConstructionService(networkProvider).(*constructionService)
+	}
+
+	getMetadata := func(service *constructionService, currencySymbol string) (*constructionMetadata, *types.Error) {
+		response, errTyped := service.ConstructionMetadata(context.Background(),
+			&types.ConstructionMetadataRequest{
+				Options: objectsMap{
+					"sender":         testscommon.TestAddressAlice,
+					"receiver":       testscommon.TestAddressBob,
+					"currencySymbol": currencySymbol,
+					"sweep":          true,
+				},
+			},
+		)
+		if errTyped != nil {
+			return nil, errTyped
+		}
+
+		metadata := &constructionMetadata{}
+		err := fromObjectsMap(response.Metadata, metadata)
+		require.Nil(t, err)
+		return metadata, nil
+	}
+
+	t.Run("native currency", func(t *testing.T) {
+		t.Parallel()
+
+		service := createService("1000000000000000000", "0", nil, 0)
+		metadata, errTyped := getMetadata(service, "XeGLD")
+		require.Nil(t, errTyped)
+		require.Equal(t, "999950000000000000", metadata.Amount)
+		require.Equal(t, uint64(50000), metadata.GasLimit)
+		require.Equal(t, uint64(1000000000), metadata.GasPrice)
+	})
+
+	t.Run("native currency, with gas limit estimation margin", func(t *testing.T) {
+		t.Parallel()
+
+		service := createService("1000000000000000000", "0", nil, 10)
+
+		metadata, errTyped := getMetadata(service, "XeGLD")
+		require.Nil(t, errTyped)
+		// Nothing is executed, thus the margin isn't needed (it would be left behind, otherwise).
+		require.Equal(t, "999950000000000000", metadata.Amount)
+		require.Equal(t, uint64(50000), metadata.GasLimit)
+
+		// Nothing remains: "value + gasLimit * gasPrice" is exactly the balance.
+		amount, _ := big.NewInt(0).SetString(metadata.Amount, 10)
+		maxFee := multiplyUint64(metadata.GasLimit, metadata.GasPrice)
+		require.Equal(t, "1000000000000000000", big.NewInt(0).Add(amount, maxFee).String())
+	})
+
+	t.Run("native currency, with pending transactions", func(t *testing.T) {
+		t.Parallel()
+
+		service := createService("1000000000000000000", "0", []uint64{42, 43}, 0)
+
+		metadata, errTyped := getMetadata(service, "XeGLD")
+		require.Nil(t, metadata)
+		require.Equal(t, int32(ErrSenderHasPendingTransactions), errTyped.Code)
+		require.True(t, errTyped.Retriable)
+	})
+
+	t.Run("native currency, with transactions in non-final blocks", func(t *testing.T) {
+		t.Parallel()
+
+		networkProvider := testscommon.NewNetworkProviderMock()
+		networkProvider.MockAccountsByAddress[testscommon.TestAddressAlice] = &resources.Account{
+			Address: testscommon.TestAddressAlice,
+			Nonce:   42,
+		}
+		networkProvider.MockLatestNoncesByAddress[testscommon.TestAddressAlice] = 43
+		networkProvider.MockAccountsNativeBalances[testscommon.TestAddressAlice] = &resources.AccountBalanceOnBlock{
+			Balance: "1000000000000000000",
+		}
+
+		service := NewConstructionService(networkProvider).(*constructionService)
+
+		metadata, errTyped := getMetadata(service, "XeGLD")
+		require.Nil(t, metadata)
+		require.Equal(t, int32(ErrSenderHasPendingTransactions), errTyped.Code)
+	})
+
+	t.Run("native currency, with stale pool nonces", func(t *testing.T) {
+		t.Parallel()
+
+		service := createService("1000000000000000000", "0", []uint64{40, 41}, 0)
+
+		metadata, errTyped := getMetadata(service, "XeGLD")
+		require.Nil(t, errTyped)
+		require.Equal(t, "999950000000000000", metadata.Amount)
+	})
+
+	t.Run("native currency, balance does not cover the fee", func(t *testing.T) {
+		t.Parallel()
+
+		service := createService("50000000000000", "0", nil, 0)
+		metadata, errTyped := getMetadata(service, "XeGLD")
+		require.Nil(t, metadata)
+		require.Equal(t, int32(ErrInsufficientBalance), errTyped.Code)
+	})
+
+	t.Run("custom currency", func(t *testing.T) {
+		t.Parallel()
+
+		service := createService("1000000000000000000", "1000", nil, 0)
+		metadata, errTyped := getMetadata(service, "TEST-abcdef")
+		require.Nil(t, errTyped)
+		require.Equal(t, "0", metadata.Amount)
+		require.Equal(t, []byte("ESDTTransfer@544553542d616263646566@03e8"), metadata.Data)
+		require.Equal(t, uint64(310000), metadata.GasLimit)
+	})
+
+	t.Run("custom currency, native balance does not cover the fee", func(t *testing.T) {
+		t.Parallel()
+
+		service := createService("300000000000000", "1000", nil, 0)
+		metadata, errTyped := getMetadata(service, "TEST-abcdef")
+		require.Nil(t, metadata)
+		require.Equal(t, int32(ErrInsufficientBalance), errTyped.Code)
+	})
+
+	t.Run("custom currency, nothing to sweep", func(t *testing.T) {
+		t.Parallel()
+
+		service := createService("1000000000000000000", "0", nil, 0)
+		metadata, errTyped := getMetadata(service, "TEST-abcdef")
+		require.Nil(t, metadata)
+		require.Equal(t, int32(ErrInsufficientBalance), errTyped.Code)
+	})
+}
@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"crypto/ed25519"
+	"strings"
 	"testing"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
@@ -525,7 +527,7 @@ func TestConstructionService_ConstructionParse(t *testing.T) {
 	})
 
 	t.Run("custom transfer", func(t *testing.T) {
-		notSignedTx := `{"nonce":42,"value":"1234","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1100000000,"gasLimit":57500,"data":"RVNEVFRyYW5zZmVyQDU0NDU1MzU0MmQ2MTYyNjM2NDY1NjZAMDRkMg==","chainID":"T","version":1}`
+		notSignedTx := `{"nonce":42,"value":"0","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1100000000,"gasLimit":57500,"data":"RVNEVFRyYW5zZmVyQDU0NDU1MzU0MmQ2MTYyNjM2NDY1NjZAMDRkMg==","chainID":"T","version":1}`
 
 		operations := []*types.Operation{
 			{
@@ -562,22 +564,105 @@ func TestConstructionService_ConstructionCombine(t *testing.T) {
 	service := NewConstructionService(networkProvider)
 
 	notSignedTx := `{"nonce":42,"value":"1234","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1100000000,"gasLimit":57500,"data":"aGVsbG8=","chainID":"T","version":1}`
+	signature := ed25519.Sign(testscommon.TestPrivateKeyAlice, []byte(notSignedTx))
+
+	t.Run("with valid signature", func(t *testing.T) {
+		response, errTyped := service.ConstructionCombine(context.Background(),
+			&types.ConstructionCombineRequest{
+				UnsignedTransaction: notSignedTx,
+				Signatures: []*types.Signature{
+					{
+						SigningPayload: &types.SigningPayload{
+							AccountIdentifier: addressToAccountIdentifier(testscommon.TestAddressAlice),
+						},
+						PublicKey: &types.PublicKey{
+							Bytes:     testscommon.TestPubKeyAlice,
+							CurveType: types.Edwards25519,
+						},
+						SignatureType: types.Ed25519,
+						Bytes:         signature,
+					},
+				},
+			},
+		)
 
-	response, errTyped := service.ConstructionCombine(context.Background(),
-		&types.ConstructionCombineRequest{
-			UnsignedTransaction: notSignedTx,
-			Signatures: []*types.Signature{
-				{
-					Bytes: []byte{0xaa, 0xbb},
+		signedTx := `{"nonce":42,"value":"1234","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1100000000,"gasLimit":57500,"data":"aGVsbG8=","signature":"c4c20036bf66260ae483928248b2867a767053e9b667f8bb1d0997493d8b80fc6ecb365696559311025cc34a848ec65f37558727e5e89c93c39f9c365f2bcc00","chainID":"T","version":1}`
+
+		require.Nil(t, errTyped)
+		require.Equal(t, signedTx, response.SignedTransaction)
+	})
+
+	t.Run("with corrupted signature", func(t *testing.T) {
+		corruptedSignature := append([]byte{}, signature...)
+		corruptedSignature[0] ^= 0xff
+
+		response, errTyped := service.ConstructionCombine(context.Background(),
+			&types.ConstructionCombineRequest{
+				UnsignedTransaction: notSignedTx,
+				Signatures: []*types.Signature{
+					{
+						Bytes: corruptedSignature,
+					},
 				},
 			},
-		},
-	)
+		)
 
-	signedTx := `{"nonce":42,"value":"1234","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1100000000,"gasLimit":57500,"data":"aGVsbG8=","signature":"aabb","chainID":"T","version":1}`
+		require.Nil(t, response)
+		require.Equal(t, int32(ErrInvalidSignature), errTyped.Code)
+		require.False(t, errTyped.Retriable)
+	})
 
-	require.Nil(t, errTyped)
-	require.Equal(t, signedTx, response.SignedTransaction)
+	t.Run("with signature of another transaction", func(t *testing.T) {
+		response, errTyped := service.ConstructionCombine(context.Background(),
+			&types.ConstructionCombineRequest{
+				UnsignedTransaction: strings.Replace(notSignedTx, `"nonce":42`, `"nonce":43`, 1),
+				Signatures: []*types.Signature{
+					{
+						Bytes: signature,
+					},
+				},
+			},
+		)
+
+		require.Nil(t, response)
+		require.Equal(t, int32(ErrInvalidSignature), errTyped.Code)
+	})
+
+	t.Run("with unexpected signer", func(t *testing.T) {
+		response, errTyped := service.ConstructionCombine(context.Background(),
+			&types.ConstructionCombineRequest{
+				UnsignedTransaction: notSignedTx,
+				Signatures: []*types.Signature{
+					{
+						SigningPayload: &types.SigningPayload{
+							AccountIdentifier: addressToAccountIdentifier(testscommon.TestAddressBob),
+						},
+						Bytes: signature,
+					},
+				},
+			},
+		)
+
+		require.Nil(t, response)
+		require.Equal(t, int32(ErrInvalidSignature), errTyped.Code)
+		require.Contains(t, errTyped.Details["originalError"], "unexpected signer")
+	})
+
+	t.Run("with bad signature length", func(t *testing.T) {
+		response, errTyped := service.ConstructionCombine(context.Background(),
+			&types.ConstructionCombineRequest{
+				UnsignedTransaction: notSignedTx,
+				Signatures: []*types.Signature{
+					{
+						Bytes: []byte{0xaa, 0xbb},
+					},
+				},
+			},
+		)
+
+		require.Nil(t, response)
+		require.Equal(t, int32(ErrInvalidSignature), errTyped.Code)
+	})
 }
 
 func TestConstructionService_ConstructionDerive(t *testing.T) {
@@ -1,9 +1,13 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/server/provider"
 )
 
 type errCode int32
@@ -28,6 +32,26 @@ const (
 	ErrInvalidInputParam
 	ErrOfflineMode
 	ErrUnableToGetGenesisBlock
+	ErrUnableToEstimateGasLimit
+	ErrUnableToSimulateTransaction
+	ErrTransactionSimulationFailed
+	ErrTransactionIsNotTracked
+	ErrInvalidSignature
+	ErrInsufficientBalance
+	ErrUnableToGetMempool
+	ErrUnableToQueryContract
+	ErrUnableToGetTokenProperties
+	ErrBlockEventsNotAvailable
+	ErrUnableToSearchTransactions
+	ErrObserverUnavailable
+	ErrRequestDeadlineExceeded
+	ErrRateLimitExceeded
+	ErrTooManyConcurrentRequests
+	ErrUnauthorized
+	ErrForbidden
+	ErrRequestBodyTooLarge
+	ErrSenderHasPendingTransactions
+	ErrBlockEventsNotRetained
 )
 
 type errPrototype struct {
@@ -146,6 +170,106 @@ func createErrPrototypes() ([]errPrototype, map[errCode]errPrototype) {
 			message:   "unable to get genesis block",
 			retriable: true,
 		},
+		{
+			code:      ErrUnableToEstimateGasLimit,
+			message:   "unable to estimate gas limit",
+			retriable: true,
+		},
+		{
+			code:      ErrUnableToSimulateTransaction,
+			message:   "unable to simulate transaction",
+			retriable: true,
+		},
+		{
+			code:      ErrTransactionSimulationFailed,
+			message:   "transaction simulation failed",
+			retriable: false,
+		},
+		{
+			code:      ErrTransactionIsNotTracked,
+			message:   "transaction is not tracked (not submitted through this instance, or forgotten)",
+			retriable: false,
+		},
+		{
+			code:      ErrInvalidSignature,
+			message:   "invalid signature",
+			retriable: false,
+		},
+		{
+			code:      ErrInsufficientBalance,
+			message:   "insufficient balance",
+			retriable: false,
+		},
+		{
+			code:      ErrUnableToGetMempool,
+			message:   "unable to get mempool",
+			retriable: true,
+		},
+		{
+			code:      ErrUnableToQueryContract,
+			message:   "unable to query contract",
+			retriable: true,
+		},
+		{
+			code:      ErrUnableToGetTokenProperties,
+			message:   "unable to get token properties",
+			retriable: true,
+		},
+		{
+			code:      ErrBlockEventsNotAvailable,
+			message:   "block events not available (yet)",
+			retriable: true,
+		},
+		{
+			code:      ErrUnableToSearchTransactions,
+			message:   "unable to search transactions",
+			retriable: true,
+		},
+		{
+			code:      ErrObserverUnavailable,
+			message:   "observer unavailable",
+			retriable: true,
+		},
+		{
+			code:      ErrRequestDeadlineExceeded,
+			message:   "request deadline exceeded",
+			retriable: true,
+		},
+		{
+			code:      ErrRateLimitExceeded,
+			message:   "rate limit exceeded",
+			retriable: true,
+		},
+		{
+			code:      ErrTooManyConcurrentRequests,
+			message:   "too many concurrent requests",
+			retriable: true,
+		},
+		{
+			code:      ErrUnauthorized,
+			message:   "missing or unknown API key",
+			retriable: false,
+		},
+		{
+			code:      ErrForbidden,
+			message:   "API key not allowed to access the endpoint",
+			retriable: false,
+		},
+		{
+			code:      ErrRequestBodyTooLarge,
+			message:   "request body too large",
+			retriable: false,
+		},
+		{
+			code:      ErrSenderHasPendingTransactions,
+			message:   "sender has pending transactions (in pool)",
+			retriable: true,
+		},
+		{
+			code:      ErrBlockEventsNotRetained,
+			message:   "block events not retained anymore (offset older than the oldest retained event)",
+			retriable: false,
+		},
 	}
 
 	prototypesMap := make(map[errCode]errPrototype)
@@ -172,6 +296,15 @@ func (factory *errFactory) getPossibleErrors() []*types.Error {
 }
 
 func (factory *errFactory) newErrWithOriginal(code errCode, originalError error) *types.Error {
+	// Whatever the failed operation, an unavailable observer is reported distinctly (the client should retry later).
+	if provider.IsObserverUnavailableError(originalError) {
+		code = ErrObserverUnavailable
+	}
+	// Same for requests which haven't completed in time (see the per-endpoint deadlines).
+	if errors.Is(originalError, context.DeadlineExceeded) {
+		code = ErrRequestDeadlineExceeded
+	}
+
 	err := factory.newErr(code)
 	err.Details = map[string]interface{}{
 		"originalError": originalError.Error(),
@@ -180,6 +313,50 @@ func (factory *errFactory) newErrWithOriginal(code errCode, originalError error)
 	return err
 }
 
+// NewErrRateLimitExceeded creates the error of a request rejected by the rate limiter, along with a hint about when to retry
+func NewErrRateLimitExceeded(retryAfter time.Duration) *types.Error {
+	return newErrFactory().newErrWithRetryAfter(ErrRateLimitExceeded, retryAfter)
+}
+
+// NewErrTooManyConcurrentRequests creates the error of a request rejected due to the cap on concurrent requests, along with a hint about when to retry
+func NewErrTooManyConcurrentRequests(retryAfter time.Duration) *types.Error {
+	return newErrFactory().newErrWithRetryAfter(ErrTooManyConcurrentRequests, retryAfter)
+}
+
+// NewErrUnauthorized creates the error of a request without a (known) API key
+func NewErrUnauthorized() *types.Error {
+	return newErrFactory().newErr(ErrUnauthorized)
+}
+
+// NewErrForbidden creates the error of a request whose API key lacks the scope required by the endpoint
+func NewErrForbidden(requiredScope string) *types.Error {
+	err := newErrFactory().newErr(ErrForbidden)
+	err.Details = map[string]interface{}{
+		"requiredScope": requiredScope,
+	}
+
+	return err
+}
+
+// NewErrRequestBodyTooLarge creates the error of a request whose body exceeds the maximum size
+func NewErrRequestBodyTooLarge(maxSize int64) *types.Error {
+	err := newErrFactory().newErr(ErrRequestBodyTooLarge)
+	err.Details = map[string]interface{}{
+		"maxBytes": maxSize,
+	}
+
+	return err
+}
+
+func (factory *errFactory) newErrWithRetryAfter(code errCode, retryAfter time.Duration) *types.Error {
+	err := factory.newErr(code)
+	err.Details = map[string]interface{}{
+		"retryAfterMilliseconds": retryAfter.Milliseconds(),
+	}
+
+	return err
+}
+
 func (factory *errFactory) newErr(code errCode) *types.Error {
 	prototype := factory.getPrototypeByCode(code)
 
@@ -201,3 +378,29 @@ func (factory *errFactory) getPrototypeByCode(code errCode) errPrototype {
 
 var errCannotRecognizeEvent = errors.New("cannot recognize transaction event")
 var errCannotParseRelayedV1 = errors.New("cannot parse relayed V1 transaction")
+var errCannotParseRelayedV2 = errors.New("cannot parse relayed V2 transaction")
+var errMalformedTransactionData = errors.New("malformed transaction data")
+var errNestedRelayedTransaction = errors.New("nested relayed transactions are not supported")
+var errValueNotAllowedWithBuiltInFunction = errors.New("value must be zero when calling a built-in function")
+var errCannotParseContractDeployment = errors.New("cannot parse contract deployment")
+var errInvalidSignature = errors.New("invalid signature")
+var errEmptyAccountIdentifier = errors.New("empty account identifier")
+var errCannotResolveAccountIdentifier = errors.New("cannot resolve account identifier")
+var errNotAContract = errors.New("not a contract")
+var errBadBlockCoordinates = errors.New("bad block coordinates: provide either a block nonce or a block hash")
+var errConflictingAddressConditions = errors.New("conflicting address conditions: account identifier and address differ")
+var errCoinsNotSupported = errors.New("coins are not supported (account-based model)")
+var errSearchOffsetTooLarge = errors.New("search offset too large")
+var errBadBlocksRange = errors.New("bad blocks range")
+var errNoAddressesToReconcile = errors.New("no addresses to reconcile")
+var errCannotParseAmount = errors.New("cannot parse amount")
+var errUnexpectedBalanceBlock = errors.New("balance fetched at an unexpected block")
+var errReconciliationStepFailed = errors.New("reconciliation step failed")
+
+func newErrSearchOffsetTooLarge(offset int64) error {
+	return fmt.Errorf("%w: offset = %d, max = %d", errSearchOffsetTooLarge, offset, maxSearchOffset)
+}
+
+func newErrReconciliationStep(step string, nonce uint64, rosettaErr *types.Error) error {
+	return fmt.Errorf("%w: %s, block = %d, error = %s, details = %v", errReconciliationStepFailed, step, nonce, rosettaErr.Message, rosettaErr.Details)
+}
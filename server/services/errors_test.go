@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrFactory_NewErrWithOriginal(t *testing.T) {
+	t.Parallel()
+
+	factory := newErrFactory()
+
+	err := factory.newErrWithOriginal(ErrUnableToGetBlock, errors.New("block not found"))
+	require.Equal(t, int32(ErrUnableToGetBlock), err.Code)
+	require.Equal(t, "block not found", err.Details["originalError"])
+
+	err = factory.newErrWithOriginal(ErrUnableToGetBlock, fmt.Errorf("cannot get block: %w", context.DeadlineExceeded))
+	require.Equal(t, int32(ErrRequestDeadlineExceeded), err.Code)
+	require.True(t, err.Retriable)
+	require.Equal(t, "cannot get block: context deadline exceeded", err.Details["originalError"])
+}
+
+func TestNewErrRateLimitExceeded(t *testing.T) {
+	t.Parallel()
+
+	err := NewErrRateLimitExceeded(1500 * time.Millisecond)
+	require.Equal(t, int32(ErrRateLimitExceeded), err.Code)
+	require.Equal(t, "rate limit exceeded", err.Message)
+	require.True(t, err.Retriable)
+	require.Equal(t, int64(1500), err.Details["retryAfterMilliseconds"])
+
+	err = NewErrTooManyConcurrentRequests(time.Second)
+	require.Equal(t, int32(ErrTooManyConcurrentRequests), err.Code)
+	require.True(t, err.Retriable)
+	require.Equal(t, int64(1000), err.Details["retryAfterMilliseconds"])
+}
+
+func TestNewErrForbidden(t *testing.T) {
+	t.Parallel()
+
+	err := NewErrForbidden("submit")
+	require.Equal(t, int32(ErrForbidden), err.Code)
+	require.False(t, err.Retriable)
+	require.Equal(t, "submit", err.Details["requiredScope"])
+
+	err = NewErrUnauthorized()
+	require.Equal(t, int32(ErrUnauthorized), err.Code)
+	require.Nil(t, err.Details)
+}
+
+func TestNewErrRequestBodyTooLarge(t *testing.T) {
+	t.Parallel()
+
+	err := NewErrRequestBodyTooLarge(1024)
+	require.Equal(t, int32(ErrRequestBodyTooLarge), err.Code)
+	require.False(t, err.Retriable)
+	require.Equal(t, int64(1024), err.Details["maxBytes"])
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+)
+
+type eventsService struct {
+	provider   NetworkProvider
+	errFactory *errFactory
+	eventsLog  *blockEventsLog
+
+	closing     chan struct{}
+	closingOnce sync.Once
+}
+
+// NewEventsService will create a new instance of eventsService.
+// The block events are recorded in memory only, by a poller following the latest (final) block.
+// The sequence numbers start at the startup time (in microseconds), so that they aren't reused when Rosetta is restarted:
+// a client holding an offset recorded before a restart gets ErrBlockEventsNotRetained, thus it can notice the reset and sync again.
+func NewEventsService(provider NetworkProvider) EventsService {
+	return newEventsService(provider, time.Now().UnixMicro())
+}
+
+func newEventsService(provider NetworkProvider, firstSequence int64) *eventsService {
+	return &eventsService{
+		provider:   provider,
+		errFactory: newErrFactory(),
+		eventsLog:  newBlockEventsLog(firstSequence, maxNumBlockEventsRetained, maxNumReportedBlocksRetained),
+		closing:    make(chan struct{}),
+	}
+}
+
+// EventsBlocks implements the /events/blocks endpoint.
+// Without an offset, the most recent events are returned (as the specification requires); a zero offset stands for the oldest retained event.
+// If the events at the requested offset aren't retained anymore (or have been recorded before a restart), ErrBlockEventsNotRetained is returned.
+func (service *eventsService) EventsBlocks(
+	_ context.Context,
+	request *types.EventsBlocksRequest,
+) (*types.EventsBlocksResponse, *types.Error) {
+	limit := int64(maxNumBlockEventsPerResponse)
+	if request.Limit != nil && *request.Limit > 0 && *request.Limit < limit {
+		limit = *request.Limit
+	}
+
+	var events []*types.BlockEvent
+	var oldestSequence, maxSequence int64
+	var ok bool
+
+	if request.Offset == nil {
+		events, _, maxSequence, ok = service.eventsLog.getLatestEvents(limit)
+	} else {
+		events, oldestSequence, maxSequence, ok = service.eventsLog.getEvents(*request.Offset, limit)
+	}
+	if !ok {
+		return nil, service.errFactory.newErr(ErrBlockEventsNotAvailable)
+	}
+
+	isOffsetTooOld := request.Offset != nil && *request.Offset != 0 && *request.Offset < oldestSequence
+	if isOffsetTooOld {
+		err := service.errFactory.newErr(ErrBlockEventsNotRetained)
+		err.Details = map[string]interface{}{
+			"oldestSequence": oldestSequence,
+			"maxSequence":    maxSequence,
+		}
+
+		return nil, err
+	}
+
+	return &types.EventsBlocksResponse{
+		MaxSequence: maxSequence,
+		Events:      events,
+	}, nil
+}
+
+// PollBlocks follows the latest (final) block: it records "block_added" events for the new blocks,
+// and "block_removed" events for the previously reported blocks that aren't part of the canonical chain anymore.
+// Since only final blocks are followed, "block_removed" events aren't expected in practice: they are only emitted
+// if the observer reports a different block at an already reported height (e.g. the observer has been replaced by one of another network).
+func (service *eventsService) PollBlocks() error {
+	// The poller works in the background, thus it isn't bound to any client request.
+	ctx := context.Background()
+
+	latestBlock, err := service.provider.GetLatestBlockSummary(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = service.removeNonCanonicalBlocks(ctx, latestBlock)
+	if err != nil {
+		return err
+	}
+
+	tip, ok := service.eventsLog.getTipBlock()
+	if !ok {
+		// Nothing reported yet (or the whole reported chain has been removed): start following the chain from the latest block.
+		service.eventsLog.addBlock(*latestBlock)
+		return nil
+	}
+
+	lastNonceToAdd := latestBlock.Nonce
+	if lastNonceToAdd > tip.Nonce+maxNumBlocksAddedPerEventsPoll {
+		lastNonceToAdd = tip.Nonce + maxNumBlocksAddedPerEventsPoll
+	}
+
+	for nonce := tip.Nonce + 1; nonce <= lastNonceToAdd; nonce++ {
+		block, err := service.getCanonicalBlockSummary(ctx, nonce, latestBlock)
+		if err != nil {
+			return err
+		}
+
+		if block.PreviousBlockHash != tip.Hash {
+			// The chain has changed in the meantime; it will be handled at the next poll.
+			log.Warn("eventsService.PollBlocks(): block does not link to the reported tip", "nonce", nonce, "hash", block.Hash, "tip", tip.Hash)
+			return nil
+		}
+
+		service.eventsLog.addBlock(*block)
+		tip = *block
+	}
+
+	return nil
+}
+
+func (service *eventsService) removeNonCanonicalBlocks(ctx context.Context, latestBlock *resources.BlockSummary) error {
+	for {
+		tip, ok := service.eventsLog.getTipBlock()
+		if !ok {
+			return nil
+		}
+
+		if tip.Nonce > latestBlock.Nonce {
+			// The observer lags behind the reported chain (e.g. it has been restarted); final blocks aren't reverted, thus wait for it to catch up.
+			return nil
+		}
+
+		canonicalBlock, err := service.getCanonicalBlockSummary(ctx, tip.Nonce, latestBlock)
+		if err != nil {
+			return err
+		}
+
+		if canonicalBlock.Hash == tip.Hash {
+			return nil
+		}
+
+		log.Info("eventsService: reported block isn't canonical anymore", "nonce", tip.Nonce, "hash", tip.Hash)
+		service.eventsLog.removeTipBlock()
+	}
+}
+
+func (service *eventsService) getCanonicalBlockSummary(ctx context.Context, nonce uint64, latestBlock *resources.BlockSummary) (*resources.BlockSummary, error) {
+	if nonce == latestBlock.Nonce {
+		return latestBlock, nil
+	}
+
+	return service.provider.GetBlockSummaryByNonce(ctx, nonce)
+}
+
+// StartPollingLoop periodically polls for new blocks (in the background), until the service is closed.
+// A zero interval disables the polling (no block events are recorded).
+func (service *eventsService) StartPollingLoop(interval time.Duration) {
+	if interval == 0 {
+		log.Info("eventsService.StartPollingLoop(): polling for block events is disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				err := service.PollBlocks()
+				if err != nil {
+					log.Warn("eventsService.StartPollingLoop(): cannot poll blocks", "err", err)
+				}
+			case <-service.closing:
+				return
+			}
+		}
+	}()
+}
+
+// Close stops the background activities of the service
+func (service *eventsService) Close() error {
+	service.closingOnce.Do(func() {
+		close(service.closing)
+	})
+
+	return nil
+}
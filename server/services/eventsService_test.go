@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-core-go/data/api"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventsService_PollBlocksAndEventsBlocks(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	service := newEventsService(networkProvider, 0)
+
+	setBlock := func(nonce uint64, hash string, previousHash string) {
+		networkProvider.MockBlocksByNonce[nonce] = &api.Block{
+			Nonce:         nonce,
+			Hash:          hash,
+			PrevBlockHash: previousHash,
+		}
+	}
+
+	setLatestBlock := func(nonce uint64) {
+		block := networkProvider.MockBlocksByNonce[nonce]
+		networkProvider.MockNodeStatus.LatestBlock = resources.BlockSummary{
+			Nonce:             block.Nonce,
+			Hash:              block.Hash,
+			PreviousBlockHash: block.PrevBlockHash,
+		}
+	}
+
+	getAllEvents := func() []*types.BlockEvent {
+		response, err := service.EventsBlocks(context.Background(), &types.EventsBlocksRequest{})
+		require.Nil(t, err)
+		return response.Events
+	}
+
+	// No events yet
+	response, errEvents := service.EventsBlocks(context.Background(), &types.EventsBlocksRequest{})
+	require.Nil(t, response)
+	require.Equal(t, int32(ErrBlockEventsNotAvailable), errEvents.Code)
+
+	// Start following the chain from the latest block
+	setBlock(10, "a10", "a09")
+	setLatestBlock(10)
+
+	err := service.PollBlocks()
+	require.Nil(t, err)
+	require.Equal(t, []*types.BlockEvent{
+		{Sequence: 0, BlockIdentifier: &types.BlockIdentifier{Index: 10, Hash: "a10"}, Type: types.ADDED},
+	}, getAllEvents())
+
+	// New blocks
+	setBlock(11, "a11", "a10")
+	setBlock(12, "a12", "a11")
+	setLatestBlock(12)
+
+	err = service.PollBlocks()
+	require.Nil(t, err)
+	require.Len(t, getAllEvents(), 3)
+
+	// Nothing changed
+	err = service.PollBlocks()
+	require.Nil(t, err)
+	require.Len(t, getAllEvents(), 3)
+
+	// Reorganization: block 12 is replaced
+	setBlock(12, "b12", "a11")
+	setBlock(13, "b13", "b12")
+	setLatestBlock(13)
+
+	err = service.PollBlocks()
+	require.Nil(t, err)
+
+	response, errEvents = service.EventsBlocks(context.Background(), &types.EventsBlocksRequest{
+		Offset: types.Int64(2),
+		Limit:  types.Int64(10),
+	})
+	require.Nil(t, errEvents)
+	require.Equal(t, int64(5), response.MaxSequence)
+	require.Equal(t, []*types.BlockEvent{
+		{Sequence: 2, BlockIdentifier: &types.BlockIdentifier{Index: 12, Hash: "a12"}, Type: types.ADDED},
+		{Sequence: 3, BlockIdentifier: &types.BlockIdentifier{Index: 12, Hash: "a12"}, Type: types.REMOVED},
+		{Sequence: 4, BlockIdentifier: &types.BlockIdentifier{Index: 12, Hash: "b12"}, Type: types.ADDED},
+		{Sequence: 5, BlockIdentifier: &types.BlockIdentifier{Index: 13, Hash: "b13"}, Type: types.ADDED},
+	}, response.Events)
+
+	// With limit
+	response, errEvents = service.EventsBlocks(context.Background(), &types.EventsBlocksRequest{
+		Offset: types.Int64(1),
+		Limit:  types.Int64(2),
+	})
+	require.Nil(t, errEvents)
+	require.Len(t, response.Events, 2)
+	require.Equal(t, int64(1), response.Events[0].Sequence)
+	require.Equal(t, int64(2), response.Events[1].Sequence)
+
+	// Without offset, the most recent events are returned
+	response, errEvents = service.EventsBlocks(context.Background(), &types.EventsBlocksRequest{
+		Limit: types.Int64(2),
+	})
+	require.Nil(t, errEvents)
+	require.Equal(t, int64(5), response.MaxSequence)
+	require.Len(t, response.Events, 2)
+	require.Equal(t, int64(4), response.Events[0].Sequence)
+	require.Equal(t, int64(5), response.Events[1].Sequence)
+
+	// With zero offset, the oldest events are returned
+	response, errEvents = service.EventsBlocks(context.Background(), &types.EventsBlocksRequest{
+		Offset: types.Int64(0),
+		Limit:  types.Int64(2),
+	})
+	require.Nil(t, errEvents)
+	require.Equal(t, int64(0), response.Events[0].Sequence)
+	require.Equal(t, int64(1), response.Events[1].Sequence)
+
+	// Observer not reachable
+	networkProvider.MockNextError = errors.New("arbitrary error")
+	err = service.PollBlocks()
+	require.ErrorContains(t, err, "arbitrary error")
+}
+
+func TestEventsService_EventsBlocksAfterRestart(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockNodeStatus.LatestBlock = resources.BlockSummary{Nonce: 10, Hash: "a10", PreviousBlockHash: "a09"}
+
+	// Sequences of a previous run are lower than the ones of the current run.
+	service := newEventsService(networkProvider, 1000)
+
+	err := service.PollBlocks()
+	require.Nil(t, err)
+
+	response, errEvents := service.EventsBlocks(context.Background(), &types.EventsBlocksRequest{})
+	require.Nil(t, errEvents)
+	require.Equal(t, int64(1000), response.MaxSequence)
+	require.Equal(t, int64(1000), response.Events[0].Sequence)
+
+	response, errEvents = service.EventsBlocks(context.Background(), &types.EventsBlocksRequest{
+		Offset: types.Int64(0),
+	})
+	require.Nil(t, errEvents)
+	require.Len(t, response.Events, 1)
+
+	// The client holds an offset recorded before the restart.
+	response, errEvents = service.EventsBlocks(context.Background(), &types.EventsBlocksRequest{
+		Offset: types.Int64(42),
+	})
+	require.Nil(t, response)
+	require.Equal(t, int32(ErrBlockEventsNotRetained), errEvents.Code)
+	require.False(t, errEvents.Retriable)
+	require.Equal(t, int64(1000), errEvents.Details["oldestSequence"])
+	require.Equal(t, int64(1000), errEvents.Details["maxSequence"])
+}
+
+func TestEventsService_PollBlocksWhenObserverLags(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	service := newEventsService(networkProvider, 0)
+
+	networkProvider.MockNodeStatus.LatestBlock = resources.BlockSummary{Nonce: 10, Hash: "a10", PreviousBlockHash: "a09"}
+	err := service.PollBlocks()
+	require.Nil(t, err)
+
+	// E.g. the observer has been restarted, and it's still catching up.
+	networkProvider.MockNodeStatus.LatestBlock = resources.BlockSummary{Nonce: 8, Hash: "a08", PreviousBlockHash: "a07"}
+	err = service.PollBlocks()
+	require.Nil(t, err)
+
+	response, errEvents := service.EventsBlocks(context.Background(), &types.EventsBlocksRequest{})
+	require.Nil(t, errEvents)
+	require.Equal(t, []*types.BlockEvent{
+		{Sequence: 0, BlockIdentifier: &types.BlockIdentifier{Index: 10, Hash: "a10"}, Type: types.ADDED},
+	}, response.Events)
+}
+
+func TestNewEventsService_SequencesDoNotRestartFromZero(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockNodeStatus.LatestBlock = resources.BlockSummary{Nonce: 10, Hash: "a10", PreviousBlockHash: "a09"}
+
+	before := time.Now().UnixMicro()
+	service := NewEventsService(networkProvider)
+
+	err := service.PollBlocks()
+	require.Nil(t, err)
+
+	response, errEvents := service.EventsBlocks(context.Background(), &types.EventsBlocksRequest{})
+	require.Nil(t, errEvents)
+	require.GreaterOrEqual(t, response.Events[0].Sequence, before)
+}
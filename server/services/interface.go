@@ -1,16 +1,22 @@
 package services
 
 import (
+	"context"
 	"math/big"
+	"time"
 
+	"github.com/coinbase/rosetta-sdk-go/server"
+	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/multiversx/mx-chain-core-go/data/api"
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
 	"github.com/multiversx/mx-chain-proxy-go/data"
+	"github.com/multiversx/mx-chain-rosetta/server/index"
 	"github.com/multiversx/mx-chain-rosetta/server/resources"
 )
 
 type NetworkProvider interface {
 	IsOffline() bool
+	ShouldSimulateBeforeSubmit() bool
 	GetBlockchainName() string
 	GetNativeCurrency() resources.Currency
 	GetCustomCurrencies() []resources.Currency
@@ -19,19 +25,61 @@ type NetworkProvider interface {
 	GetNetworkConfig() *resources.NetworkConfig
 	GetGenesisBlockSummary() *resources.BlockSummary
 	GetGenesisTimestamp() int64
-	GetGenesisBalances() ([]*resources.GenesisBalance, error)
-	GetNodeStatus() (*resources.AggregatedNodeStatus, error)
-	GetBlockByNonce(nonce uint64) (*api.Block, error)
-	GetBlockByHash(hash string) (*api.Block, error)
-	GetAccount(address string) (*resources.AccountOnBlock, error)
-	GetAccountBalance(address string, tokenIdentifier string, options resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error)
+	GetGenesisBalances(ctx context.Context) ([]*resources.GenesisBalance, error)
+	GetNodeStatus(ctx context.Context) (*resources.AggregatedNodeStatus, error)
+	GetBlockByNonce(ctx context.Context, nonce uint64) (*api.Block, error)
+	GetBlockSummaryByNonce(ctx context.Context, nonce uint64) (*resources.BlockSummary, error)
+	GetLatestBlockSummary(ctx context.Context) (*resources.BlockSummary, error)
+	GetBlockByHash(ctx context.Context, hash string) (*api.Block, error)
+	GetAccount(ctx context.Context, address string) (*resources.AccountOnBlock, error)
+	GetAccountNonceOnLatestBlock(ctx context.Context, address string) (uint64, error)
+	GetAccountBalance(ctx context.Context, address string, tokenIdentifier string, options resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error)
 	IsAddressObserved(address string) (bool, error)
 	ComputeShardIdOfPubKey(pubkey []byte) uint32
 	ConvertPubKeyToAddress(pubkey []byte) string
 	ConvertAddressToPubKey(address string) ([]byte, error)
-	SendTransaction(tx *data.Transaction) (string, error)
+	SendTransaction(ctx context.Context, tx *data.Transaction) (string, error)
+	GetSubmittedTransaction(hash string) (*resources.SubmittedTransaction, bool)
 	ComputeTransactionHash(tx *data.Transaction) (string, error)
+	EstimateTransactionGas(ctx context.Context, tx *data.Transaction) (uint64, error)
+	SimulateTransaction(ctx context.Context, tx *data.Transaction) (*resources.TransactionSimulationResults, error)
 	ComputeReceiptHash(apiReceipt *transaction.ApiReceipt) (string, error)
 	ComputeTransactionFeeForMoveBalance(tx *transaction.ApiTransactionResult) *big.Int
-	GetMempoolTransactionByHash(hash string) (*transaction.ApiTransactionResult, error)
+	GetMempoolTransactionByHash(ctx context.Context, hash string) (*transaction.ApiTransactionResult, error)
+	GetTransactionsPoolNoncesForSender(ctx context.Context, sender string) ([]uint64, error)
+	GetTransactionsInPool(ctx context.Context) ([]*resources.TransactionInPool, error)
+	ResolveUsername(ctx context.Context, username string) (string, error)
+	QueryContract(ctx context.Context, query *data.VmValueRequest, options resources.AccountQueryOptions) (*resources.VMQueryOutput, error)
+	GetTokenProperties(ctx context.Context, tokenIdentifier string) (*resources.TokenProperties, error)
+}
+
+// EventsService defines the servicer of the Events API, along with the controls of its (background) blocks poller
+type EventsService interface {
+	server.EventsAPIServicer
+	PollBlocks() error
+	StartPollingLoop(interval time.Duration)
+	Close() error
+}
+
+// SearchService defines the servicer of the Search API, along with the controls of its (background) indexer
+type SearchService interface {
+	server.SearchAPIServicer
+	IndexNewBlocks() (int, error)
+	StartIndexingLoop(interval time.Duration)
+	GetIndexingProgress() (*IndexingProgress, error)
+	Close() error
+}
+
+// BalancesReconciler defines a component which checks the operations (of /block) against the balances (of /account/balance)
+type BalancesReconciler interface {
+	Reconcile(ctx context.Context, args ArgsReconcileBalances) (*ReconciliationReport, error)
+}
+
+type transactionsIndex interface {
+	IndexBlock(block *types.BlockIdentifier, transactions []*types.Transaction) error
+	RemoveLastBlock() error
+	GetFirstIndexedBlock() (*types.BlockIdentifier, bool, error)
+	GetLastIndexedBlock() (*types.BlockIdentifier, bool, error)
+	Search(query index.Query) (*index.Result, error)
+	Close() error
 }
@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/coinbase/rosetta-sdk-go/server"
 	"github.com/coinbase/rosetta-sdk-go/types"
@@ -11,6 +13,21 @@ type mempoolService struct {
 	provider       NetworkProvider
 	errFactory     *errFactory
 	txsTransformer *transactionsTransformer
+
+	snapshotMutex sync.Mutex
+	snapshot      *mempoolSnapshot
+}
+
+// mempoolSnapshot holds the (filtered) identifiers of the transactions in the pool, at a given moment
+type mempoolSnapshot struct {
+	identifiers []*types.TransactionIdentifier
+	takenAt     time.Time
+}
+
+// mempoolRequestMetadata holds the (optional) paging parameters of a /mempool request
+type mempoolRequestMetadata struct {
+	Page     int `json:"page"`
+	PageSize int `json:"pageSize"`
 }
 
 // NewMempoolService will create a new instance of mempoolAPIService
@@ -22,17 +39,98 @@ func NewMempoolService(provider NetworkProvider) server.MempoolAPIServicer {
 	}
 }
 
-// Mempool is not implemented yet
-func (service *mempoolService) Mempool(context.Context, *types.NetworkRequest) (*types.MempoolResponse, *types.Error) {
-	return nil, newErrFactory().newErr(ErrNotImplemented)
+// Mempool lists the transactions in the pool of the observer, whose sender or receiver is observed.
+// The listing is backed by a short-lived snapshot, so that paging ("page" and "pageSize", in metadata) is consistent across subsequent requests.
+func (service *mempoolService) Mempool(ctx context.Context, request *types.NetworkRequest) (*types.MempoolResponse, *types.Error) {
+	requestMetadata := &mempoolRequestMetadata{}
+
+	err := fromObjectsMap(request.Metadata, requestMetadata)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrInvalidInputParam, err)
+	}
+	if requestMetadata.Page < 0 || requestMetadata.PageSize < 0 {
+		return nil, service.errFactory.newErr(ErrInvalidInputParam)
+	}
+
+	identifiers, err := service.getMempoolSnapshot(ctx, time.Now())
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrUnableToGetMempool, err)
+	}
+
+	return &types.MempoolResponse{
+		TransactionIdentifiers: getPageOfTransactionIdentifiers(identifiers, requestMetadata.Page, requestMetadata.PageSize),
+	}, nil
+}
+
+func (service *mempoolService) getMempoolSnapshot(ctx context.Context, now time.Time) ([]*types.TransactionIdentifier, error) {
+	service.snapshotMutex.Lock()
+	defer service.snapshotMutex.Unlock()
+
+	if service.snapshot != nil && now.Sub(service.snapshot.takenAt) < durationMempoolSnapshotMaxAge {
+		return service.snapshot.identifiers, nil
+	}
+
+	transactions, err := service.provider.GetTransactionsInPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	identifiers := make([]*types.TransactionIdentifier, 0, len(transactions))
+
+	for _, tx := range transactions {
+		if service.isAnyAddressObserved(tx.Sender, tx.Receiver) {
+			identifiers = append(identifiers, hashToTransactionIdentifier(tx.Hash))
+		}
+	}
+
+	service.snapshot = &mempoolSnapshot{
+		identifiers: identifiers,
+		takenAt:     now,
+	}
+
+	return identifiers, nil
+}
+
+func (service *mempoolService) isAnyAddressObserved(addresses ...string) bool {
+	for _, address := range addresses {
+		if len(address) == 0 {
+			continue
+		}
+
+		isObserved, err := service.provider.IsAddressObserved(address)
+		if err == nil && isObserved {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getPageOfTransactionIdentifiers returns a page of identifiers (or all of them, if the page size isn't specified)
+func getPageOfTransactionIdentifiers(identifiers []*types.TransactionIdentifier, page int, pageSize int) []*types.TransactionIdentifier {
+	if pageSize == 0 {
+		return identifiers
+	}
+
+	start := page * pageSize
+	if start >= len(identifiers) {
+		return []*types.TransactionIdentifier{}
+	}
+
+	end := start + pageSize
+	if end > len(identifiers) {
+		end = len(identifiers)
+	}
+
+	return identifiers[start:end]
 }
 
 // MempoolTransaction will return operations for a transaction that is in pool
 func (service *mempoolService) MempoolTransaction(
-	_ context.Context,
+	ctx context.Context,
 	request *types.MempoolTransactionRequest,
 ) (*types.MempoolTransactionResponse, *types.Error) {
-	tx, err := service.provider.GetMempoolTransactionByHash(request.TransactionIdentifier.Hash)
+	tx, err := service.provider.GetMempoolTransactionByHash(ctx, request.TransactionIdentifier.Hash)
 	if err != nil {
 		return nil, service.errFactory.newErrWithOriginal(ErrCannotParsePoolTransaction, err)
 	}
@@ -40,9 +138,9 @@ func (service *mempoolService) MempoolTransaction(
 		return nil, service.errFactory.newErr(ErrTransactionIsNotInPool)
 	}
 
-	rosettaTx := service.txsTransformer.mempoolMoveBalanceTxToRosettaTx(tx)
+	rosettaTx, err := service.txsTransformer.mempoolTxToRosettaTx(tx)
 	if err != nil {
-		return nil, service.errFactory.newErr(ErrCannotParsePoolTransaction)
+		return nil, service.errFactory.newErrWithOriginal(ErrCannotParsePoolTransaction, err)
 	}
 
 	return &types.MempoolTransactionResponse{
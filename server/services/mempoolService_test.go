@@ -2,11 +2,15 @@ package services
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/coinbase/rosetta-sdk-go/server"
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
 	"github.com/multiversx/mx-chain-rosetta/testscommon"
 	"github.com/stretchr/testify/require"
 )
@@ -51,6 +55,14 @@ func TestMempoolService_MempoolTransaction(t *testing.T) {
 				Type:                opTransfer,
 				Account:             addressToAccountIdentifier(testscommon.TestAddressBob),
 				Amount:              extension.valueToNativeAmount("1234"),
+				Metadata:            objectsMap{"unconfirmed": true},
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(2),
+				Type:                opFee,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToNativeAmount("-50000000000000"),
+				Metadata:            objectsMap{"unconfirmed": true},
 			},
 		},
 		Metadata: extractTransactionMetadata(tx),
@@ -61,6 +73,290 @@ func TestMempoolService_MempoolTransaction(t *testing.T) {
 	require.Equal(t, expectedRosettaTx, txResponse.Transaction)
 }
 
+func TestMempoolService_MempoolTransactionWithCustomTransfers(t *testing.T) {
+	networkProvider := testscommon.NewNetworkProviderMock()
+	extension := newNetworkProviderExtension(networkProvider)
+	service := NewMempoolService(networkProvider)
+
+	unconfirmed := objectsMap{"unconfirmed": true}
+
+	t.Run("ESDTTransfer", func(t *testing.T) {
+		tx := &transaction.ApiTransactionResult{
+			Hash:     "aaaa",
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressBob,
+			Value:    "0",
+			Data:     []byte("ESDTTransfer@544553542d616263646566@03e8"),
+			GasLimit: 500000,
+			GasPrice: 1000000000,
+		}
+
+		networkProvider.MockMempoolTransactionsByHash["aaaa"] = tx
+
+		txResponse, err := getMempoolTransactionByHash(service, "aaaa")
+		require.Nil(t, err)
+		require.Equal(t, []*types.Operation{
+			{
+				OperationIdentifier: indexToOperationIdentifier(0),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToCustomAmount("-1000", "TEST-abcdef"),
+				Metadata:            unconfirmed,
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(1),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressBob),
+				Amount:              extension.valueToCustomAmount("1000", "TEST-abcdef"),
+				Metadata:            unconfirmed,
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(2),
+				Type:                opFee,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				// (50000 + 1500 * 40) * 1000000000 + (500000 - 110000) * 1000000000 * 0.01
+				Amount:   extension.valueToNativeAmount("-113900000000000"),
+				Metadata: unconfirmed,
+			},
+		}, txResponse.Transaction.Operations)
+	})
+
+	t.Run("MultiESDTNFTTransfer", func(t *testing.T) {
+		tx := &transaction.ApiTransactionResult{
+			Hash:     "bbbb",
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressAlice,
+			Value:    "0",
+			Data:     []byte("MultiESDTNFTTransfer@" + hex.EncodeToString(testscommon.TestPubKeyBob) + "@02@544553542d616263646566@@0a@4e46542d616263646566@07@01"),
+			GasLimit: 50000 + 1500*142,
+			GasPrice: 1000000000,
+		}
+
+		networkProvider.MockMempoolTransactionsByHash["bbbb"] = tx
+
+		txResponse, err := getMempoolTransactionByHash(service, "bbbb")
+		require.Nil(t, err)
+		require.Equal(t, []*types.Operation{
+			{
+				OperationIdentifier: indexToOperationIdentifier(0),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToCustomAmount("-10", "TEST-abcdef"),
+				Metadata:            unconfirmed,
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(1),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressBob),
+				Amount:              extension.valueToCustomAmount("10", "TEST-abcdef"),
+				Metadata:            unconfirmed,
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(2),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToCustomAmount("-1", "NFT-abcdef-07"),
+				Metadata:            unconfirmed,
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(3),
+				Type:                opCustomTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressBob),
+				Amount:              extension.valueToCustomAmount("1", "NFT-abcdef-07"),
+				Metadata:            unconfirmed,
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(4),
+				Type:                opFee,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToNativeAmount("-263000000000000"),
+				Metadata:            unconfirmed,
+			},
+		}, txResponse.Transaction.Operations)
+	})
+
+	t.Run("relayed V3, the relayer pays the fee", func(t *testing.T) {
+		tx := &transaction.ApiTransactionResult{
+			Hash:             "cccc",
+			Sender:           testscommon.TestAddressAlice,
+			Receiver:         testscommon.TestAddressBob,
+			RelayerAddress:   testscommon.TestAddressCarol,
+			Signature:        "signature",
+			RelayerSignature: "signature",
+			Value:            "1234",
+			GasLimit:         100000,
+			GasPrice:         1000000000,
+		}
+
+		networkProvider.MockMempoolTransactionsByHash["cccc"] = tx
+
+		txResponse, err := getMempoolTransactionByHash(service, "cccc")
+		require.Nil(t, err)
+		require.Len(t, txResponse.Transaction.Operations, 3)
+		require.Equal(t, opFee, txResponse.Transaction.Operations[2].Type)
+		require.Equal(t, addressToAccountIdentifier(testscommon.TestAddressCarol), txResponse.Transaction.Operations[2].Account)
+	})
+
+	t.Run("contract deployment", func(t *testing.T) {
+		tx := &transaction.ApiTransactionResult{
+			Hash:     "dddd",
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: systemContractDeployAddress,
+			Value:    "0",
+			Data:     []byte("0061736d@0500@0502"),
+			GasLimit: 50000 + 1500*18,
+			GasPrice: 1000000000,
+		}
+
+		networkProvider.MockMempoolTransactionsByHash["dddd"] = tx
+
+		txResponse, err := getMempoolTransactionByHash(service, "dddd")
+		require.Nil(t, err)
+		require.Equal(t, []*types.Operation{
+			{
+				OperationIdentifier: indexToOperationIdentifier(0),
+				Type:                opFee,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToNativeAmount("-77000000000000"),
+				Metadata:            unconfirmed,
+			},
+		}, txResponse.Transaction.Operations)
+	})
+
+	t.Run("with malformed data (falls back to the native transfer)", func(t *testing.T) {
+		networkProvider.MockMempoolTransactionsByHash["eeee"] = &transaction.ApiTransactionResult{
+			Hash:     "eeee",
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressBob,
+			Value:    "0",
+			Data:     []byte("ESDTTransfer@foo"),
+			GasLimit: 500000,
+			GasPrice: 1000000000,
+		}
+
+		txResponse, err := getMempoolTransactionByHash(service, "eeee")
+		require.Nil(t, err)
+		require.Equal(t, []*types.Operation{
+			{
+				OperationIdentifier: indexToOperationIdentifier(0),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				Amount:              extension.valueToNativeAmount("-0"),
+				Metadata:            unconfirmed,
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(1),
+				Type:                opTransfer,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressBob),
+				Amount:              extension.valueToNativeAmount("0"),
+				Metadata:            unconfirmed,
+			},
+			{
+				OperationIdentifier: indexToOperationIdentifier(2),
+				Type:                opFee,
+				Account:             addressToAccountIdentifier(testscommon.TestAddressAlice),
+				// (50000 + 1500 * 16) * 1000000000 + (500000 - 74000) * 1000000000 * 0.01
+				Amount:   extension.valueToNativeAmount("-78260000000000"),
+				Metadata: unconfirmed,
+			},
+		}, txResponse.Transaction.Operations)
+	})
+
+	t.Run("with malformed value", func(t *testing.T) {
+		networkProvider.MockMempoolTransactionsByHash["ffff"] = &transaction.ApiTransactionResult{
+			Hash:     "ffff",
+			Sender:   testscommon.TestAddressAlice,
+			Receiver: testscommon.TestAddressBob,
+			Value:    "foo",
+			GasLimit: 50000,
+			GasPrice: 1000000000,
+		}
+
+		txResponse, err := getMempoolTransactionByHash(service, "ffff")
+		require.Equal(t, ErrCannotParsePoolTransaction, errCode(err.Code))
+		require.Nil(t, txResponse)
+	})
+}
+
+func TestMempoolService_Mempool(t *testing.T) {
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockObservedActualShard = 0
+	networkProvider.MockTransactionsInPool = []*resources.TransactionInPool{
+		// Shard 1 to shard 0 (incoming)
+		{Hash: "aaaa", Sender: testscommon.TestAddressAlice, Receiver: testscommon.TestAddressBob},
+		// Shard 1 to shard 2
+		{Hash: "bbbb", Sender: testscommon.TestAddressAlice, Receiver: testscommon.TestAddressCarol},
+		// Shard 0 to shard 2 (outgoing)
+		{Hash: "cccc", Sender: testscommon.TestUserBShard0.Address, Receiver: testscommon.TestAddressCarol},
+		// Shard 0 to shard 0
+		{Hash: "dddd", Sender: testscommon.TestUserBShard0.Address, Receiver: testscommon.TestUserCShard0.Address},
+	}
+
+	service := NewMempoolService(networkProvider).(*mempoolService)
+
+	t.Run("without paging", func(t *testing.T) {
+		response, err := service.Mempool(context.Background(), &types.NetworkRequest{})
+		require.Nil(t, err)
+		require.Equal(t, []*types.TransactionIdentifier{
+			hashToTransactionIdentifier("aaaa"),
+			hashToTransactionIdentifier("cccc"),
+			hashToTransactionIdentifier("dddd"),
+		}, response.TransactionIdentifiers)
+	})
+
+	t.Run("with paging", func(t *testing.T) {
+		response, err := service.Mempool(context.Background(), &types.NetworkRequest{
+			Metadata: objectsMap{"page": 1, "pageSize": 2},
+		})
+		require.Nil(t, err)
+		require.Equal(t, []*types.TransactionIdentifier{hashToTransactionIdentifier("dddd")}, response.TransactionIdentifiers)
+
+		response, err = service.Mempool(context.Background(), &types.NetworkRequest{
+			Metadata: objectsMap{"page": 2, "pageSize": 2},
+		})
+		require.Nil(t, err)
+		require.Empty(t, response.TransactionIdentifiers)
+
+		response, err = service.Mempool(context.Background(), &types.NetworkRequest{
+			Metadata: objectsMap{"page": -1, "pageSize": 2},
+		})
+		require.Nil(t, response)
+		require.Equal(t, ErrInvalidInputParam, errCode(err.Code))
+	})
+
+	t.Run("with snapshot", func(t *testing.T) {
+		now := time.Now()
+		service.snapshot = nil
+
+		identifiers, err := service.getMempoolSnapshot(context.Background(), now)
+		require.Nil(t, err)
+		require.Len(t, identifiers, 3)
+
+		// The pool changes, but the snapshot is still fresh
+		networkProvider.MockTransactionsInPool = networkProvider.MockTransactionsInPool[:1]
+
+		identifiers, err = service.getMempoolSnapshot(context.Background(), now.Add(time.Second))
+		require.Nil(t, err)
+		require.Len(t, identifiers, 3)
+
+		identifiers, err = service.getMempoolSnapshot(context.Background(), now.Add(durationMempoolSnapshotMaxAge))
+		require.Nil(t, err)
+		require.Len(t, identifiers, 1)
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		service.snapshot = nil
+		networkProvider.MockNextError = errors.New("arbitrary error")
+		defer func() {
+			networkProvider.MockNextError = nil
+		}()
+
+		response, err := service.Mempool(context.Background(), &types.NetworkRequest{})
+		require.Nil(t, response)
+		require.Equal(t, ErrUnableToGetMempool, errCode(err.Code))
+	})
+}
+
 func getMempoolTransactionByHash(service server.MempoolAPIServicer, hash string) (*types.MempoolTransactionResponse, *types.Error) {
 	return service.MempoolTransaction(context.Background(), &types.MempoolTransactionRequest{
 		NetworkIdentifier:     nil,
@@ -5,21 +5,25 @@ import (
 
 	"github.com/coinbase/rosetta-sdk-go/server"
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
 	"github.com/multiversx/mx-chain-rosetta/version"
 )
 
 type networkService struct {
-	provider   NetworkProvider
-	extension  *networkProviderExtension
-	errFactory *errFactory
+	provider      NetworkProvider
+	extension     *networkProviderExtension
+	errFactory    *errFactory
+	searchService SearchService
 }
 
-// NewNetworkService creates a new instance of a networkService
-func NewNetworkService(networkProvider NetworkProvider) server.NetworkAPIServicer {
+// NewNetworkService creates a new instance of a networkService.
+// The search service is optional (nil, if the Search API isn't enabled); if provided, the indexing progress is reported by /network/status.
+func NewNetworkService(networkProvider NetworkProvider, searchService SearchService) server.NetworkAPIServicer {
 	return &networkService{
-		provider:   networkProvider,
-		extension:  newNetworkProviderExtension(networkProvider),
-		errFactory: newErrFactory(),
+		provider:      networkProvider,
+		extension:     newNetworkProviderExtension(networkProvider),
+		errFactory:    newErrFactory(),
+		searchService: searchService,
 	}
 }
 
@@ -42,33 +46,44 @@ func (service *networkService) NetworkList(
 
 // NetworkStatus implements the /network/status endpoint.
 func (service *networkService) NetworkStatus(
-	_ context.Context,
+	ctx context.Context,
 	_ *types.NetworkRequest,
 ) (*types.NetworkStatusResponse, *types.Error) {
 	if service.provider.IsOffline() {
 		return nil, service.errFactory.newErr(ErrOfflineMode)
 	}
 
-	nodeStatus, err := service.provider.GetNodeStatus()
+	nodeStatus, err := service.provider.GetNodeStatus(ctx)
 	if err != nil {
 		return nil, service.errFactory.newErrWithOriginal(ErrUnableToGetNodeStatus, err)
 	}
 
+	peerMetadata := objectsMap{
+		"version":           nodeStatus.Version,
+		"connections":       nodeStatus.ConnectedPeersCounts,
+		"epoch":             nodeStatus.Sync.CurrentEpoch,
+		"round":             nodeStatus.Sync.SynchronizedRound,
+		"networkRound":      nodeStatus.Sync.NetworkRound,
+		"lagInMilliseconds": nodeStatus.Sync.LagInMilliseconds,
+		"highestFinalNonce": nodeStatus.Sync.HighestFinalNonce,
+		"lastExecutedNonce": nodeStatus.Sync.LastExecutedNonce,
+	}
+
+	// The response itself has no metadata, thus the indexing progress is attached to the (single) peer.
+	if service.searchService != nil {
+		peerMetadata["searchIndex"] = service.getSearchIndexingProgress(nodeStatus.LatestBlock.Nonce)
+	}
+
 	networkStatusResponse := &types.NetworkStatusResponse{
 		CurrentBlockIdentifier: blockSummaryToIdentifier(&nodeStatus.LatestBlock),
 		CurrentBlockTimestamp:  getTimestampInMS(nodeStatus.LatestBlock.Timestamp, nodeStatus.LatestBlock.TimestampMs),
 		GenesisBlockIdentifier: service.extension.getGenesisBlockIdentifier(),
 		OldestBlockIdentifier:  blockSummaryToIdentifier(&nodeStatus.OldestBlockWithHistoricalState),
-		SyncStatus: &types.SyncStatus{
-			Synced: &nodeStatus.Synced,
-		},
+		SyncStatus:             getSyncStatus(nodeStatus),
 		Peers: []*types.Peer{
 			{
-				PeerID: nodeStatus.ObserverPublicKey,
-				Metadata: objectsMap{
-					"version":     nodeStatus.Version,
-					"connections": nodeStatus.ConnectedPeersCounts,
-				},
+				PeerID:   nodeStatus.ObserverPublicKey,
+				Metadata: peerMetadata,
 			},
 		},
 	}
@@ -76,12 +91,56 @@ func (service *networkService) NetworkStatus(
 	return networkStatusResponse, nil
 }
 
+// getSearchIndexingProgress describes the range of blocks covered by the local index (which backs the Search API), and how far behind the latest block it is
+func (service *networkService) getSearchIndexingProgress(latestNonce uint64) objectsMap {
+	progress, err := service.searchService.GetIndexingProgress()
+	if err != nil {
+		log.Warn("networkService.getSearchIndexingProgress()", "err", err)
+		return objectsMap{"error": err.Error()}
+	}
+
+	if progress.LastIndexedBlock == nil {
+		return objectsMap{"isEmpty": true}
+	}
+
+	blocksBehind := uint64(0)
+	if latestNonce > uint64(progress.LastIndexedBlock.Index) {
+		blocksBehind = latestNonce - uint64(progress.LastIndexedBlock.Index)
+	}
+
+	return objectsMap{
+		"isEmpty":           false,
+		"firstIndexedBlock": progress.FirstIndexedBlock.Index,
+		"lastIndexedBlock":  progress.LastIndexedBlock.Index,
+		"blocksBehind":      blocksBehind,
+	}
+}
+
+// getSyncStatus describes the synchronization progress of the observer: the current index is the latest nonce known by the observer,
+// while the target index is the (probable) highest nonce of the network.
+func getSyncStatus(nodeStatus *resources.AggregatedNodeStatus) *types.SyncStatus {
+	stage := syncStageSyncing
+	if nodeStatus.Synced {
+		stage = syncStageSynced
+	}
+
+	currentIndex := int64(nodeStatus.Sync.CurrentNonce)
+	targetIndex := int64(nodeStatus.Sync.ProbableHighestNonce)
+
+	return &types.SyncStatus{
+		CurrentIndex: &currentIndex,
+		TargetIndex:  &targetIndex,
+		Stage:        &stage,
+		Synced:       &nodeStatus.Synced,
+	}
+}
+
 // NetworkOptions implements the /network/options endpoint.
 func (service *networkService) NetworkOptions(
-	_ context.Context,
+	ctx context.Context,
 	_ *types.NetworkRequest,
 ) (*types.NetworkOptionsResponse, *types.Error) {
-	nodeVersion, err := service.getNodeVersion()
+	nodeVersion, err := service.getNodeVersion(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -97,17 +156,43 @@ func (service *networkService) NetworkOptions(
 			OperationTypes:          SupportedOperationTypes,
 			Errors:                  service.errFactory.getPossibleErrors(),
 			HistoricalBalanceLookup: true,
+			CallMethods:             service.getCallMethods(),
+			// Balances of the accounts are fully explained by the emitted operations: contracts are either fully handled or not reported at all ("handle-contracts").
+			BalanceExemptions: []*types.BalanceExemption{},
+			// Account-based model (there are no coins / UTXOs).
+			MempoolCoins:        false,
+			TimestampStartIndex: service.getTimestampStartIndex(),
 		},
 	}, nil
 }
 
-func (service *networkService) getNodeVersion() (string, *types.Error) {
+// getCallMethods returns the methods supported by /call (none, in offline mode).
+func (service *networkService) getCallMethods() []string {
+	if service.provider.IsOffline() {
+		return []string{}
+	}
+
+	return SupportedCallMethods
+}
+
+// getTimestampStartIndex returns the index of the first block with a valid timestamp.
+// The genesis block only has a valid timestamp if the genesis time is known (configured).
+func (service *networkService) getTimestampStartIndex() *int64 {
+	startIndex := service.extension.getGenesisBlockIdentifier().Index
+	if service.provider.GetGenesisTimestamp() <= 0 {
+		startIndex++
+	}
+
+	return &startIndex
+}
+
+func (service *networkService) getNodeVersion(ctx context.Context) (string, *types.Error) {
 	if service.provider.IsOffline() {
 		// In offline mode, Rosetta does not interact with the Node.
 		return nodeVersionForOfflineRosetta, nil
 	}
 
-	nodeStatus, err := service.provider.GetNodeStatus()
+	nodeStatus, err := service.provider.GetNodeStatus(ctx)
 	if err != nil {
 		return "", service.errFactory.newErrWithOriginal(ErrUnableToGetNodeStatus, err)
 	}
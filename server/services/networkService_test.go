@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
 	"github.com/multiversx/mx-chain-rosetta/testscommon"
 	"github.com/multiversx/mx-chain-rosetta/version"
 	"github.com/stretchr/testify/require"
@@ -13,7 +14,7 @@ import (
 func TestNetworkService_NetworkList(t *testing.T) {
 	networkProvider := testscommon.NewNetworkProviderMock()
 	networkProvider.MockNetworkConfig.NetworkName = "testnet"
-	service := NewNetworkService(networkProvider)
+	service := NewNetworkService(networkProvider, nil)
 
 	response, err := service.NetworkList(context.Background(), nil)
 
@@ -27,7 +28,7 @@ func TestNetworkService_NetworkList(t *testing.T) {
 func TestNetworkService_NetworkOptions(t *testing.T) {
 	networkProvider := testscommon.NewNetworkProviderMock()
 	networkProvider.MockNodeStatus.Version = "v1.2.3"
-	service := NewNetworkService(networkProvider)
+	service := NewNetworkService(networkProvider, nil)
 
 	networkOptions, err := service.NetworkOptions(context.Background(), nil)
 	require.Nil(t, err)
@@ -42,10 +43,35 @@ func TestNetworkService_NetworkOptions(t *testing.T) {
 			OperationStatuses:       supportedOperationStatuses,
 			OperationTypes:          SupportedOperationTypes,
 			Errors:                  newErrFactory().getPossibleErrors(),
+			CallMethods:             SupportedCallMethods,
+			BalanceExemptions:       []*types.BalanceExemption{},
+			MempoolCoins:            false,
+			TimestampStartIndex:     types.Int64(0),
 		},
 	}, networkOptions)
 }
 
+func TestNetworkService_NetworkOptionsWhenOffline(t *testing.T) {
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockIsOffline = true
+	service := NewNetworkService(networkProvider, nil)
+
+	networkOptions, err := service.NetworkOptions(context.Background(), nil)
+	require.Nil(t, err)
+	require.Equal(t, "N / A", networkOptions.Version.NodeVersion)
+	require.Equal(t, []string{}, networkOptions.Allow.CallMethods)
+}
+
+func TestNetworkService_NetworkOptionsWithoutGenesisTimestamp(t *testing.T) {
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockGenesisTimestamp = 0
+	service := NewNetworkService(networkProvider, nil)
+
+	networkOptions, err := service.NetworkOptions(context.Background(), nil)
+	require.Nil(t, err)
+	require.Equal(t, types.Int64(1), networkOptions.Allow.TimestampStartIndex)
+}
+
 func TestNetworkService_NetworkStatus(t *testing.T) {
 	networkProvider := testscommon.NewNetworkProviderMock()
 	networkProvider.MockGenesisBlockHash = "genesisHash"
@@ -61,8 +87,18 @@ func TestNetworkService_NetworkStatus(t *testing.T) {
 		"fullObs":  2,
 		"intraObs": 3,
 	}
+	networkProvider.MockNodeStatus.Sync = resources.NodeSyncStatus{
+		CurrentEpoch:         3,
+		CurrentNonce:         45,
+		ProbableHighestNonce: 45,
+		HighestFinalNonce:    44,
+		LastExecutedNonce:    44,
+		SynchronizedRound:    50,
+		NetworkRound:         51,
+		LagInMilliseconds:    6000,
+	}
 
-	service := NewNetworkService(networkProvider)
+	service := NewNetworkService(networkProvider, nil)
 
 	networkStatusResponse, err := service.NetworkStatus(context.Background(), nil)
 
@@ -82,7 +118,10 @@ func TestNetworkService_NetworkStatus(t *testing.T) {
 			Hash:  "oldestHash",
 		},
 		SyncStatus: &types.SyncStatus{
-			Synced: types.Bool(true),
+			CurrentIndex: types.Int64(45),
+			TargetIndex:  types.Int64(45),
+			Stage:        types.String("synced"),
+			Synced:       types.Bool(true),
 		},
 		Peers: []*types.Peer{
 			{
@@ -93,8 +132,66 @@ func TestNetworkService_NetworkStatus(t *testing.T) {
 						"fullObs":  2,
 						"intraObs": 3,
 					},
+					"epoch":             uint32(3),
+					"round":             uint64(50),
+					"networkRound":      uint64(51),
+					"lagInMilliseconds": uint64(6000),
+					"highestFinalNonce": uint64(44),
+					"lastExecutedNonce": uint64(44),
 				},
 			},
 		},
 	}, networkStatusResponse)
 }
+
+func TestNetworkService_NetworkStatusWhileSyncing(t *testing.T) {
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockNodeStatus.Synced = false
+	networkProvider.MockNodeStatus.Sync = resources.NodeSyncStatus{
+		CurrentNonce:         100,
+		ProbableHighestNonce: 500,
+		SynchronizedRound:    110,
+		NetworkRound:         510,
+		LagInMilliseconds:    2400000,
+	}
+
+	service := NewNetworkService(networkProvider, nil)
+
+	networkStatusResponse, err := service.NetworkStatus(context.Background(), nil)
+	require.Nil(t, err)
+	require.Equal(t, &types.SyncStatus{
+		CurrentIndex: types.Int64(100),
+		TargetIndex:  types.Int64(500),
+		Stage:        types.String("syncing"),
+		Synced:       types.Bool(false),
+	}, networkStatusResponse.SyncStatus)
+	require.Equal(t, uint64(2400000), networkStatusResponse.Peers[0].Metadata["lagInMilliseconds"])
+}
+
+func TestNetworkService_NetworkStatusWithSearchIndex(t *testing.T) {
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockNumShards = 1
+	setupBlocksForSearch(networkProvider.MockBlocksByNonce, networkProvider.MockNodeStatus)
+
+	searchService := createSearchServiceForTests(t, networkProvider)
+	service := NewNetworkService(networkProvider, searchService)
+
+	networkStatus, err := service.NetworkStatus(context.Background(), nil)
+	require.Nil(t, err)
+	require.Equal(t, objectsMap{"isEmpty": true}, networkStatus.Peers[0].Metadata["searchIndex"])
+
+	_, errIndex := searchService.IndexNewBlocks()
+	require.Nil(t, errIndex)
+
+	// The indexer is behind
+	networkProvider.MockNodeStatus.LatestBlock.Nonce = 5
+
+	networkStatus, err = service.NetworkStatus(context.Background(), nil)
+	require.Nil(t, err)
+	require.Equal(t, objectsMap{
+		"isEmpty":           false,
+		"firstIndexedBlock": int64(1),
+		"lastIndexedBlock":  int64(3),
+		"blocksBehind":      uint64(2),
+	}, networkStatus.Peers[0].Metadata["searchIndex"])
+}
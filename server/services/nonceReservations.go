@@ -0,0 +1,76 @@
+package services
+
+import (
+	"sync"
+	"time"
+)
+
+// nonceReservations holds (for a while) the nonces handed out to construction flows, so that concurrent flows of the same sender get distinct nonces
+type nonceReservations struct {
+	mutex    sync.Mutex
+	bySender map[string]map[uint64]time.Time
+	duration time.Duration
+}
+
+func newNonceReservations(duration time.Duration) *nonceReservations {
+	return &nonceReservations{
+		bySender: make(map[string]map[uint64]time.Time),
+		duration: duration,
+	}
+}
+
+// decideNextNonce picks the lowest nonce (starting from the account nonce) that is neither in the pool, nor reserved.
+// If "shouldReserve" is set, the picked nonce is reserved, as well.
+func (reservations *nonceReservations) decideNextNonce(sender string, accountNonce uint64, poolNonces []uint64, shouldReserve bool) uint64 {
+	reservations.mutex.Lock()
+	defer reservations.mutex.Unlock()
+
+	now := time.Now()
+	reservations.prune(sender, accountNonce, now)
+
+	takenNonces := make(map[uint64]struct{})
+	for _, nonce := range poolNonces {
+		takenNonces[nonce] = struct{}{}
+	}
+	for nonce := range reservations.bySender[sender] {
+		takenNonces[nonce] = struct{}{}
+	}
+
+	nonce := accountNonce
+	for {
+		_, isTaken := takenNonces[nonce]
+		if !isTaken {
+			break
+		}
+
+		nonce++
+	}
+
+	if shouldReserve {
+		if reservations.bySender[sender] == nil {
+			reservations.bySender[sender] = make(map[uint64]time.Time)
+		}
+
+		reservations.bySender[sender][nonce] = now.Add(reservations.duration)
+	}
+
+	return nonce
+}
+
+// prune forgets the expired reservations (of all senders) and the ones already consumed (of the given sender)
+func (reservations *nonceReservations) prune(sender string, accountNonce uint64, now time.Time) {
+	for someSender, nonces := range reservations.bySender {
+		for nonce, expiry := range nonces {
+			isExpired := now.After(expiry)
+			isConsumed := someSender == sender && nonce < accountNonce
+
+			if isExpired || isConsumed {
+				delete(nonces, nonce)
+			}
+		}
+
+		if len(nonces) == 0 {
+			delete(reservations.bySender, someSender)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonceReservations_DecideNextNonce(t *testing.T) {
+	t.Parallel()
+
+	alice := testscommon.TestAddressAlice
+	bob := testscommon.TestAddressBob
+
+	t.Run("without reservations", func(t *testing.T) {
+		t.Parallel()
+
+		reservations := newNonceReservations(time.Minute)
+
+		require.Equal(t, uint64(42), reservations.decideNextNonce(alice, 42, nil, false))
+		require.Equal(t, uint64(42), reservations.decideNextNonce(alice, 42, nil, false))
+		require.Equal(t, uint64(44), reservations.decideNextNonce(alice, 42, []uint64{42, 43}, false))
+		require.Equal(t, uint64(43), reservations.decideNextNonce(alice, 42, []uint64{42, 44}, false))
+		require.Equal(t, uint64(42), reservations.decideNextNonce(alice, 42, []uint64{41}, false))
+	})
+
+	t.Run("with reservations", func(t *testing.T) {
+		t.Parallel()
+
+		reservations := newNonceReservations(time.Minute)
+
+		require.Equal(t, uint64(42), reservations.decideNextNonce(alice, 42, nil, true))
+		require.Equal(t, uint64(43), reservations.decideNextNonce(alice, 42, nil, true))
+		require.Equal(t, uint64(45), reservations.decideNextNonce(alice, 42, []uint64{44}, true))
+
+		// Flows that don't reserve still skip the reserved nonces
+		require.Equal(t, uint64(46), reservations.decideNextNonce(alice, 42, []uint64{44}, false))
+
+		// Other senders aren't affected
+		require.Equal(t, uint64(7), reservations.decideNextNonce(bob, 7, nil, true))
+
+		// Consumed reservations are forgotten
+		require.Equal(t, uint64(44), reservations.decideNextNonce(alice, 44, nil, false))
+		require.Len(t, reservations.bySender[alice], 1)
+	})
+
+	t.Run("with expired reservations", func(t *testing.T) {
+		t.Parallel()
+
+		reservations := newNonceReservations(-time.Second)
+
+		require.Equal(t, uint64(42), reservations.decideNextNonce(alice, 42, nil, true))
+		require.Equal(t, uint64(42), reservations.decideNextNonce(alice, 42, nil, true))
+		require.Equal(t, uint64(7), reservations.decideNextNonce(bob, 7, nil, false))
+		require.Empty(t, reservations.bySender)
+	})
+}
@@ -81,3 +81,27 @@ func (service *offlineService) NetworkList(
 ) (*types.NetworkListResponse, *types.Error) {
 	return nil, service.errFactory.newErr(ErrOfflineMode)
 }
+
+// Call implements the /call endpoint.
+func (service *offlineService) Call(
+	_ context.Context,
+	_ *types.CallRequest,
+) (*types.CallResponse, *types.Error) {
+	return nil, service.errFactory.newErr(ErrOfflineMode)
+}
+
+// EventsBlocks implements the /events/blocks endpoint.
+func (service *offlineService) EventsBlocks(
+	_ context.Context,
+	_ *types.EventsBlocksRequest,
+) (*types.EventsBlocksResponse, *types.Error) {
+	return nil, service.errFactory.newErr(ErrOfflineMode)
+}
+
+// SearchTransactions implements the /search/transactions endpoint.
+func (service *offlineService) SearchTransactions(
+	_ context.Context,
+	_ *types.SearchTransactionsRequest,
+) (*types.SearchTransactionsResponse, *types.Error) {
+	return nil, service.errFactory.newErr(ErrOfflineMode)
+}
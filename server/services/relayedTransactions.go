@@ -14,6 +14,7 @@ type innerTransactionOfRelayedV1 struct {
 	Value          big.Int `json:"value"`
 	ReceiverPubKey []byte  `json:"receiver"`
 	SenderPubKey   []byte  `json:"sender"`
+	Data           []byte  `json:"data"`
 }
 
 func isRelayedV1Transaction(tx *transaction.ApiTransactionResult) bool {
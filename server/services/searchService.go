@@ -0,0 +1,300 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-rosetta/server/index"
+)
+
+// ArgsNewSearchService holds the arguments for creating a searchService
+type ArgsNewSearchService struct {
+	Provider NetworkProvider
+	// Folder of the local index (created if missing)
+	IndexPath string
+}
+
+// IndexingProgress describes the progress of the local index backing the Search API
+type IndexingProgress struct {
+	FirstIndexedBlock *types.BlockIdentifier
+	LastIndexedBlock  *types.BlockIdentifier
+}
+
+type searchService struct {
+	provider       NetworkProvider
+	extension      *networkProviderExtension
+	errFactory     *errFactory
+	txsTransformer *transactionsTransformer
+	index          transactionsIndex
+
+	closing     chan struct{}
+	closingOnce sync.Once
+	loopsGroup  sync.WaitGroup
+}
+
+// NewSearchService will create a new instance of searchService, along with its local index of transactions.
+// The index is populated (in the background) from the transformed blocks, starting with the oldest block with historical state (at the time of the first run).
+func NewSearchService(args ArgsNewSearchService) (SearchService, error) {
+	transactionsIndex, err := index.NewTransactionsIndex(index.ArgsNewTransactionsIndex{
+		Path:              args.IndexPath,
+		OperationStatuses: supportedOperationStatuses,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newSearchServiceWithIndex(args.Provider, transactionsIndex), nil
+}
+
+func newSearchServiceWithIndex(provider NetworkProvider, transactionsIndex transactionsIndex) *searchService {
+	return &searchService{
+		provider:       provider,
+		extension:      newNetworkProviderExtension(provider),
+		errFactory:     newErrFactory(),
+		txsTransformer: newTransactionsTransformer(provider),
+		index:          transactionsIndex,
+		closing:        make(chan struct{}),
+	}
+}
+
+// SearchTransactions implements the /search/transactions endpoint.
+// Only the transactions already indexed (see /network/status, peer metadata) are considered.
+func (service *searchService) SearchTransactions(
+	ctx context.Context,
+	request *types.SearchTransactionsRequest,
+) (*types.SearchTransactionsResponse, *types.Error) {
+	query, err := service.requestToQuery(ctx, request)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrInvalidInputParam, err)
+	}
+
+	result, err := service.index.Search(*query)
+	if err != nil {
+		return nil, service.errFactory.newErrWithOriginal(ErrUnableToSearchTransactions, err)
+	}
+
+	response := &types.SearchTransactionsResponse{
+		Transactions: result.Transactions,
+		TotalCount:   result.TotalCount,
+	}
+
+	nextOffset := query.Offset + int64(len(result.Transactions))
+	if nextOffset < result.TotalCount {
+		response.NextOffset = &nextOffset
+	}
+
+	return response, nil
+}
+
+func (service *searchService) requestToQuery(ctx context.Context, request *types.SearchTransactionsRequest) (*index.Query, error) {
+	query := &index.Query{
+		Operator: types.AND,
+		MaxBlock: request.MaxBlock,
+		Limit:    maxNumSearchResultsPerResponse,
+		Currency: request.Currency,
+		Success:  request.Success,
+	}
+
+	if request.Operator != nil {
+		query.Operator = *request.Operator
+	}
+	if request.Offset != nil {
+		// The transactions before the offset are scanned, as well (thus, deep paging is refused).
+		if *request.Offset > maxSearchOffset {
+			return nil, newErrSearchOffsetTooLarge(*request.Offset)
+		}
+
+		query.Offset = *request.Offset
+	}
+	if request.Limit != nil && *request.Limit > 0 && *request.Limit < query.Limit {
+		query.Limit = *request.Limit
+	}
+	if request.TransactionIdentifier != nil {
+		query.TransactionHash = request.TransactionIdentifier.Hash
+	}
+	if request.CoinIdentifier != nil {
+		// Coins aren't supported (account-based model).
+		return nil, errCoinsNotSupported
+	}
+	if request.Status != nil {
+		query.OperationStatus = *request.Status
+	}
+	if request.Type != nil {
+		query.OperationType = *request.Type
+	}
+
+	// Sub-accounts aren't used, thus "account_identifier" and "address" refer to the same thing.
+	// Addresses are indexed in their bech32 form, thus the other forms (hex public keys, usernames) are resolved beforehand.
+	if request.AccountIdentifier != nil {
+		address, err := service.extension.resolveAddress(ctx, request.AccountIdentifier.Address)
+		if err != nil {
+			return nil, err
+		}
+
+		query.Address = address
+	}
+	if request.Address != nil {
+		address, err := service.extension.resolveAddress(ctx, *request.Address)
+		if err != nil {
+			return nil, err
+		}
+		if len(query.Address) > 0 && query.Address != address {
+			return nil, errConflictingAddressConditions
+		}
+
+		query.Address = address
+	}
+
+	return query, nil
+}
+
+// IndexNewBlocks indexes the blocks following the last indexed one (at most "maxNumBlocksIndexedPerRound" of them), up to the latest (final) block.
+// If the last indexed block isn't canonical anymore, it's removed from the index (and the indexing continues at the next round).
+func (service *searchService) IndexNewBlocks() (int, error) {
+	// The indexer works in the background, thus it isn't bound to any client request.
+	ctx := context.Background()
+
+	latestBlock, err := service.provider.GetLatestBlockSummary(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	lastIndexedBlock, ok, err := service.index.GetLastIndexedBlock()
+	if err != nil {
+		return 0, err
+	}
+
+	nextNonce := uint64(0)
+	if ok {
+		nextNonce = uint64(lastIndexedBlock.Index) + 1
+	} else {
+		nextNonce, err = service.getFirstNonceToIndex(ctx)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	numIndexed := 0
+
+	for nonce := nextNonce; nonce <= latestBlock.Nonce && numIndexed < maxNumBlocksIndexedPerRound; nonce++ {
+		block, err := service.provider.GetBlockByNonce(ctx, nonce)
+		if err != nil {
+			return numIndexed, err
+		}
+
+		if ok && block.PrevBlockHash != lastIndexedBlock.Hash {
+			log.Info("searchService: last indexed block isn't canonical anymore", "nonce", lastIndexedBlock.Index, "hash", lastIndexedBlock.Hash)
+			return numIndexed, service.index.RemoveLastBlock()
+		}
+
+		transactions, err := service.txsTransformer.transformBlockTxs(block)
+		if err != nil {
+			return numIndexed, err
+		}
+
+		blockIdentifier := blockToIdentifier(block)
+
+		err = service.index.IndexBlock(blockIdentifier, transactions)
+		if err != nil {
+			return numIndexed, err
+		}
+
+		lastIndexedBlock = blockIdentifier
+		ok = true
+		numIndexed++
+	}
+
+	return numIndexed, nil
+}
+
+func (service *searchService) getFirstNonceToIndex(ctx context.Context) (uint64, error) {
+	nodeStatus, err := service.provider.GetNodeStatus(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// The genesis block isn't indexed (it isn't a regular block).
+	genesisNonce := service.provider.GetGenesisBlockSummary().Nonce
+	firstNonce := nodeStatus.OldestBlockWithHistoricalState.Nonce
+	if firstNonce <= genesisNonce {
+		firstNonce = genesisNonce + 1
+	}
+
+	return firstNonce, nil
+}
+
+// GetIndexingProgress gets the range of the indexed blocks (nil, if nothing has been indexed yet)
+func (service *searchService) GetIndexingProgress() (*IndexingProgress, error) {
+	firstIndexedBlock, _, err := service.index.GetFirstIndexedBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	lastIndexedBlock, _, err := service.index.GetLastIndexedBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return &IndexingProgress{
+		FirstIndexedBlock: firstIndexedBlock,
+		LastIndexedBlock:  lastIndexedBlock,
+	}, nil
+}
+
+// StartIndexingLoop periodically indexes the new blocks (in the background), until the service is closed.
+// While catching up, the blocks are indexed without waiting for the next tick. A zero interval disables the indexing.
+func (service *searchService) StartIndexingLoop(interval time.Duration) {
+	if interval == 0 {
+		log.Info("searchService.StartIndexingLoop(): indexing is disabled")
+		return
+	}
+
+	service.loopsGroup.Add(1)
+
+	go func() {
+		defer service.loopsGroup.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				service.indexUntilCaughtUp()
+			case <-service.closing:
+				return
+			}
+		}
+	}()
+}
+
+func (service *searchService) indexUntilCaughtUp() {
+	for {
+		numIndexed, err := service.IndexNewBlocks()
+		if err != nil {
+			log.Warn("searchService.StartIndexingLoop(): cannot index blocks", "err", err)
+			return
+		}
+		if numIndexed < maxNumBlocksIndexedPerRound {
+			return
+		}
+
+		select {
+		case <-service.closing:
+			return
+		default:
+		}
+	}
+}
+
+// Close stops the background activities of the service, then closes the local index
+func (service *searchService) Close() error {
+	service.closingOnce.Do(func() {
+		close(service.closing)
+	})
+
+	service.loopsGroup.Wait()
+	return service.index.Close()
+}
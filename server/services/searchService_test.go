@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-core-go/data/api"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func createSearchServiceForTests(t *testing.T, networkProvider NetworkProvider) SearchService {
+	service, err := NewSearchService(ArgsNewSearchService{
+		Provider:  networkProvider,
+		IndexPath: t.TempDir(),
+	})
+	require.Nil(t, err)
+
+	t.Cleanup(func() {
+		_ = service.Close()
+	})
+
+	return service
+}
+
+func setupBlocksForSearch(blocksByNonce map[uint64]*api.Block, nodeStatus *resources.AggregatedNodeStatus) {
+	createBlock := func(nonce uint64, hash string, previousHash string, txs ...*transaction.ApiTransactionResult) *api.Block {
+		return &api.Block{
+			Nonce:         nonce,
+			Hash:          hash,
+			PrevBlockHash: previousHash,
+			MiniBlocks:    []*api.MiniBlock{{Transactions: txs}},
+		}
+	}
+
+	createTransfer := func(hash string, sender string, receiver string) *transaction.ApiTransactionResult {
+		return &transaction.ApiTransactionResult{
+			Hash:             hash,
+			Type:             string(transaction.TxTypeNormal),
+			Sender:           sender,
+			Receiver:         receiver,
+			Value:            "1",
+			InitiallyPaidFee: "50000000000000",
+		}
+	}
+
+	blocksByNonce[1] = createBlock(1, "0001", "0000", createTransfer("aaaa", testscommon.TestAddressAlice, testscommon.TestAddressBob))
+	blocksByNonce[2] = createBlock(2, "0002", "0001")
+	blocksByNonce[3] = createBlock(3, "0003", "0002", createTransfer("bbbb", testscommon.TestAddressBob, testscommon.TestAddressAlice))
+	nodeStatus.LatestBlock = resources.BlockSummary{Nonce: 3, Hash: "0003", PreviousBlockHash: "0002"}
+}
+
+func TestSearchService_IndexNewBlocks(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockNumShards = 1
+	setupBlocksForSearch(networkProvider.MockBlocksByNonce, networkProvider.MockNodeStatus)
+
+	service := createSearchServiceForTests(t, networkProvider)
+
+	progress, err := service.GetIndexingProgress()
+	require.Nil(t, err)
+	require.Nil(t, progress.LastIndexedBlock)
+
+	// The genesis block is skipped
+	numIndexed, err := service.IndexNewBlocks()
+	require.Nil(t, err)
+	require.Equal(t, 3, numIndexed)
+
+	progress, err = service.GetIndexingProgress()
+	require.Nil(t, err)
+	require.Equal(t, &types.BlockIdentifier{Index: 1, Hash: "0001"}, progress.FirstIndexedBlock)
+	require.Equal(t, &types.BlockIdentifier{Index: 3, Hash: "0003"}, progress.LastIndexedBlock)
+
+	// Nothing new
+	numIndexed, err = service.IndexNewBlocks()
+	require.Nil(t, err)
+	require.Equal(t, 0, numIndexed)
+
+	// Block 3 isn't canonical anymore
+	networkProvider.MockBlocksByNonce[3] = &api.Block{Nonce: 3, Hash: "0003b", PrevBlockHash: "0002"}
+	networkProvider.MockBlocksByNonce[4] = &api.Block{Nonce: 4, Hash: "0004", PrevBlockHash: "0003b"}
+	networkProvider.MockNodeStatus.LatestBlock = resources.BlockSummary{Nonce: 4, Hash: "0004", PreviousBlockHash: "0003b"}
+
+	numIndexed, err = service.IndexNewBlocks()
+	require.Nil(t, err)
+	require.Equal(t, 0, numIndexed)
+
+	progress, _ = service.GetIndexingProgress()
+	require.Equal(t, &types.BlockIdentifier{Index: 2, Hash: "0002"}, progress.LastIndexedBlock)
+
+	numIndexed, err = service.IndexNewBlocks()
+	require.Nil(t, err)
+	require.Equal(t, 2, numIndexed)
+
+	progress, _ = service.GetIndexingProgress()
+	require.Equal(t, &types.BlockIdentifier{Index: 4, Hash: "0004"}, progress.LastIndexedBlock)
+
+	response, errSearch := service.SearchTransactions(context.Background(), &types.SearchTransactionsRequest{
+		TransactionIdentifier: &types.TransactionIdentifier{Hash: "bbbb"},
+	})
+	require.Nil(t, errSearch)
+	require.Equal(t, int64(0), response.TotalCount)
+}
+
+func TestSearchService_SearchTransactions(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockNumShards = 1
+	networkProvider.MockUsernames["alice.elrond"] = testscommon.TestAddressAlice
+	setupBlocksForSearch(networkProvider.MockBlocksByNonce, networkProvider.MockNodeStatus)
+
+	service := createSearchServiceForTests(t, networkProvider)
+
+	_, err := service.IndexNewBlocks()
+	require.Nil(t, err)
+
+	getHashes := func(response *types.SearchTransactionsResponse) []string {
+		hashes := make([]string, 0)
+		for _, blockTransaction := range response.Transactions {
+			hashes = append(hashes, blockTransaction.Transaction.TransactionIdentifier.Hash)
+		}
+
+		return hashes
+	}
+
+	t.Run("by account", func(t *testing.T) {
+		response, err := service.SearchTransactions(context.Background(), &types.SearchTransactionsRequest{
+			AccountIdentifier: &types.AccountIdentifier{Address: testscommon.TestAddressAlice},
+		})
+		require.Nil(t, err)
+		require.Equal(t, []string{"bbbb", "aaaa"}, getHashes(response))
+		require.Equal(t, int64(2), response.TotalCount)
+		require.Nil(t, response.NextOffset)
+		require.Equal(t, &types.BlockIdentifier{Index: 3, Hash: "0003"}, response.Transactions[0].BlockIdentifier)
+	})
+
+	t.Run("by address, with paging", func(t *testing.T) {
+		response, err := service.SearchTransactions(context.Background(), &types.SearchTransactionsRequest{
+			Address: types.String(testscommon.TestAddressBob),
+			Limit:   types.Int64(1),
+		})
+		require.Nil(t, err)
+		require.Equal(t, []string{"bbbb"}, getHashes(response))
+		require.Equal(t, int64(2), response.TotalCount)
+		require.Equal(t, types.Int64(1), response.NextOffset)
+
+		response, err = service.SearchTransactions(context.Background(), &types.SearchTransactionsRequest{
+			Address: types.String(testscommon.TestAddressBob),
+			Offset:  response.NextOffset,
+			Limit:   types.Int64(1),
+		})
+		require.Nil(t, err)
+		require.Equal(t, []string{"aaaa"}, getHashes(response))
+		require.Nil(t, response.NextOffset)
+	})
+
+	t.Run("by hash, with max block", func(t *testing.T) {
+		response, err := service.SearchTransactions(context.Background(), &types.SearchTransactionsRequest{
+			TransactionIdentifier: &types.TransactionIdentifier{Hash: "bbbb"},
+			MaxBlock:              types.Int64(2),
+		})
+		require.Nil(t, err)
+		require.Len(t, response.Transactions, 0)
+		require.Equal(t, int64(0), response.TotalCount)
+	})
+
+	t.Run("by type and currency", func(t *testing.T) {
+		response, err := service.SearchTransactions(context.Background(), &types.SearchTransactionsRequest{
+			Type:     types.String(opFee),
+			Currency: newNetworkProviderExtension(networkProvider).getNativeCurrency(),
+			Success:  types.Bool(true),
+		})
+		require.Nil(t, err)
+		require.Equal(t, []string{"bbbb", "aaaa"}, getHashes(response))
+	})
+
+	t.Run("with conflicting addresses", func(t *testing.T) {
+		response, err := service.SearchTransactions(context.Background(), &types.SearchTransactionsRequest{
+			AccountIdentifier: &types.AccountIdentifier{Address: testscommon.TestAddressAlice},
+			Address:           types.String(testscommon.TestAddressBob),
+		})
+		require.Nil(t, response)
+		require.Equal(t, int32(ErrInvalidInputParam), err.Code)
+	})
+
+	t.Run("by hex-encoded public key", func(t *testing.T) {
+		response, err := service.SearchTransactions(context.Background(), &types.SearchTransactionsRequest{
+			AccountIdentifier: &types.AccountIdentifier{Address: hex.EncodeToString(testscommon.TestPubKeyAlice)},
+		})
+		require.Nil(t, err)
+		require.Equal(t, []string{"bbbb", "aaaa"}, getHashes(response))
+	})
+
+	t.Run("by username", func(t *testing.T) {
+		response, err := service.SearchTransactions(context.Background(), &types.SearchTransactionsRequest{
+			Address: types.String("alice.elrond"),
+		})
+		require.Nil(t, err)
+		require.Equal(t, []string{"bbbb", "aaaa"}, getHashes(response))
+
+		// Not conflicting: both refer to the same account.
+		response, err = service.SearchTransactions(context.Background(), &types.SearchTransactionsRequest{
+			AccountIdentifier: &types.AccountIdentifier{Address: testscommon.TestAddressAlice},
+			Address:           types.String("alice.elrond"),
+		})
+		require.Nil(t, err)
+		require.Equal(t, int64(2), response.TotalCount)
+	})
+
+	t.Run("with unresolvable address", func(t *testing.T) {
+		response, err := service.SearchTransactions(context.Background(), &types.SearchTransactionsRequest{
+			Address: types.String("nobody.elrond"),
+		})
+		require.Nil(t, response)
+		require.Equal(t, int32(ErrInvalidInputParam), err.Code)
+	})
+
+	t.Run("with offset too large", func(t *testing.T) {
+		response, err := service.SearchTransactions(context.Background(), &types.SearchTransactionsRequest{
+			Offset: types.Int64(maxSearchOffset + 1),
+		})
+		require.Nil(t, response)
+		require.Equal(t, int32(ErrInvalidInputParam), err.Code)
+		require.Contains(t, err.Details["originalError"], "search offset too large")
+	})
+
+	t.Run("by coin", func(t *testing.T) {
+		response, err := service.SearchTransactions(context.Background(), &types.SearchTransactionsRequest{
+			CoinIdentifier: &types.CoinIdentifier{Identifier: "coin"},
+		})
+		require.Nil(t, response)
+		require.Equal(t, int32(ErrInvalidInputParam), err.Code)
+		require.Contains(t, err.Details["originalError"], "coins are not supported")
+	})
+}
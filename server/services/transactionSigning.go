@@ -0,0 +1,72 @@
+package services
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-core-go/hashing"
+)
+
+// validateTransactionVersionAndOptions checks the (protocol) constraints between the version and the options of a transaction
+func validateTransactionVersionAndOptions(version uint32, options uint32) error {
+	if version < uint32(transactionVersion) {
+		return errors.New("bad transaction: 'version' must be at least 1")
+	}
+	if options != 0 && version < uint32(transactionVersionWithOptions) {
+		return errors.New("bad transaction: 'options' require 'version' to be at least 2")
+	}
+
+	return nil
+}
+
+func isSignedWithHash(options uint32) bool {
+	return options&transactionOptionSignedWithHash != 0
+}
+
+// computeSigningPayload computes the bytes to be signed, given the serialized (unsigned) transaction.
+// When the transaction has the "sign with hash" option set (useful for hardware signers, with small buffers),
+// the protocol expects the signature over the Keccak-256 digest of the serialized transaction, instead of the serialized transaction itself.
+func computeSigningPayload(hasher hashing.Hasher, options uint32, txJson []byte) []byte {
+	if isSignedWithHash(options) {
+		return hasher.Compute(string(txJson))
+	}
+
+	return txJson
+}
+
+// verifyTransactionSignature checks an Ed25519 signature against the signing payload and the public key of the (declared) signer
+func verifyTransactionSignature(signerPubKey []byte, signingPayload []byte, signature []byte) error {
+	if len(signerPubKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("%w: bad public key length: %d", errInvalidSignature, len(signerPubKey))
+	}
+	if len(signature) != ed25519.SignatureSize {
+		return fmt.Errorf("%w: bad signature length: %d", errInvalidSignature, len(signature))
+	}
+	if !ed25519.Verify(signerPubKey, signingPayload, signature) {
+		return fmt.Errorf("%w: signature does not match the payload and the public key of the signer", errInvalidSignature)
+	}
+
+	return nil
+}
+
+// checkDeclaredSignerOfSignature checks that a signature (as received by /construction/combine) is declared to be produced by the expected signer
+func checkDeclaredSignerOfSignature(signature *types.Signature, expectedSigner string, expectedSignerPubKey []byte) error {
+	if signature.SignatureType != "" && signature.SignatureType != types.Ed25519 {
+		return fmt.Errorf("%w: unsupported signature type: %s", errInvalidSignature, signature.SignatureType)
+	}
+
+	payload := signature.SigningPayload
+	if payload != nil && payload.AccountIdentifier != nil && payload.AccountIdentifier.Address != expectedSigner {
+		return fmt.Errorf("%w: unexpected signer: %s", errInvalidSignature, payload.AccountIdentifier.Address)
+	}
+
+	publicKey := signature.PublicKey
+	if publicKey != nil && len(publicKey.Bytes) > 0 && !bytes.Equal(publicKey.Bytes, expectedSignerPubKey) {
+		return fmt.Errorf("%w: public key does not belong to the signer", errInvalidSignature)
+	}
+
+	return nil
+}
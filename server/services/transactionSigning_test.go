@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/multiversx/mx-chain-core-go/hashing/keccak"
+	"github.com/multiversx/mx-chain-rosetta/server/resources"
+	"github.com/multiversx/mx-chain-rosetta/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTransactionVersionAndOptions(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, validateTransactionVersionAndOptions(1, 0))
+	require.Nil(t, validateTransactionVersionAndOptions(2, 0))
+	require.Nil(t, validateTransactionVersionAndOptions(2, 1))
+	require.ErrorContains(t, validateTransactionVersionAndOptions(0, 0), "'version' must be at least 1")
+	require.ErrorContains(t, validateTransactionVersionAndOptions(1, 1), "'options' require 'version' to be at least 2")
+}
+
+func TestComputeSigningPayload(t *testing.T) {
+	t.Parallel()
+
+	hasher := keccak.NewKeccak()
+	txJson := []byte(`{"nonce":42}`)
+
+	require.Equal(t, txJson, computeSigningPayload(hasher, 0, txJson))
+	require.Equal(t, hasher.Compute(string(txJson)), computeSigningPayload(hasher, transactionOptionSignedWithHash, txJson))
+	require.Len(t, computeSigningPayload(hasher, transactionOptionSignedWithHash, txJson), 32)
+}
+
+func TestConstructionService_FlowWithSignWithHash(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	networkProvider.MockAccountsByAddress[testscommon.TestAddressAlice] = &resources.Account{
+		Address: testscommon.TestAddressAlice,
+		Nonce:   42,
+	}
+
+	service := NewConstructionService(networkProvider)
+
+	preprocessResponse, errTyped := service.ConstructionPreprocess(context.Background(),
+		&types.ConstructionPreprocessRequest{
+			Metadata: objectsMap{
+				"sender":         testscommon.TestAddressAlice,
+				"receiver":       testscommon.TestAddressBob,
+				"amount":         "1234",
+				"currencySymbol": "XeGLD",
+				"signWithHash":   true,
+			},
+		},
+	)
+	require.Nil(t, errTyped)
+	require.Equal(t, true, preprocessResponse.Options["signWithHash"])
+
+	metadataResponse, errTyped := service.ConstructionMetadata(context.Background(),
+		&types.ConstructionMetadataRequest{
+			Options: preprocessResponse.Options,
+		},
+	)
+	require.Nil(t, errTyped)
+	require.Equal(t, float64(2), metadataResponse.Metadata["version"])
+	require.Equal(t, float64(1), metadataResponse.Metadata["options"])
+
+	payloadsResponse, errTyped := service.ConstructionPayloads(context.Background(),
+		&types.ConstructionPayloadsRequest{
+			Metadata: metadataResponse.Metadata,
+		},
+	)
+	require.Nil(t, errTyped)
+
+	expectedTxJson := `{"nonce":42,"value":"1234","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1000000000,"gasLimit":50000,"chainID":"T","version":2,"options":1}`
+	require.Equal(t, expectedTxJson, payloadsResponse.UnsignedTransaction)
+	require.Equal(t, keccak.NewKeccak().Compute(expectedTxJson), payloadsResponse.Payloads[0].Bytes)
+
+	parseResponse, errTyped := service.ConstructionParse(context.Background(),
+		&types.ConstructionParseRequest{
+			Signed:      false,
+			Transaction: payloadsResponse.UnsignedTransaction,
+		},
+	)
+	require.Nil(t, errTyped)
+	require.Len(t, parseResponse.Operations, 2)
+
+	combineResponse, errTyped := service.ConstructionCombine(context.Background(),
+		&types.ConstructionCombineRequest{
+			UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+			Signatures: []*types.Signature{
+				{
+					Bytes: ed25519.Sign(testscommon.TestPrivateKeyAlice, payloadsResponse.Payloads[0].Bytes),
+				},
+			},
+		},
+	)
+	require.Nil(t, errTyped)
+	require.Contains(t, combineResponse.SignedTransaction, `"version":2,"options":1`)
+
+	hashResponse, errTyped := service.ConstructionHash(context.Background(),
+		&types.ConstructionHashRequest{
+			SignedTransaction: combineResponse.SignedTransaction,
+		},
+	)
+	require.Nil(t, errTyped)
+	require.NotEmpty(t, hashResponse.TransactionIdentifier.Hash)
+}
+
+func TestConstructionService_ParseAndHashRejectOptionsWithoutVersion2(t *testing.T) {
+	t.Parallel()
+
+	networkProvider := testscommon.NewNetworkProviderMock()
+	service := NewConstructionService(networkProvider)
+
+	tx := `{"nonce":42,"value":"1234","receiver":"erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx","sender":"erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th","gasPrice":1000000000,"gasLimit":50000,"signature":"aabb","chainID":"T","version":1,"options":1}`
+
+	_, errTyped := service.ConstructionParse(context.Background(),
+		&types.ConstructionParseRequest{
+			Signed:      true,
+			Transaction: tx,
+		},
+	)
+	require.Equal(t, int32(ErrMalformedValue), errTyped.Code)
+
+	_, errTyped = service.ConstructionHash(context.Background(),
+		&types.ConstructionHashRequest{
+			SignedTransaction: tx,
+		},
+	)
+	require.Equal(t, int32(ErrMalformedValue), errTyped.Code)
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+const (
+	numArgumentsOfESDTTransfer                     = 2
+	numArgumentsOfESDTNFTTransfer                  = 4
+	numArgumentsOfMultiESDTNFTTransferHeader       = 2
+	numArgumentsPerTransferOfMultiESDTNFTTransfer  = 3
+	numArgumentsOfRelayedV1                        = 1
+	numArgumentsOfRelayedV2                        = 4
+	indexOfInnerReceiverInArgumentsOfRelayedV2     = 0
+	indexOfInnerDataInArgumentsOfRelayedV2         = 2
+	indexOfDestinationInArgumentsOfESDTNFTTransfer = 3
+)
+
+// parseTransfersOfTransaction returns the transfers requested by a (not yet executed) transaction, or by an inner transaction of a relayed one.
+// Transfers are represented as "eventESDT" items (same as in the case of the data API), with the native currency identified by "EGLD-000000".
+func (extension *networkProviderExtension) parseTransfersOfTransaction(sender string, receiver string, value string, txData []byte, isInner bool) ([]*eventESDT, error) {
+	if receiver == systemContractDeployAddress {
+		return nil, errCannotParseContractDeployment
+	}
+
+	valueBig, ok := big.NewInt(0).SetString(value, 10)
+	if !ok || valueBig.Sign() < 0 {
+		return nil, fmt.Errorf("%w: bad value: %s", errMalformedTransactionData, value)
+	}
+
+	hasValue := valueBig.Sign() > 0
+	parts := strings.Split(string(txData), argumentsSeparator)
+	function := parts[0]
+
+	switch function {
+	case builtInFunctionESDTTransfer, builtInFunctionESDTNFTTransfer, builtInFunctionMultiESDTNFTTransfer:
+		if hasValue {
+			return nil, errValueNotAllowedWithBuiltInFunction
+		}
+
+		args, err := decodeArgumentsOfTransactionData(parts[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		return extension.parseTransfersOfBuiltInFunction(function, sender, receiver, args)
+	case relayedTransactionV1Function:
+		if isInner {
+			return nil, errNestedRelayedTransaction
+		}
+
+		return extension.parseTransfersOfRelayedV1(sender, receiver, value, parts[1:])
+	case relayedTransactionV2Function:
+		if isInner {
+			return nil, errNestedRelayedTransaction
+		}
+		if hasValue {
+			return nil, fmt.Errorf("%w: value must be zero", errCannotParseRelayedV2)
+		}
+
+		return extension.parseTransfersOfRelayedV2(receiver, parts[1:])
+	default:
+		// Native transfers and contract calls. Plain transfers of zero value between users are kept as (zero) transfers.
+		if !hasValue && extension.isContractAddress(receiver) {
+			return []*eventESDT{}, nil
+		}
+
+		return []*eventESDT{newNativeTransfer(sender, receiver, value)}, nil
+	}
+}
+
+func (extension *networkProviderExtension) parseTransfersOfBuiltInFunction(function string, sender string, receiver string, args [][]byte) ([]*eventESDT, error) {
+	switch function {
+	case builtInFunctionESDTTransfer:
+		// ESDTTransfer@token@amount[@function@args...]
+		if len(args) < numArgumentsOfESDTTransfer {
+			return nil, fmt.Errorf("%w: bad number of arguments for %s", errMalformedTransactionData, function)
+		}
+
+		return []*eventESDT{
+			{
+				senderAddress:   sender,
+				receiverAddress: receiver,
+				identifier:      string(args[0]),
+				value:           big.NewInt(0).SetBytes(args[1]).String(),
+			},
+		}, nil
+	case builtInFunctionESDTNFTTransfer:
+		// ESDTNFTTransfer@token@nonce@quantity@destination[@function@args...], sent to self
+		if len(args) < numArgumentsOfESDTNFTTransfer {
+			return nil, fmt.Errorf("%w: bad number of arguments for %s", errMalformedTransactionData, function)
+		}
+
+		destination, err := extension.decodeDestinationOfTransfer(sender, receiver, args[indexOfDestinationInArgumentsOfESDTNFTTransfer])
+		if err != nil {
+			return nil, err
+		}
+
+		return []*eventESDT{
+			{
+				senderAddress:   sender,
+				receiverAddress: destination,
+				identifier:      string(args[0]),
+				nonceAsBytes:    args[1],
+				value:           big.NewInt(0).SetBytes(args[2]).String(),
+			},
+		}, nil
+	case builtInFunctionMultiESDTNFTTransfer:
+		// MultiESDTNFTTransfer@destination@numTransfers(@token@nonce@amount)+[@function@args...], sent to self
+		if len(args) < numArgumentsOfMultiESDTNFTTransferHeader {
+			return nil, fmt.Errorf("%w: bad number of arguments for %s", errMalformedTransactionData, function)
+		}
+
+		destination, err := extension.decodeDestinationOfTransfer(sender, receiver, args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		numTransfers := big.NewInt(0).SetBytes(args[1])
+		maxNumTransfers := (len(args) - numArgumentsOfMultiESDTNFTTransferHeader) / numArgumentsPerTransferOfMultiESDTNFTTransfer
+		if !numTransfers.IsUint64() || numTransfers.Uint64() > uint64(maxNumTransfers) {
+			return nil, fmt.Errorf("%w: bad number of transfers for %s", errMalformedTransactionData, function)
+		}
+
+		transfers := make([]*eventESDT, 0, numTransfers.Uint64())
+
+		for i := 0; i < int(numTransfers.Uint64()); i++ {
+			offset := numArgumentsOfMultiESDTNFTTransferHeader + i*numArgumentsPerTransferOfMultiESDTNFTTransfer
+
+			transfers = append(transfers, &eventESDT{
+				senderAddress:   sender,
+				receiverAddress: destination,
+				identifier:      string(args[offset]),
+				nonceAsBytes:    args[offset+1],
+				value:           big.NewInt(0).SetBytes(args[offset+2]).String(),
+			})
+		}
+
+		return transfers, nil
+	default:
+		return nil, fmt.Errorf("%w: unexpected function %s", errMalformedTransactionData, function)
+	}
+}
+
+// parseTransfersOfRelayedV1 handles "relayedTx@{inner transaction as hex-encoded JSON}".
+// The value of the relayed transaction is first transferred from the relayer to the sender of the inner transaction.
+func (extension *networkProviderExtension) parseTransfersOfRelayedV1(relayer string, receiver string, value string, args []string) ([]*eventESDT, error) {
+	if len(args) != numArgumentsOfRelayedV1 {
+		return nil, errCannotParseRelayedV1
+	}
+
+	innerTxPayload, err := hex.DecodeString(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errCannotParseRelayedV1, err)
+	}
+
+	var innerTx innerTransactionOfRelayedV1
+
+	err = json.Unmarshal(innerTxPayload, &innerTx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errCannotParseRelayedV1, err)
+	}
+
+	innerSender := extension.provider.ConvertPubKeyToAddress(innerTx.SenderPubKey)
+	innerReceiver := extension.provider.ConvertPubKeyToAddress(innerTx.ReceiverPubKey)
+	if innerSender != receiver {
+		return nil, fmt.Errorf("%w: inner sender must be the receiver of the relayed transaction", errCannotParseRelayedV1)
+	}
+
+	innerTransfers, err := extension.parseTransfersOfTransaction(innerSender, innerReceiver, innerTx.Value.String(), innerTx.Data, true)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := make([]*eventESDT, 0, len(innerTransfers)+1)
+	valueBig, ok := big.NewInt(0).SetString(value, 10)
+	if ok && valueBig.Sign() > 0 {
+		transfers = append(transfers, newNativeTransfer(relayer, receiver, value))
+	}
+
+	return append(transfers, innerTransfers...), nil
+}
+
+// parseTransfersOfRelayedV2 handles "relayedTxV2@innerReceiver@innerNonce@innerData@innerSignature".
+// The sender of the inner transaction is the receiver of the relayed transaction. The inner transaction holds no value.
+func (extension *networkProviderExtension) parseTransfersOfRelayedV2(innerSender string, args []string) ([]*eventESDT, error) {
+	if len(args) != numArgumentsOfRelayedV2 {
+		return nil, errCannotParseRelayedV2
+	}
+
+	decodedArgs, err := decodeArgumentsOfTransactionData(args)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errCannotParseRelayedV2, err)
+	}
+
+	innerReceiver := extension.provider.ConvertPubKeyToAddress(decodedArgs[indexOfInnerReceiverInArgumentsOfRelayedV2])
+	innerData := decodedArgs[indexOfInnerDataInArgumentsOfRelayedV2]
+
+	return extension.parseTransfersOfTransaction(innerSender, innerReceiver, amountZero, innerData, true)
+}
+
+// decodeDestinationOfTransfer is used for "ESDTNFTTransfer" and "MultiESDTNFTTransfer", which must be sent to self.
+func (extension *networkProviderExtension) decodeDestinationOfTransfer(sender string, receiver string, destinationPubKey []byte) (string, error) {
+	if sender != receiver {
+		return "", fmt.Errorf("%w: transaction must be sent to self", errMalformedTransactionData)
+	}
+
+	destination := extension.provider.ConvertPubKeyToAddress(destinationPubKey)
+	if len(destination) == 0 {
+		return "", fmt.Errorf("%w: bad destination", errMalformedTransactionData)
+	}
+
+	return destination, nil
+}
+
+func (extension *networkProviderExtension) transferToOperations(transfer *eventESDT) []*types.Operation {
+	if transfer.identifier == nativeAsESDTIdentifier {
+		return []*types.Operation{
+			{
+				Type:    opTransfer,
+				Account: addressToAccountIdentifier(transfer.senderAddress),
+				Amount:  extension.valueToNativeAmount("-" + transfer.value),
+			},
+			{
+				Type:    opTransfer,
+				Account: addressToAccountIdentifier(transfer.receiverAddress),
+				Amount:  extension.valueToNativeAmount(transfer.value),
+			},
+		}
+	}
+
+	currencySymbol := transfer.getExtendedIdentifier()
+
+	return []*types.Operation{
+		{
+			Type:    opCustomTransfer,
+			Account: addressToAccountIdentifier(transfer.senderAddress),
+			Amount:  extension.valueToCustomAmount("-"+transfer.value, currencySymbol),
+		},
+		{
+			Type:    opCustomTransfer,
+			Account: addressToAccountIdentifier(transfer.receiverAddress),
+			Amount:  extension.valueToCustomAmount(transfer.value, currencySymbol),
+		},
+	}
+}
+
+func newNativeTransfer(sender string, receiver string, value string) *eventESDT {
+	return &eventESDT{
+		senderAddress:   sender,
+		receiverAddress: receiver,
+		identifier:      nativeAsESDTIdentifier,
+		value:           value,
+	}
+}
+
+func decodeArgumentsOfTransactionData(args []string) ([][]byte, error) {
+	decodedArgs := make([][]byte, 0, len(args))
+
+	for _, arg := range args {
+		decodedArg, err := hex.DecodeString(arg)
+		if err != nil {
+			return nil, fmt.Errorf("%w: bad argument: %s", errMalformedTransactionData, arg)
+		}
+
+		decodedArgs = append(decodedArgs, decodedArg)
+	}
+
+	return decodedArgs, nil
+}
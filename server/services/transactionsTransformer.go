@@ -1,7 +1,10 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
+	"strings"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/multiversx/mx-chain-core-go/data/api"
@@ -300,22 +303,39 @@ func (transformer *transactionsTransformer) invalidTxToRosettaTx(tx *transaction
 	}
 }
 
-func (transformer *transactionsTransformer) mempoolMoveBalanceTxToRosettaTx(tx *transaction.ApiTransactionResult) *types.Transaction {
-	hasValue := isNonZeroAmount(tx.Value)
+// mempoolTxToRosettaTx describes the expected effects of a pending transaction: the requested transfers (native or custom) and the (estimated) fee.
+// Since the transaction isn't executed yet, the operations are marked as unconfirmed.
+func (transformer *transactionsTransformer) mempoolTxToRosettaTx(tx *transaction.ApiTransactionResult) (*types.Transaction, error) {
 	operations := make([]*types.Operation, 0)
 
-	if hasValue {
-		operations = append(operations, &types.Operation{
-			Type:    opTransfer,
-			Account: addressToAccountIdentifier(tx.Sender),
-			Amount:  transformer.extension.valueToNativeAmount("-" + tx.Value),
-		})
+	transfers, err := transformer.extension.parseTransfersOfTransaction(tx.Sender, tx.Receiver, tx.Value, tx.Data, false)
+	if err != nil && !errors.Is(err, errCannotParseContractDeployment) {
+		// The data cannot be understood (e.g. malformed arguments of a token transfer): only the native transfer (if any) and the fee are reported.
+		log.Debug("transactionsTransformer.mempoolTxToRosettaTx(): cannot parse transfers, falling back to the native transfer", "hash", tx.Hash, "err", err)
 
-		operations = append(operations, &types.Operation{
-			Type:    opTransfer,
-			Account: addressToAccountIdentifier(tx.Receiver),
-			Amount:  transformer.extension.valueToNativeAmount(tx.Value),
-		})
+		transfers, err = transformer.extension.parseTransfersOfTransaction(tx.Sender, tx.Receiver, tx.Value, nil, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// For contract deployments, the address of the new contract isn't known in advance, thus only the fee is reported.
+	for _, transfer := range transfers {
+		operations = append(operations, transformer.extension.transferToOperations(transfer)...)
+	}
+
+	feePayer := transformer.decideFeePayer(tx)
+	fee := transformer.estimateFeeOfMempoolTx(tx)
+	operations = append(operations, &types.Operation{
+		Type:    opFee,
+		Account: addressToAccountIdentifier(feePayer),
+		Amount:  transformer.extension.valueToNativeAmount("-" + fee.String()),
+	})
+
+	for _, operation := range operations {
+		operation.Metadata = objectsMap{
+			"unconfirmed": true,
+		}
 	}
 
 	indexOperations(operations)
@@ -324,6 +344,43 @@ func (transformer *transactionsTransformer) mempoolMoveBalanceTxToRosettaTx(tx *
 		TransactionIdentifier: hashToTransactionIdentifier(tx.Hash),
 		Operations:            operations,
 		Metadata:              extractTransactionMetadata(tx),
+	}, nil
+}
+
+// estimateFeeOfMempoolTx computes the fee of a move-balance transaction. For transactions that require execution (contract calls, built-in function calls,
+// relayed V1 & V2 transactions), it assumes that the whole gas limit is consumed (thus it gives an upper bound, since the unconsumed gas is refunded).
+func (transformer *transactionsTransformer) estimateFeeOfMempoolTx(tx *transaction.ApiTransactionResult) *big.Int {
+	moveBalanceFee := transformer.provider.ComputeTransactionFeeForMoveBalance(tx)
+	if !transformer.requiresExecution(tx) || tx.GasPrice == 0 {
+		return moveBalanceFee
+	}
+
+	movementGasLimit := big.NewInt(0).Div(moveBalanceFee, big.NewInt(0).SetUint64(tx.GasPrice)).Uint64()
+	if tx.GasLimit <= movementGasLimit {
+		return moveBalanceFee
+	}
+
+	executionGasLimit := tx.GasLimit - movementGasLimit
+	gasPriceModifier := transformer.provider.GetNetworkConfig().GasPriceModifier
+	return computeFee(movementGasLimit, executionGasLimit, tx.GasPrice, gasPriceModifier)
+}
+
+func (transformer *transactionsTransformer) requiresExecution(tx *transaction.ApiTransactionResult) bool {
+	if len(tx.Data) == 0 {
+		return false
+	}
+	if transformer.extension.isContractAddress(tx.Receiver) {
+		return true
+	}
+
+	function := strings.Split(string(tx.Data), argumentsSeparator)[0]
+
+	switch function {
+	case builtInFunctionESDTTransfer, builtInFunctionESDTNFTTransfer, builtInFunctionMultiESDTNFTTransfer,
+		relayedTransactionV1Function, relayedTransactionV2Function:
+		return true
+	default:
+		return false
 	}
 }
 
@@ -1,11 +1,18 @@
 package testscommon
 
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+)
+
 var (
 	// TODO: use "testAccount", instead
 	// TestAddressAlice is a test address
 	TestAddressAlice = "erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th"
 	// TestPubKeyAlice is a test pubkey
 	TestPubKeyAlice, _ = RealWorldBech32PubkeyConverter.Decode(TestAddressAlice)
+	// TestPrivateKeyAlice is the (Ed25519) private key of Alice, from the well-known test wallets
+	TestPrivateKeyAlice = ed25519.NewKeyFromSeed(mustDecodeHex("413f42575f7f26fad3317a778771212fdb80245850981e48b58a4f25e344e8f9"))
 
 	// TODO: use "testAccount", instead
 	// TestAddressBob is a test address
@@ -70,3 +77,12 @@ func newTestAccount(address string) *testAccount {
 		PubKey:  pubKey,
 	}
 }
+
+func mustDecodeHex(encoded string) []byte {
+	decoded, err := hex.DecodeString(encoded)
+	if err != nil {
+		panic(err)
+	}
+
+	return decoded
+}
@@ -1,6 +1,7 @@
 package testscommon
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"strings"
@@ -23,6 +24,7 @@ type networkProviderMock struct {
 	pubKeyConverter core.PubkeyConverter
 
 	MockIsOffline                   bool
+	MockShouldSimulateBeforeSubmit  bool
 	MockNumShards                   uint32
 	MockObservedActualShard         uint32
 	MockObservedProjectedShard      uint32
@@ -38,14 +40,23 @@ type networkProviderMock struct {
 	MockBlocksByHash                map[string]*api.Block
 	MockNextAccountBlockCoordinates *resources.BlockCoordinates
 	MockAccountsByAddress           map[string]*resources.Account
+	MockLatestNoncesByAddress       map[string]uint64
 	MockAccountsNativeBalances      map[string]*resources.AccountBalanceOnBlock
 	MockAccountsCustomBalances      map[string]*resources.AccountBalanceOnBlock
 	MockMempoolTransactionsByHash   map[string]*transaction.ApiTransactionResult
+	MockPoolNoncesBySender          map[string][]uint64
+	MockTransactionsInPool          []*resources.TransactionInPool
+	MockSubmittedTransactions       map[string]*resources.SubmittedTransaction
+	MockUsernames                   map[string]string
+	MockTokensProperties            map[string]*resources.TokenProperties
 	MockComputedTransactionHash     string
 	MockComputedReceiptHash         string
 	MockNextError                   error
 
-	SendTransactionCalled func(tx *data.Transaction) (string, error)
+	SendTransactionCalled        func(tx *data.Transaction) (string, error)
+	EstimateTransactionGasCalled func(tx *data.Transaction) (uint64, error)
+	SimulateTransactionCalled    func(tx *data.Transaction) (*resources.TransactionSimulationResults, error)
+	QueryContractCalled          func(query *data.VmValueRequest, options resources.AccountQueryOptions) (*resources.VMQueryOutput, error)
 }
 
 // NewNetworkProviderMock -
@@ -98,9 +109,14 @@ func NewNetworkProviderMock() *networkProviderMock {
 			Hash:  emptyHash,
 		},
 		MockAccountsByAddress:         make(map[string]*resources.Account),
+		MockLatestNoncesByAddress:     make(map[string]uint64),
 		MockAccountsNativeBalances:    make(map[string]*resources.AccountBalanceOnBlock),
 		MockAccountsCustomBalances:    make(map[string]*resources.AccountBalanceOnBlock),
 		MockMempoolTransactionsByHash: make(map[string]*transaction.ApiTransactionResult),
+		MockPoolNoncesBySender:        make(map[string][]uint64),
+		MockSubmittedTransactions:     make(map[string]*resources.SubmittedTransaction),
+		MockUsernames:                 make(map[string]string),
+		MockTokensProperties:          make(map[string]*resources.TokenProperties),
 		MockComputedTransactionHash:   emptyHash,
 		MockNextError:                 nil,
 	}
@@ -111,6 +127,11 @@ func (mock *networkProviderMock) IsOffline() bool {
 	return mock.MockIsOffline
 }
 
+// ShouldSimulateBeforeSubmit -
+func (mock *networkProviderMock) ShouldSimulateBeforeSubmit() bool {
+	return mock.MockShouldSimulateBeforeSubmit
+}
+
 // GetBlockchainName -
 func (mock *networkProviderMock) GetBlockchainName() string {
 	return mock.MockNetworkConfig.BlockchainName
@@ -166,7 +187,7 @@ func (mock *networkProviderMock) GetGenesisTimestamp() int64 {
 }
 
 // GetGenesisBalances -
-func (mock *networkProviderMock) GetGenesisBalances() ([]*resources.GenesisBalance, error) {
+func (mock *networkProviderMock) GetGenesisBalances(_ context.Context) ([]*resources.GenesisBalance, error) {
 	if mock.MockNextError != nil {
 		return nil, mock.MockNextError
 	}
@@ -175,7 +196,7 @@ func (mock *networkProviderMock) GetGenesisBalances() ([]*resources.GenesisBalan
 }
 
 // GetNodeStatus -
-func (mock *networkProviderMock) GetNodeStatus() (*resources.AggregatedNodeStatus, error) {
+func (mock *networkProviderMock) GetNodeStatus(_ context.Context) (*resources.AggregatedNodeStatus, error) {
 	if mock.MockNextError != nil {
 		return nil, mock.MockNextError
 	}
@@ -183,8 +204,18 @@ func (mock *networkProviderMock) GetNodeStatus() (*resources.AggregatedNodeStatu
 	return mock.MockNodeStatus, nil
 }
 
+// GetLatestBlockSummary -
+func (mock *networkProviderMock) GetLatestBlockSummary(_ context.Context) (*resources.BlockSummary, error) {
+	if mock.MockNextError != nil {
+		return nil, mock.MockNextError
+	}
+
+	summary := mock.MockNodeStatus.LatestBlock
+	return &summary, nil
+}
+
 // GetBlockByNonce -
-func (mock *networkProviderMock) GetBlockByNonce(nonce uint64) (*api.Block, error) {
+func (mock *networkProviderMock) GetBlockByNonce(_ context.Context, nonce uint64) (*api.Block, error) {
 	if mock.MockNextError != nil {
 		return nil, mock.MockNextError
 	}
@@ -197,8 +228,24 @@ func (mock *networkProviderMock) GetBlockByNonce(nonce uint64) (*api.Block, erro
 	return nil, fmt.Errorf("block %d not found", nonce)
 }
 
+// GetBlockSummaryByNonce -
+func (mock *networkProviderMock) GetBlockSummaryByNonce(ctx context.Context, nonce uint64) (*resources.BlockSummary, error) {
+	block, err := mock.GetBlockByNonce(ctx, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resources.BlockSummary{
+		Nonce:             block.Nonce,
+		Hash:              block.Hash,
+		PreviousBlockHash: block.PrevBlockHash,
+		Timestamp:         block.Timestamp,
+		TimestampMs:       block.TimestampMs,
+	}, nil
+}
+
 // GetBlockByHash -
-func (mock *networkProviderMock) GetBlockByHash(hash string) (*api.Block, error) {
+func (mock *networkProviderMock) GetBlockByHash(_ context.Context, hash string) (*api.Block, error) {
 	if mock.MockNextError != nil {
 		return nil, mock.MockNextError
 	}
@@ -212,7 +259,7 @@ func (mock *networkProviderMock) GetBlockByHash(hash string) (*api.Block, error)
 }
 
 // GetAccount -
-func (mock *networkProviderMock) GetAccount(address string) (*resources.AccountOnBlock, error) {
+func (mock *networkProviderMock) GetAccount(_ context.Context, address string) (*resources.AccountOnBlock, error) {
 	if mock.MockNextError != nil {
 		return nil, mock.MockNextError
 	}
@@ -228,7 +275,27 @@ func (mock *networkProviderMock) GetAccount(address string) (*resources.AccountO
 	return nil, fmt.Errorf("account %s not found", address)
 }
 
-func (mock *networkProviderMock) GetAccountBalance(address string, tokenIdentifier string, _ resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error) {
+// GetAccountNonceOnLatestBlock -
+func (mock *networkProviderMock) GetAccountNonceOnLatestBlock(_ context.Context, address string) (uint64, error) {
+	if mock.MockNextError != nil {
+		return 0, mock.MockNextError
+	}
+
+	nonce, ok := mock.MockLatestNoncesByAddress[address]
+	if ok {
+		return nonce, nil
+	}
+
+	// By default, the latest block and the final block agree.
+	account, ok := mock.MockAccountsByAddress[address]
+	if ok {
+		return account.Nonce, nil
+	}
+
+	return 0, fmt.Errorf("account %s not found", address)
+}
+
+func (mock *networkProviderMock) GetAccountBalance(_ context.Context, address string, tokenIdentifier string, _ resources.AccountQueryOptions) (*resources.AccountBalanceOnBlock, error) {
 	if mock.MockNextError != nil {
 		return nil, mock.MockNextError
 	}
@@ -312,6 +379,25 @@ func (mock *networkProviderMock) ComputeTransactionHash(_ *data.Transaction) (st
 	return mock.MockComputedTransactionHash, nil
 }
 
+// EstimateTransactionGas -
+func (mock *networkProviderMock) EstimateTransactionGas(_ context.Context, tx *data.Transaction) (uint64, error) {
+	if mock.MockNextError != nil {
+		return 0, mock.MockNextError
+	}
+
+	if mock.EstimateTransactionGasCalled != nil {
+		return mock.EstimateTransactionGasCalled(tx)
+	}
+
+	// By default, we emulate the static estimation (move balance, possibly with a custom transfer).
+	gasLimit := mock.MockNetworkConfig.MinGasLimit + mock.MockNetworkConfig.GasPerDataByte*uint64(len(tx.Data))
+	if strings.HasPrefix(string(tx.Data), core.BuiltInFunctionESDTTransfer) {
+		gasLimit += mock.MockNetworkConfig.GasLimitCustomTransfer
+	}
+
+	return gasLimit, nil
+}
+
 // ComputeReceiptHash -
 func (mock *networkProviderMock) ComputeReceiptHash(_ *transaction.ApiReceipt) (string, error) {
 	if mock.MockNextError != nil {
@@ -343,8 +429,23 @@ func (mock *networkProviderMock) ComputeTransactionFeeForMoveBalance(tx *transac
 	return fee
 }
 
+// SimulateTransaction -
+func (mock *networkProviderMock) SimulateTransaction(_ context.Context, tx *data.Transaction) (*resources.TransactionSimulationResults, error) {
+	if mock.MockNextError != nil {
+		return nil, mock.MockNextError
+	}
+
+	if mock.SimulateTransactionCalled != nil {
+		return mock.SimulateTransactionCalled(tx)
+	}
+
+	results := &resources.TransactionSimulationResults{}
+	results.Status = transaction.TxStatusSuccess
+	return results, nil
+}
+
 // SendTransaction -
-func (mock *networkProviderMock) SendTransaction(tx *data.Transaction) (string, error) {
+func (mock *networkProviderMock) SendTransaction(_ context.Context, tx *data.Transaction) (string, error) {
 	if mock.MockNextError != nil {
 		return "", mock.MockNextError
 	}
@@ -356,8 +457,14 @@ func (mock *networkProviderMock) SendTransaction(tx *data.Transaction) (string,
 	return mock.MockComputedTransactionHash, nil
 }
 
+// GetSubmittedTransaction -
+func (mock *networkProviderMock) GetSubmittedTransaction(hash string) (*resources.SubmittedTransaction, bool) {
+	submission, ok := mock.MockSubmittedTransactions[hash]
+	return submission, ok
+}
+
 // GetMempoolTransactionByHash -
-func (mock *networkProviderMock) GetMempoolTransactionByHash(hash string) (*transaction.ApiTransactionResult, error) {
+func (mock *networkProviderMock) GetMempoolTransactionByHash(_ context.Context, hash string) (*transaction.ApiTransactionResult, error) {
 	if mock.MockNextError != nil {
 		return nil, mock.MockNextError
 	}
@@ -369,3 +476,64 @@ func (mock *networkProviderMock) GetMempoolTransactionByHash(hash string) (*tran
 
 	return nil, nil
 }
+
+// GetTransactionsPoolNoncesForSender -
+func (mock *networkProviderMock) GetTransactionsPoolNoncesForSender(_ context.Context, sender string) ([]uint64, error) {
+	if mock.MockNextError != nil {
+		return nil, mock.MockNextError
+	}
+
+	return mock.MockPoolNoncesBySender[sender], nil
+}
+
+// GetTransactionsInPool -
+func (mock *networkProviderMock) GetTransactionsInPool(_ context.Context) ([]*resources.TransactionInPool, error) {
+	if mock.MockNextError != nil {
+		return nil, mock.MockNextError
+	}
+
+	return mock.MockTransactionsInPool, nil
+}
+
+// ResolveUsername -
+func (mock *networkProviderMock) ResolveUsername(_ context.Context, username string) (string, error) {
+	if mock.MockNextError != nil {
+		return "", mock.MockNextError
+	}
+
+	address, ok := mock.MockUsernames[username]
+	if !ok {
+		return "", fmt.Errorf("username not found: %s", username)
+	}
+
+	return address, nil
+}
+
+// QueryContract -
+func (mock *networkProviderMock) QueryContract(_ context.Context, query *data.VmValueRequest, options resources.AccountQueryOptions) (*resources.VMQueryOutput, error) {
+	if mock.MockNextError != nil {
+		return nil, mock.MockNextError
+	}
+
+	if mock.QueryContractCalled != nil {
+		return mock.QueryContractCalled(query, options)
+	}
+
+	return &resources.VMQueryOutput{
+		ReturnCode: "ok",
+	}, nil
+}
+
+// GetTokenProperties -
+func (mock *networkProviderMock) GetTokenProperties(_ context.Context, tokenIdentifier string) (*resources.TokenProperties, error) {
+	if mock.MockNextError != nil {
+		return nil, mock.MockNextError
+	}
+
+	properties, ok := mock.MockTokensProperties[tokenIdentifier]
+	if !ok {
+		return nil, fmt.Errorf("token not found: %s", tokenIdentifier)
+	}
+
+	return properties, nil
+}
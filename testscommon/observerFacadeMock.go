@@ -1,6 +1,7 @@
 package testscommon
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -15,6 +16,7 @@ type observerFacadeMock struct {
 	MockNumShards               uint32
 	MockSelfShard               uint32
 	MockGetResponse             interface{}
+	MockPostResponse            interface{}
 	MockAccount                 *data.AccountModel
 	MockComputedTransactionHash string
 	MockNextError               error
@@ -24,13 +26,15 @@ type observerFacadeMock struct {
 	MockTransactionsByHash map[string]*transaction.ApiTransactionResult
 	MockBlocks             []*api.Block
 
-	GetBlockByNonceCalled     func(shardID uint32, nonce uint64, options common.BlockQueryOptions) (*data.BlockApiResponse, error)
-	GetBlockByHashCalled      func(shardID uint32, hash string, options common.BlockQueryOptions) (*data.BlockApiResponse, error)
-	CallGetRestEndPointCalled func(baseUrl string, path string, value interface{}) (int, error)
-	SendTransactionCalled     func(tx *data.Transaction) (int, string, error)
+	GetBlockByNonceCalled      func(shardID uint32, nonce uint64, options common.BlockQueryOptions) (*data.BlockApiResponse, error)
+	GetBlockByHashCalled       func(shardID uint32, hash string, options common.BlockQueryOptions) (*data.BlockApiResponse, error)
+	CallGetRestEndPointCalled  func(baseUrl string, path string, value interface{}) (int, error)
+	CallPostRestEndPointCalled func(baseUrl string, path string, data interface{}, response interface{}) (int, error)
+	SendTransactionCalled      func(tx *data.Transaction) (int, string, error)
 
-	RecordedBaseUrl string
-	RecordedPath    string
+	RecordedBaseUrl     string
+	RecordedPath        string
+	RecordedPostPayload interface{}
 }
 
 // NewObserverFacadeMock -
@@ -52,7 +56,7 @@ func NewObserverFacadeMock() *observerFacadeMock {
 }
 
 // CallGetRestEndPoint -
-func (mock *observerFacadeMock) CallGetRestEndPoint(baseUrl string, path string, value interface{}) (int, error) {
+func (mock *observerFacadeMock) CallGetRestEndPoint(_ context.Context, baseUrl string, path string, value interface{}) (int, error) {
 	mock.RecordedBaseUrl = baseUrl
 	mock.RecordedPath = path
 
@@ -77,6 +81,33 @@ func (mock *observerFacadeMock) CallGetRestEndPoint(baseUrl string, path string,
 	return 200, nil
 }
 
+// CallPostRestEndPoint -
+func (mock *observerFacadeMock) CallPostRestEndPoint(_ context.Context, baseUrl string, path string, data interface{}, response interface{}) (int, error) {
+	mock.RecordedBaseUrl = baseUrl
+	mock.RecordedPath = path
+	mock.RecordedPostPayload = data
+
+	if mock.CallPostRestEndPointCalled != nil {
+		return mock.CallPostRestEndPointCalled(baseUrl, path, data, response)
+	}
+
+	if mock.MockNextError != nil {
+		return 0, mock.MockNextError
+	}
+
+	marshalledData, err := json.Marshal(mock.MockPostResponse)
+	if err != nil {
+		return 500, err
+	}
+
+	err = json.Unmarshal(marshalledData, response)
+	if err != nil {
+		return 500, err
+	}
+
+	return 200, nil
+}
+
 // ComputeShardId -
 func (mock *observerFacadeMock) ComputeShardId(pubKey []byte) uint32 {
 	shardCoordinator, err := sharding.NewMultiShardCoordinator(mock.MockNumShards, mock.MockSelfShard)
@@ -89,7 +120,7 @@ func (mock *observerFacadeMock) ComputeShardId(pubKey []byte) uint32 {
 }
 
 // SendTransaction -
-func (mock *observerFacadeMock) SendTransaction(tx *data.Transaction) (int, string, error) {
+func (mock *observerFacadeMock) SendTransaction(_ context.Context, tx *data.Transaction) (int, string, error) {
 	if mock.MockNextError != nil {
 		return 500, "", mock.MockNextError
 	}
@@ -111,7 +142,7 @@ func (mock *observerFacadeMock) ComputeTransactionHash(_ *data.Transaction) (str
 }
 
 // GetTransactionByHashAndSenderAddress -
-func (mock *observerFacadeMock) GetTransactionByHashAndSenderAddress(hash string, _ string, _ bool) (*transaction.ApiTransactionResult, int, error) {
+func (mock *observerFacadeMock) GetTransactionByHashAndSenderAddress(_ context.Context, hash string, _ string, _ bool) (*transaction.ApiTransactionResult, int, error) {
 	if mock.MockNextError != nil {
 		return nil, 0, mock.MockNextError
 	}
@@ -125,7 +156,7 @@ func (mock *observerFacadeMock) GetTransactionByHashAndSenderAddress(hash string
 }
 
 // GetBlockByHash -
-func (mock *observerFacadeMock) GetBlockByHash(shardID uint32, hash string, options common.BlockQueryOptions) (*data.BlockApiResponse, error) {
+func (mock *observerFacadeMock) GetBlockByHash(_ context.Context, shardID uint32, hash string, options common.BlockQueryOptions) (*data.BlockApiResponse, error) {
 	if mock.GetBlockByHashCalled != nil {
 		return mock.GetBlockByHashCalled(shardID, hash, options)
 	}
@@ -157,7 +188,7 @@ func (mock *observerFacadeMock) GetBlockByHash(shardID uint32, hash string, opti
 }
 
 // GetBlockByNonce -
-func (mock *observerFacadeMock) GetBlockByNonce(shardID uint32, nonce uint64, options common.BlockQueryOptions) (*data.BlockApiResponse, error) {
+func (mock *observerFacadeMock) GetBlockByNonce(_ context.Context, shardID uint32, nonce uint64, options common.BlockQueryOptions) (*data.BlockApiResponse, error) {
 	if mock.GetBlockByNonceCalled != nil {
 		return mock.GetBlockByNonceCalled(shardID, nonce, options)
 	}